@@ -39,3 +39,14 @@ func (_m *MockNetwork) Interfaces() ([]*network.Interface, error) {
 func (_mr *_MockNetworkRecorder) Interfaces() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Interfaces")
 }
+
+func (_m *MockNetwork) Routes() ([]*network.Route, error) {
+	ret := _m.ctrl.Call(_m, "Routes")
+	ret0, _ := ret[0].([]*network.Route)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockNetworkRecorder) Routes() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Routes")
+}
@@ -10,6 +10,7 @@ import (
 //go:generate mockgen -package mocks -destination mocks/network.go github.com/pivotal-cf/pcfdev-cli/address Network
 type Network interface {
 	Interfaces() (interfaces []*network.Interface, err error)
+	Routes() (routes []*network.Route, err error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/driver.go github.com/pivotal-cf/pcfdev-cli/address Driver
@@ -17,9 +18,22 @@ type Driver interface {
 	IsInterfaceInUse(interfaceName string) (inUse bool, err error)
 }
 
+type Candidate struct {
+	Subnet    string
+	IP        string
+	Domain    string
+	Interface *network.Interface
+	Conflicts []string
+}
+
+func (c *Candidate) Available() bool {
+	return len(c.Conflicts) == 0
+}
+
 type Picker struct {
 	Network Network
 	Driver  Driver
+	Table   *Table
 }
 
 func (p *Picker) SelectAvailableInterface(reusableInterfaces []*network.Interface, config *cfg.VMConfig) (*cfg.NetworkConfig, error) {
@@ -34,17 +48,20 @@ func (p *Picker) SelectAvailableInterface(reusableInterfaces []*network.Interfac
 			}
 			ip = config.IP
 		} else {
-			subnetIP, err = SubnetForDomain(config.Domain)
+			ip, err = p.Table.IPForDomain(config.Domain)
+			if err != nil {
+				return nil, err
+			}
+			subnetIP, err = SubnetForIP(ip)
 			if err != nil {
 				return nil, err
 			}
-			ip = IPForSubnet(subnetIP)
 		}
 
 		if config.Domain != "" {
 			domain = config.Domain
 		} else {
-			domain = DomainForIP(ip)
+			domain = p.Table.DomainForIP(ip)
 		}
 
 		var networkInterface *network.Interface
@@ -64,29 +81,77 @@ func (p *Picker) SelectAvailableInterface(reusableInterfaces []*network.Interfac
 		}, nil
 	}
 
+	candidates, err := p.check(reusableInterfaces, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		if candidate.Available() {
+			return &cfg.NetworkConfig{
+				VMIP:      candidate.IP,
+				VMDomain:  candidate.Domain,
+				Interface: candidate.Interface,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("all allowed network interfaces are currently taken")
+}
+
+func (p *Picker) DomainForIP(ip string) string {
+	return p.Table.DomainForIP(ip)
+}
+
+func (p *Picker) IsPCFDevSubnet(subnet string) bool {
+	return p.Table.HasSubnet(subnet)
+}
+
+func (p *Picker) Check(reusableInterfaces []*network.Interface) ([]*Candidate, error) {
+	return p.check(reusableInterfaces, false)
+}
+
+func (p *Picker) check(reusableInterfaces []*network.Interface, stopWhenAvailable bool) ([]*Candidate, error) {
 	allInterfaces, err := p.Network.Interfaces()
 	if err != nil {
 		return nil, err
 	}
 
-	for _, subnetIP := range allowedSubnets {
-		if p.nonReusableInterfaceExists(subnetIP, reusableInterfaces, allInterfaces) {
+	routes, err := p.Network.Routes()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []*Candidate
+	for _, mapping := range p.Table.Candidates() {
+		subnetIP, err := SubnetForIP(mapping.IP)
+		if err != nil {
+			return nil, err
+		}
+
+		candidate := &Candidate{
+			Subnet: subnetIP,
+			IP:     mapping.IP,
+			Domain: mapping.Domain,
+		}
+		candidates = append(candidates, candidate)
+
+		candidate.Conflicts, err = network.Conflicts(subnetIP, allInterfaces, routes, reusableInterfaces)
+		if err != nil {
+			return nil, err
+		}
+		if len(candidate.Conflicts) > 0 {
 			continue
 		}
 
 		matchingAddrs := p.addrsInSet(subnetIP, reusableInterfaces)
-		domain := DomainForIP(IPForSubnet(subnetIP))
 
 		switch len(matchingAddrs) {
 		case 0:
-			return &cfg.NetworkConfig{
-				VMIP:     IPForSubnet(subnetIP),
-				VMDomain: domain,
-				Interface: &network.Interface{
-					IP:     subnetIP,
-					Exists: false,
-				},
-			}, nil
+			candidate.Interface = &network.Interface{
+				IP:     subnetIP,
+				Exists: false,
+			}
 		case 1:
 			inUse, err := p.Driver.IsInterfaceInUse(matchingAddrs[0].Name)
 			if err != nil {
@@ -94,18 +159,21 @@ func (p *Picker) SelectAvailableInterface(reusableInterfaces []*network.Interfac
 			}
 
 			if inUse {
+				candidate.Conflicts = append(candidate.Conflicts, fmt.Sprintf("host-only interface %s is in use by another VM", matchingAddrs[0].Name))
 				continue
 			}
 
-			return &cfg.NetworkConfig{
-				VMIP:      IPForSubnet(subnetIP),
-				VMDomain:  domain,
-				Interface: matchingAddrs[0],
-			}, nil
+			candidate.Interface = matchingAddrs[0]
+		default:
+			candidate.Conflicts = append(candidate.Conflicts, fmt.Sprintf("%d host-only interfaces are configured with %s", len(matchingAddrs), subnetIP))
+		}
+
+		if stopWhenAvailable && candidate.Available() {
+			break
 		}
 	}
 
-	return nil, fmt.Errorf("all allowed network interfaces are currently taken")
+	return candidates, nil
 }
 
 func (p *Picker) addrsInSet(ip string, set []*network.Interface) (addrs []*network.Interface) {
@@ -118,19 +186,3 @@ func (p *Picker) addrsInSet(ip string, set []*network.Interface) (addrs []*netwo
 
 	return addrs
 }
-
-func (p *Picker) nonReusableInterfaceExists(ip string, reusableInterfaces []*network.Interface, allInterfaces []*network.Interface) bool {
-	for _, iface := range allInterfaces {
-		reusable := false
-		for _, reusableIface := range reusableInterfaces {
-			if iface.HardwareAddress == reusableIface.HardwareAddress {
-				reusable = true
-			}
-		}
-
-		if !reusable && ip == iface.IP {
-			return true
-		}
-	}
-	return false
-}
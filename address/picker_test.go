@@ -29,6 +29,7 @@ var _ = Describe("Picker", func() {
 		picker = &address.Picker{
 			Network: mockNetwork,
 			Driver:  mockDriver,
+			Table:   address.DefaultTable(),
 		}
 	})
 
@@ -216,6 +217,7 @@ var _ = Describe("Picker", func() {
 				}
 
 				mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil)
+				mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil)
 
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{})).To(Equal(expectedNetworkConfig))
 			})
@@ -242,6 +244,7 @@ var _ = Describe("Picker", func() {
 				}
 
 				mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil)
+				mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil)
 
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{})).To(Equal(expectedNetworkConfig))
 			})
@@ -275,6 +278,7 @@ var _ = Describe("Picker", func() {
 				}
 
 				mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil)
+				mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil)
 
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{})).To(Equal(expectedNetworkConfig))
 			})
@@ -307,6 +311,7 @@ var _ = Describe("Picker", func() {
 				}
 
 				mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil)
+				mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil)
 
 				Expect(picker.SelectAvailableInterface(vboxInterfaces, &config.VMConfig{})).To(Equal(expectedNetworkConfig))
 			})
@@ -342,6 +347,7 @@ var _ = Describe("Picker", func() {
 
 				gomock.InOrder(
 					mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil),
+					mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil),
 					mockDriver.EXPECT().IsInterfaceInUse("some-vbox-interface").Return(true, nil),
 					mockDriver.EXPECT().IsInterfaceInUse("some-other-vbox-interface").Return(false, nil),
 				)
@@ -378,6 +384,7 @@ var _ = Describe("Picker", func() {
 
 				gomock.InOrder(
 					mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil),
+					mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil),
 					mockDriver.EXPECT().IsInterfaceInUse("some-vbox-interface").Return(true, nil),
 					mockDriver.EXPECT().IsInterfaceInUse("some-other-vbox-interface").Return(true, nil),
 				)
@@ -401,6 +408,7 @@ var _ = Describe("Picker", func() {
 				}
 
 				mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil)
+				mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil)
 
 				_, err := picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{})
 				Expect(err).To(MatchError("all allowed network interfaces are currently taken"))
@@ -430,6 +438,7 @@ var _ = Describe("Picker", func() {
 
 				gomock.InOrder(
 					mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil),
+					mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil),
 					mockDriver.EXPECT().IsInterfaceInUse("some-vbox-interface").Return(false, errors.New("some-error")),
 				)
 
@@ -437,5 +446,170 @@ var _ = Describe("Picker", func() {
 				Expect(err).To(MatchError("some-error"))
 			})
 		})
+
+		Context("when the address table has custom entries", func() {
+			BeforeEach(func() {
+				picker.Table = &address.Table{
+					Addresses: []address.Mapping{
+						{IP: "10.0.5.20", Domain: "pcfdev.example.com"},
+						{IP: "10.0.6.20", Domain: "pcfdev2.example.com"},
+					},
+					WildcardPattern: "%s.nip.io",
+				}
+			})
+
+			It("should pick the first free entry from the table", func() {
+				allInterfaces := []*network.Interface{
+					&network.Interface{IP: "10.0.5.1", HardwareAddress: "some-hardware-address"},
+				}
+				expectedNetworkConfig := &config.NetworkConfig{
+					VMIP:     "10.0.6.20",
+					VMDomain: "pcfdev2.example.com",
+					Interface: &network.Interface{
+						IP:     "10.0.6.1",
+						Exists: false,
+					},
+				}
+
+				mockNetwork.EXPECT().Interfaces().Return(allInterfaces, nil)
+				mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil)
+
+				Expect(picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{})).To(Equal(expectedNetworkConfig))
+			})
+
+			It("should use the IP from the table for a desired domain", func() {
+				Expect(picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{
+					Domain: "pcfdev.example.com",
+				})).To(Equal(&config.NetworkConfig{
+					VMIP:     "10.0.5.20",
+					VMDomain: "pcfdev.example.com",
+					Interface: &network.Interface{
+						IP:     "10.0.5.1",
+						Exists: false,
+					},
+				}))
+			})
+
+			It("should use the wildcard pattern for a desired ip outside the table", func() {
+				networkConfig, err := picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{
+					IP: "192.168.200.138",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(networkConfig.VMDomain).To(Equal("192.168.200.138.nip.io"))
+			})
+		})
+
+		Context("when a route covers the first subnet", func() {
+			It("should return the next interface", func() {
+				gomock.InOrder(
+					mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil),
+					mockNetwork.EXPECT().Routes().Return([]*network.Route{
+						{Interface: "tun0", Destination: "192.168.0.0/20"},
+					}, nil),
+				)
+
+				networkConfig, err := picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(networkConfig.VMIP).To(Equal("192.168.22.11"))
+			})
+		})
+
+		Context("when there is an error reading the routes", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{}, nil),
+					mockNetwork.EXPECT().Routes().Return(nil, errors.New("some-error")),
+				)
+
+				_, err := picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{})
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when every table entry is taken and there is a subnet pool", func() {
+			It("should allocate from the pool", func() {
+				picker.Table = &address.Table{
+					Addresses:       []address.Mapping{{IP: "192.168.11.11", Domain: "local.pcfdev.io"}},
+					WildcardPattern: "%s.nip.io",
+					SubnetPool:      []string{"10.20.0.0/23"},
+				}
+
+				gomock.InOrder(
+					mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{
+						{Name: "eth0", IP: "192.168.11.5", CIDR: "192.168.11.0/24"},
+						{Name: "eth1", IP: "10.20.0.7", CIDR: "10.20.0.0/24"},
+					}, nil),
+					mockNetwork.EXPECT().Routes().Return([]*network.Route{}, nil),
+				)
+
+				Expect(picker.SelectAvailableInterface([]*network.Interface{}, &config.VMConfig{})).To(Equal(&config.NetworkConfig{
+					VMIP:     "10.20.1.11",
+					VMDomain: "10.20.1.11.nip.io",
+					Interface: &network.Interface{
+						IP:     "10.20.1.1",
+						Exists: false,
+					},
+				}))
+			})
+		})
+	})
+
+	Describe("#Check", func() {
+		It("should explain why each candidate subnet was rejected", func() {
+			picker.Table = &address.Table{
+				Addresses: []address.Mapping{
+					{IP: "192.168.11.11", Domain: "local.pcfdev.io"},
+					{IP: "192.168.22.11", Domain: "local2.pcfdev.io"},
+					{IP: "192.168.33.11", Domain: "local3.pcfdev.io"},
+					{IP: "192.168.44.11", Domain: "local4.pcfdev.io"},
+				},
+				WildcardPattern: "%s.xip.io",
+			}
+			vboxInterfaces := []*network.Interface{
+				{Name: "vboxnet0", IP: "192.168.22.1", HardwareAddress: "some-vbox-hardware-address"},
+			}
+
+			gomock.InOrder(
+				mockNetwork.EXPECT().Interfaces().Return([]*network.Interface{
+					{Name: "docker0", IP: "192.168.11.1", CIDR: "192.168.11.0/24", HardwareAddress: "some-docker-hardware-address"},
+					{Name: "vboxnet0", IP: "192.168.22.1", CIDR: "192.168.22.0/24", HardwareAddress: "some-vbox-hardware-address"},
+				}, nil),
+				mockNetwork.EXPECT().Routes().Return([]*network.Route{
+					{Interface: "tun0", Destination: "192.168.33.0/24"},
+				}, nil),
+				mockDriver.EXPECT().IsInterfaceInUse("vboxnet0").Return(true, nil),
+			)
+
+			candidates, err := picker.Check(vboxInterfaces)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(candidates).To(HaveLen(4))
+
+			Expect(candidates[0].Subnet).To(Equal("192.168.11.1"))
+			Expect(candidates[0].Conflicts).To(Equal([]string{"interface docker0 has address 192.168.11.1 on network 192.168.11.0/24"}))
+			Expect(candidates[1].Conflicts).To(Equal([]string{"host-only interface vboxnet0 is in use by another VM"}))
+			Expect(candidates[2].Conflicts).To(Equal([]string{"route to 192.168.33.0/24 via interface tun0"}))
+			Expect(candidates[3].Available()).To(BeTrue())
+			Expect(candidates[3].Domain).To(Equal("local4.pcfdev.io"))
+			Expect(candidates[3].Interface).To(Equal(&network.Interface{IP: "192.168.44.1", Exists: false}))
+		})
+	})
+
+	Describe("#DomainForIP", func() {
+		It("should return the domain mapped to the IP", func() {
+			Expect(picker.DomainForIP("192.168.22.11")).To(Equal("local2.pcfdev.io"))
+		})
+
+		Context("when the IP is not in the address table", func() {
+			It("should return the wildcard DNS domain for the IP", func() {
+				Expect(picker.DomainForIP("10.0.0.5")).To(Equal("10.0.0.5.xip.io"))
+			})
+		})
+	})
+
+	Describe("#IsPCFDevSubnet", func() {
+		It("should only accept subnets from the address table", func() {
+			Expect(picker.IsPCFDevSubnet("192.168.22.1")).To(BeTrue())
+			Expect(picker.IsPCFDevSubnet("192.168.56.1")).To(BeFalse())
+		})
 	})
 })
@@ -0,0 +1,196 @@
+package address
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/network"
+)
+
+const DefaultWildcardPattern = "%s.xip.io"
+
+type Mapping struct {
+	IP     string `json:"ip"`
+	Domain string `json:"domain"`
+}
+
+type Table struct {
+	Addresses       []Mapping `json:"addresses"`
+	WildcardPattern string    `json:"wildcard_pattern"`
+	SubnetPool      []string  `json:"subnet_pool"`
+}
+
+func DefaultTable() *Table {
+	return &Table{
+		Addresses: []Mapping{
+			{IP: "192.168.11.11", Domain: "local.pcfdev.io"},
+			{IP: "192.168.22.11", Domain: "local2.pcfdev.io"},
+			{IP: "192.168.33.11", Domain: "local3.pcfdev.io"},
+			{IP: "192.168.44.11", Domain: "local4.pcfdev.io"},
+			{IP: "192.168.55.11", Domain: "local5.pcfdev.io"},
+			{IP: "192.168.66.11", Domain: "local6.pcfdev.io"},
+			{IP: "192.168.77.11", Domain: "local7.pcfdev.io"},
+			{IP: "192.168.88.11", Domain: "local8.pcfdev.io"},
+			{IP: "192.168.99.11", Domain: "local9.pcfdev.io"},
+		},
+		WildcardPattern: DefaultWildcardPattern,
+	}
+}
+
+func LoadTable(path string) (*Table, error) {
+	contents, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return DefaultTable(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := ParseTable(contents)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address table %s: %s", path, err)
+	}
+	return table, nil
+}
+
+func ParseTable(contents []byte) (*Table, error) {
+	table := &Table{}
+	if err := json.Unmarshal(contents, table); err != nil {
+		return nil, err
+	}
+
+	defaults := DefaultTable()
+	if table.Addresses == nil {
+		table.Addresses = defaults.Addresses
+	}
+	if table.WildcardPattern == "" {
+		table.WildcardPattern = defaults.WildcardPattern
+	}
+
+	if strings.Count(table.WildcardPattern, "%s") != 1 || strings.Count(table.WildcardPattern, "%") != 1 {
+		return nil, fmt.Errorf("wildcard pattern %s must contain exactly one %%s", table.WildcardPattern)
+	}
+
+	for _, cidr := range table.SubnetPool {
+		if _, err := poolSubnets(cidr); err != nil {
+			return nil, err
+		}
+	}
+
+	ips := map[string]bool{}
+	domains := map[string]bool{}
+	for _, mapping := range table.Addresses {
+		if !network.IsIPV4(mapping.IP) {
+			return nil, fmt.Errorf("%s is not a supported IP address", mapping.IP)
+		}
+		if mapping.Domain == "" {
+			return nil, fmt.Errorf("%s is missing a domain", mapping.IP)
+		}
+		if ips[mapping.IP] || domains[mapping.Domain] {
+			return nil, fmt.Errorf("duplicate entry for %s (%s)", mapping.IP, mapping.Domain)
+		}
+		ips[mapping.IP] = true
+		domains[mapping.Domain] = true
+	}
+
+	return table, nil
+}
+
+func (t *Table) DomainForIP(ip string) string {
+	for _, mapping := range t.Addresses {
+		if mapping.IP == ip {
+			return mapping.Domain
+		}
+	}
+	return fmt.Sprintf(t.WildcardPattern, ip)
+}
+
+func (t *Table) IPForDomain(domain string) (string, error) {
+	for _, mapping := range t.Addresses {
+		if mapping.Domain == domain {
+			return mapping.IP, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not one of the allowed PCF Dev domains", domain)
+}
+
+func (t *Table) SubnetForDomain(domain string) (string, error) {
+	ip, err := t.IPForDomain(domain)
+	if err != nil {
+		return "", err
+	}
+	return SubnetForIP(ip)
+}
+
+func (t *Table) IsDomainAllowed(domain string) bool {
+	_, err := t.IPForDomain(domain)
+	return err == nil
+}
+
+func (t *Table) Domains() []string {
+	domains := make([]string, 0, len(t.Addresses))
+	for _, mapping := range t.Addresses {
+		domains = append(domains, mapping.Domain)
+	}
+	return domains
+}
+
+func (t *Table) Candidates() []Mapping {
+	candidates := append([]Mapping{}, t.Addresses...)
+
+	subnets := map[string]bool{}
+	for _, mapping := range t.Addresses {
+		if subnet, err := SubnetForIP(mapping.IP); err == nil {
+			subnets[subnet] = true
+		}
+	}
+
+	for _, cidr := range t.SubnetPool {
+		poolSubnets, err := poolSubnets(cidr)
+		if err != nil {
+			continue
+		}
+		for _, subnet := range poolSubnets {
+			if subnets[subnet] {
+				continue
+			}
+			subnets[subnet] = true
+
+			ip := IPForSubnet(subnet)
+			candidates = append(candidates, Mapping{IP: ip, Domain: t.DomainForIP(ip)})
+		}
+	}
+
+	return candidates
+}
+
+func (t *Table) HasSubnet(subnet string) bool {
+	for _, candidate := range t.Candidates() {
+		if candidateSubnet, err := SubnetForIP(candidate.IP); err == nil && candidateSubnet == subnet {
+			return true
+		}
+	}
+	return false
+}
+
+func poolSubnets(cidr string) ([]string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil || ipNet.IP.To4() == nil {
+		return nil, fmt.Errorf("subnet pool entry %s is not an IPv4 network", cidr)
+	}
+	ones, _ := ipNet.Mask.Size()
+	if ones < 16 || ones > 24 {
+		return nil, fmt.Errorf("subnet pool entry %s must be between /16 and /24", cidr)
+	}
+
+	base := ipNet.IP.To4()
+	subnets := []string{}
+	for i := 0; i < 1<<uint(24-ones); i++ {
+		subnets = append(subnets, net.IPv4(base[0], base[1]+byte(i>>8), base[2]+byte(i), 1).String())
+	}
+	return subnets, nil
+}
@@ -0,0 +1,208 @@
+package address_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/address"
+)
+
+var _ = Describe("Table", func() {
+	var table *address.Table
+
+	BeforeEach(func() {
+		table = &address.Table{
+			Addresses: []address.Mapping{
+				{IP: "192.168.11.11", Domain: "local.pcfdev.io"},
+				{IP: "10.0.5.20", Domain: "pcfdev.example.com"},
+			},
+			WildcardPattern: "%s.nip.io",
+		}
+	})
+
+	Describe("#DomainForIP", func() {
+		It("should convert a passed in ip to the correct domain", func() {
+			Expect(table.DomainForIP("192.168.11.11")).To(Equal("local.pcfdev.io"))
+			Expect(table.DomainForIP("10.0.5.20")).To(Equal("pcfdev.example.com"))
+		})
+
+		Context("when the ip is not in the table", func() {
+			It("should use the wildcard pattern", func() {
+				Expect(table.DomainForIP("192.168.89.11")).To(Equal("192.168.89.11.nip.io"))
+			})
+		})
+	})
+
+	Describe("#SubnetForDomain", func() {
+		It("should convert a passed in domain to the correct subnet", func() {
+			Expect(table.SubnetForDomain("local.pcfdev.io")).To(Equal("192.168.11.1"))
+			Expect(table.SubnetForDomain("pcfdev.example.com")).To(Equal("10.0.5.1"))
+		})
+
+		Context("when the domain is not in the table", func() {
+			It("should return an error", func() {
+				_, err := table.SubnetForDomain("some-bad-domain")
+				Expect(err).To(MatchError("some-bad-domain is not one of the allowed PCF Dev domains"))
+			})
+		})
+	})
+
+	Describe("#IsDomainAllowed", func() {
+		It("should return whether the domain is in the table", func() {
+			Expect(table.IsDomainAllowed("local.pcfdev.io")).To(BeTrue())
+			Expect(table.IsDomainAllowed("pcfdev.example.com")).To(BeTrue())
+			Expect(table.IsDomainAllowed("local2.pcfdev.io")).To(BeFalse())
+		})
+	})
+
+	Describe("#Domains", func() {
+		It("should return the domains in order", func() {
+			Expect(table.Domains()).To(Equal([]string{"local.pcfdev.io", "pcfdev.example.com"}))
+		})
+	})
+
+	Describe("#Candidates", func() {
+		It("should list the table entries followed by the subnets in the pool", func() {
+			table.SubnetPool = []string{"10.0.4.0/22"}
+
+			Expect(table.Candidates()).To(Equal([]address.Mapping{
+				{IP: "192.168.11.11", Domain: "local.pcfdev.io"},
+				{IP: "10.0.5.20", Domain: "pcfdev.example.com"},
+				{IP: "10.0.4.11", Domain: "10.0.4.11.nip.io"},
+				{IP: "10.0.6.11", Domain: "10.0.6.11.nip.io"},
+				{IP: "10.0.7.11", Domain: "10.0.7.11.nip.io"},
+			}))
+		})
+	})
+
+	Describe("#HasSubnet", func() {
+		It("should recognize the subnets of the table entries and the pool", func() {
+			table.SubnetPool = []string{"10.0.4.0/22"}
+
+			Expect(table.HasSubnet("192.168.11.1")).To(BeTrue())
+			Expect(table.HasSubnet("10.0.5.1")).To(BeTrue())
+			Expect(table.HasSubnet("10.0.7.1")).To(BeTrue())
+			Expect(table.HasSubnet("192.168.56.1")).To(BeFalse())
+			Expect(table.HasSubnet("10.0.8.1")).To(BeFalse())
+		})
+	})
+
+	Describe(".DefaultTable", func() {
+		It("should contain the standard PCF Dev domains", func() {
+			table := address.DefaultTable()
+			Expect(table.Addresses).To(HaveLen(9))
+			Expect(table.DomainForIP("192.168.22.11")).To(Equal("local2.pcfdev.io"))
+			Expect(table.SubnetForDomain("local9.pcfdev.io")).To(Equal("192.168.99.1"))
+			Expect(table.DomainForIP("192.168.89.11")).To(Equal("192.168.89.11.xip.io"))
+		})
+	})
+
+	Describe(".ParseTable", func() {
+		It("should parse the addresses and wildcard pattern", func() {
+			table, err := address.ParseTable([]byte(`{"addresses":[{"ip":"10.0.5.20","domain":"pcfdev.example.com"}],"wildcard_pattern":"%s.sslip.io"}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.Addresses).To(Equal([]address.Mapping{{IP: "10.0.5.20", Domain: "pcfdev.example.com"}}))
+			Expect(table.WildcardPattern).To(Equal("%s.sslip.io"))
+		})
+
+		Context("when fields are omitted", func() {
+			It("should use the defaults", func() {
+				table, err := address.ParseTable([]byte(`{"wildcard_pattern":"%s.nip.io"}`))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(table.Addresses).To(Equal(address.DefaultTable().Addresses))
+
+				table, err = address.ParseTable([]byte(`{"addresses":[]}`))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(table.Addresses).To(BeEmpty())
+				Expect(table.WildcardPattern).To(Equal("%s.xip.io"))
+			})
+		})
+
+		Context("when the wildcard pattern is invalid", func() {
+			It("should return an error", func() {
+				_, err := address.ParseTable([]byte(`{"wildcard_pattern":"nip.io"}`))
+				Expect(err).To(MatchError("wildcard pattern nip.io must contain exactly one %s"))
+			})
+		})
+
+		Context("when a subnet pool entry is invalid", func() {
+			It("should return an error", func() {
+				_, err := address.ParseTable([]byte(`{"subnet_pool":["some-bad-cidr"]}`))
+				Expect(err).To(MatchError("subnet pool entry some-bad-cidr is not an IPv4 network"))
+
+				_, err = address.ParseTable([]byte(`{"subnet_pool":["10.0.0.0/8"]}`))
+				Expect(err).To(MatchError("subnet pool entry 10.0.0.0/8 must be between /16 and /24"))
+			})
+		})
+
+		Context("when an ip is invalid", func() {
+			It("should return an error", func() {
+				_, err := address.ParseTable([]byte(`{"addresses":[{"ip":"some-bad-ip","domain":"some-domain"}]}`))
+				Expect(err).To(MatchError("some-bad-ip is not a supported IP address"))
+			})
+		})
+
+		Context("when a domain is missing", func() {
+			It("should return an error", func() {
+				_, err := address.ParseTable([]byte(`{"addresses":[{"ip":"10.0.5.20"}]}`))
+				Expect(err).To(MatchError("10.0.5.20 is missing a domain"))
+			})
+		})
+
+		Context("when an entry is duplicated", func() {
+			It("should return an error", func() {
+				_, err := address.ParseTable([]byte(`{"addresses":[{"ip":"10.0.5.20","domain":"a.example.com"},{"ip":"10.0.6.20","domain":"a.example.com"}]}`))
+				Expect(err).To(MatchError("duplicate entry for 10.0.6.20 (a.example.com)"))
+			})
+		})
+
+		Context("when the contents are not valid JSON", func() {
+			It("should return an error", func() {
+				_, err := address.ParseTable([]byte("some-bad-json"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe(".LoadTable", func() {
+		var tempDir string
+
+		BeforeEach(func() {
+			var err error
+			tempDir, err = ioutil.TempDir("", "pcfdev-address")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tempDir)
+		})
+
+		It("should read the table from the file", func() {
+			path := filepath.Join(tempDir, "addresses.json")
+			Expect(ioutil.WriteFile(path, []byte(`{"wildcard_pattern":"%s.nip.io"}`), 0644)).To(Succeed())
+
+			table, err := address.LoadTable(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(table.WildcardPattern).To(Equal("%s.nip.io"))
+		})
+
+		Context("when the file does not exist", func() {
+			It("should return the default table", func() {
+				Expect(address.LoadTable(filepath.Join(tempDir, "addresses.json"))).To(Equal(address.DefaultTable()))
+			})
+		})
+
+		Context("when the file is invalid", func() {
+			It("should return an error", func() {
+				path := filepath.Join(tempDir, "addresses.json")
+				Expect(ioutil.WriteFile(path, []byte(`{"wildcard_pattern":"nip.io"}`), 0644)).To(Succeed())
+
+				_, err := address.LoadTable(path)
+				Expect(err).To(MatchError("invalid address table " + path + ": wildcard pattern nip.io must contain exactly one %s"))
+			})
+		})
+	})
+})
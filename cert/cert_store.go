@@ -19,6 +19,7 @@ type FS interface {
 type SystemStore interface {
 	Store(path string) error
 	Unstore() error
+	IsTrusted(cert string) (bool, error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/cmd_runner.go github.com/pivotal-cf/pcfdev-cli/cert CmdRunner
@@ -48,3 +49,7 @@ func (c *CertStore) Store(cert string) error {
 func (c *CertStore) Unstore() error {
 	return c.SystemStore.Unstore()
 }
+
+func (c *CertStore) IsTrusted(cert string) (bool, error) {
+	return c.SystemStore.IsTrusted(cert)
+}
@@ -95,4 +95,21 @@ var _ = Describe("CertStore", func() {
 			})
 		})
 	})
+
+	Describe("#IsTrusted", func() {
+		It("should check the system certificate store", func() {
+			mockSystemStore.EXPECT().IsTrusted("some-cert").Return(true, nil)
+
+			Expect(certStore.IsTrusted("some-cert")).To(BeTrue())
+		})
+
+		Context("when there is an error checking the system certificate store", func() {
+			It("should return the error", func() {
+				mockSystemStore.EXPECT().IsTrusted("some-cert").Return(false, errors.New("some-error"))
+
+				_, err := certStore.IsTrusted("some-cert")
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
 })
@@ -1,6 +1,9 @@
 package cert
 
+import "github.com/pivotal-cf/pcfdev-cli/address"
+
 type ConcreteSystemStore struct {
-	FS        FS
-	CmdRunner CmdRunner
+	FS           FS
+	CmdRunner    CmdRunner
+	AddressTable *address.Table
 }
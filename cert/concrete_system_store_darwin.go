@@ -1,7 +1,9 @@
 package cert
 
 import (
+	"bytes"
 	"path/filepath"
+	"strings"
 
 	"github.com/pivotal-cf/pcfdev-cli/helpers"
 	"github.com/pivotal-cf/pcfdev-cli/user"
@@ -41,6 +43,42 @@ func (c *ConcreteSystemStore) Unstore() error {
 	return pcfdevKeychain.Delete()
 }
 
+func (c *ConcreteSystemStore) IsTrusted(cert string) (bool, error) {
+	pcfdevKeychainPath, err := c.pcfdevKeychain()
+	if err != nil {
+		return false, err
+	}
+
+	pcfdevKeychain := &Keychain{
+		CommandRunner: c.CmdRunner,
+		Name:          "pcfdev.keychain",
+		Path:          pcfdevKeychainPath,
+		FS:            c.FS,
+	}
+	if !pcfdevKeychain.Exists() {
+		return false, nil
+	}
+
+	contains, err := pcfdevKeychain.Contains(cert)
+	if err != nil || !contains {
+		return false, err
+	}
+
+	tempDir, err := c.FS.TempDir()
+	if err != nil {
+		return false, err
+	}
+	defer c.FS.Remove(tempDir)
+	certPath := filepath.Join(tempDir, "cert.pem")
+
+	if err := c.FS.Write(certPath, strings.NewReader(cert), false); err != nil {
+		return false, err
+	}
+
+	_, err = c.CmdRunner.Run("security", "verify-cert", "-c", certPath, "-k", pcfdevKeychainPath)
+	return err == nil, nil
+}
+
 func (c *ConcreteSystemStore) createOrReplaceKeychain(keychain string, password string) {
 	helpers.IgnoreErrorFrom(c.CmdRunner.Run("security", "create-keychain", "-p", password, keychain))
 }
@@ -71,6 +109,14 @@ func (k *Keychain) Exists() bool {
 	return err == nil
 }
 
+func (k *Keychain) Contains(cert string) (bool, error) {
+	output, err := k.CommandRunner.Run("security", "find-certificate", "-a", "-p", k.Path)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Contains(output, bytes.TrimSpace([]byte(cert))), nil
+}
+
 func (k *Keychain) Delete() error {
 	tmpDir, err := k.FS.TempDir()
 	if err != nil {
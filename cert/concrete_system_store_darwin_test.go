@@ -70,6 +70,63 @@ var _ = Describe("ConcreteSystemStore", func() {
 		})
 	})
 
+	Describe("#IsTrusted", func() {
+		It("should return true when the certificate is in the pcfdev keychain and verifies", func() {
+			certPath := filepath.Join("some-dir", "cert.pem")
+			gomock.InOrder(
+				mockCmdRunner.EXPECT().Run("security", "showkeychaininfo", endsWith("pcfdev.keychain")),
+				mockCmdRunner.EXPECT().Run("security", "find-certificate", "-a", "-p", endsWith("pcfdev.keychain")).Return([]byte("some-other-cert\nsome-cert\n"), nil),
+				mockFS.EXPECT().TempDir().Return("some-dir", nil),
+				mockFS.EXPECT().Write(certPath, strings.NewReader("some-cert\n"), false),
+				mockCmdRunner.EXPECT().Run("security", "verify-cert", "-c", certPath, "-k", endsWith("pcfdev.keychain")),
+				mockFS.EXPECT().Remove("some-dir"),
+			)
+
+			Expect(certStore.IsTrusted("some-cert\n")).To(BeTrue())
+		})
+
+		It("should return false when the pcfdev keychain does not exist", func() {
+			mockCmdRunner.EXPECT().Run("security", "showkeychaininfo", endsWith("pcfdev.keychain")).Return(nil, errors.New("some-error"))
+
+			Expect(certStore.IsTrusted("some-cert")).To(BeFalse())
+		})
+
+		It("should return false when the pcfdev keychain holds a different certificate", func() {
+			gomock.InOrder(
+				mockCmdRunner.EXPECT().Run("security", "showkeychaininfo", endsWith("pcfdev.keychain")),
+				mockCmdRunner.EXPECT().Run("security", "find-certificate", "-a", "-p", endsWith("pcfdev.keychain")).Return([]byte("some-other-cert\n"), nil),
+			)
+
+			Expect(certStore.IsTrusted("some-cert")).To(BeFalse())
+		})
+
+		It("should return false when the certificate does not verify", func() {
+			certPath := filepath.Join("some-dir", "cert.pem")
+			gomock.InOrder(
+				mockCmdRunner.EXPECT().Run("security", "showkeychaininfo", endsWith("pcfdev.keychain")),
+				mockCmdRunner.EXPECT().Run("security", "find-certificate", "-a", "-p", endsWith("pcfdev.keychain")).Return([]byte("some-cert\n"), nil),
+				mockFS.EXPECT().TempDir().Return("some-dir", nil),
+				mockFS.EXPECT().Write(certPath, strings.NewReader("some-cert"), false),
+				mockCmdRunner.EXPECT().Run("security", "verify-cert", "-c", certPath, "-k", endsWith("pcfdev.keychain")).Return(nil, errors.New("some-error")),
+				mockFS.EXPECT().Remove("some-dir"),
+			)
+
+			Expect(certStore.IsTrusted("some-cert")).To(BeFalse())
+		})
+
+		Context("when there is an error listing the certificates in the pcfdev keychain", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("security", "showkeychaininfo", endsWith("pcfdev.keychain")),
+					mockCmdRunner.EXPECT().Run("security", "find-certificate", "-a", "-p", endsWith("pcfdev.keychain")).Return(nil, errors.New("some-error")),
+				)
+
+				_, err := certStore.IsTrusted("some-cert")
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
 	Describe("#Unstore", func() {
 		allowHappyPathInteractions := func() {
 			mockFS.EXPECT().TempDir().Return("some-dir", nil).AnyTimes()
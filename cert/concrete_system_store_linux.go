@@ -31,6 +31,20 @@ func (c *ConcreteSystemStore) Unstore() error {
 	return nil
 }
 
+func (c *ConcreteSystemStore) IsTrusted(cert string) (bool, error) {
+	caCertsPath, err := c.getCaCertPath()
+	if err != nil {
+		return false, err
+	}
+
+	contents, err := c.FS.Read(caCertsPath)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Contains(contents, bytes.TrimSpace([]byte(cert))), nil
+}
+
 func (c *ConcreteSystemStore) getCaCertPath() (string, error) {
 	paths := []string{
 		"/etc/ssl/certs/ca-certificates.crt",
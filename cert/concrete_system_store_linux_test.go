@@ -129,4 +129,36 @@ var _ = Describe("ConcreteSystemStore", func() {
 			})
 		})
 	})
+
+	Describe("#IsTrusted", func() {
+		It("should return true when the certificate is in the system certificate store", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists("/etc/ssl/certs/ca-certificates.crt").Return(true, nil),
+				mockFS.EXPECT().Read("/etc/ssl/certs/ca-certificates.crt").Return([]byte("some-other-cert\nsome-cert\n"), nil),
+			)
+
+			Expect(certStore.IsTrusted("some-cert\n")).To(BeTrue())
+		})
+
+		It("should return false when the certificate is not in the system certificate store", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists("/etc/ssl/certs/ca-certificates.crt").Return(true, nil),
+				mockFS.EXPECT().Read("/etc/ssl/certs/ca-certificates.crt").Return([]byte("some-other-cert\n"), nil),
+			)
+
+			Expect(certStore.IsTrusted("some-cert")).To(BeFalse())
+		})
+
+		Context("when there is an error reading the system certificate store", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists("/etc/ssl/certs/ca-certificates.crt").Return(true, nil),
+					mockFS.EXPECT().Read("/etc/ssl/certs/ca-certificates.crt").Return(nil, errors.New("some-error")),
+				)
+
+				_, err := certStore.IsTrusted("some-cert")
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
 })
@@ -1,6 +1,11 @@
 package cert
 
-import "github.com/pivotal-cf/pcfdev-cli/address"
+import (
+	"crypto/sha1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
 
 func (c *ConcreteSystemStore) Store(path string) error {
 	_, err := c.CmdRunner.Run("certutil", "-addstore", "-user", "-f", "ROOT", path)
@@ -8,9 +13,20 @@ func (c *ConcreteSystemStore) Store(path string) error {
 }
 
 func (c *ConcreteSystemStore) Unstore() error {
-	for _, domain := range address.AllowedAddresses {
+	for _, domain := range c.AddressTable.Domains() {
 		c.CmdRunner.Run("certutil", "-delstore", "-user", "ROOT", domain)
 	}
 
 	return nil
 }
+
+func (c *ConcreteSystemStore) IsTrusted(cert string) (bool, error) {
+	block, _ := pem.Decode([]byte(cert))
+	if block == nil {
+		return false, errors.New("failed to parse the PCF Dev certificate")
+	}
+
+	thumbprint := fmt.Sprintf("%x", sha1.Sum(block.Bytes))
+	_, err := c.CmdRunner.Run("certutil", "-verifystore", "-user", "ROOT", thumbprint)
+	return err == nil, nil
+}
@@ -60,4 +60,31 @@ var _ = Describe("ConcreteSystemStore", func() {
 			Expect(certStore.Unstore()).To(Succeed())
 		})
 	})
+
+	Describe("#IsTrusted", func() {
+		var cert string
+
+		BeforeEach(func() {
+			cert = "-----BEGIN CERTIFICATE-----\nc29tZS1jZXJ0\n-----END CERTIFICATE-----\n"
+		})
+
+		It("should return true when the certificate is in the store", func() {
+			mockCmdRunner.EXPECT().Run("certutil", "-verifystore", "-user", "ROOT", "13056782ab96123591b4d799a9131167e88b4789")
+
+			Expect(certStore.IsTrusted(cert)).To(BeTrue())
+		})
+
+		It("should return false when the certificate is not in the store", func() {
+			mockCmdRunner.EXPECT().Run("certutil", "-verifystore", "-user", "ROOT", "13056782ab96123591b4d799a9131167e88b4789").Return(nil, errors.New("some-error"))
+
+			Expect(certStore.IsTrusted(cert)).To(BeFalse())
+		})
+
+		Context("when the certificate cannot be parsed", func() {
+			It("should return an error", func() {
+				_, err := certStore.IsTrusted("some-cert")
+				Expect(err).To(MatchError("failed to parse the PCF Dev certificate"))
+			})
+		})
+	})
 })
@@ -28,6 +28,17 @@ func (_m *MockSystemStore) EXPECT() *_MockSystemStoreRecorder {
 	return _m.recorder
 }
 
+func (_m *MockSystemStore) IsTrusted(_param0 string) (bool, error) {
+	ret := _m.ctrl.Call(_m, "IsTrusted", _param0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockSystemStoreRecorder) IsTrusted(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsTrusted", arg0)
+}
+
 func (_m *MockSystemStore) Store(_param0 string) error {
 	ret := _m.ctrl.Call(_m, "Store", _param0)
 	ret0, _ := ret[0].(error)
@@ -17,6 +17,7 @@ type Config struct {
 	OVAPath                  string
 	PartialOVAPath           string
 	VMDir                    string
+	AddressTablePath         string
 	HTTPProxy                string
 	HTTPSProxy               string
 	NoProxy                  string
@@ -32,6 +33,8 @@ type Config struct {
 	ExpectedMD5              string
 	InsecurePrivateKey       []byte
 	PrivateKeyPath           string
+	ScrubConfigPath          string
+	HistoryPath              string
 	Version                  *Version
 }
 
@@ -72,6 +75,7 @@ func New(defaultVMName string, expectedMD5 string, insecurePrivateKey []byte, sy
 		PCFDevHome:               pcfdevHome,
 		OVADir:                   filepath.Join(pcfdevHome, "ova"),
 		VMDir:                    filepath.Join(pcfdevHome, "vms"),
+		AddressTablePath:         filepath.Join(pcfdevHome, "addresses.json"),
 		OVAPath:                  filepath.Join(pcfdevHome, "ova", defaultVMName+".ova"),
 		PartialOVAPath:           filepath.Join(pcfdevHome, "ova", defaultVMName+".ova.partial"),
 		HTTPProxy:                getHTTPProxy(),
@@ -88,10 +92,20 @@ func New(defaultVMName string, expectedMD5 string, insecurePrivateKey []byte, sy
 		DefaultCPUs:              system.PhysicalCores,
 		InsecurePrivateKey:       insecurePrivateKey,
 		PrivateKeyPath:           filepath.Join(pcfdevHome, "vms", "key.pem"),
+		ScrubConfigPath:          filepath.Join(pcfdevHome, "scrub.json"),
+		HistoryPath:              filepath.Join(pcfdevHome, "history.jsonl"),
 		Version:                  version,
 	}, nil
 }
 
+func (c *Config) ConsoleLogPath(vmName string) string {
+	return filepath.Join(c.VMDir, vmName, "console.log")
+}
+
+func (c *Config) ProvisionLogPath(vmName string) string {
+	return filepath.Join(c.VMDir, vmName, "provision.log")
+}
+
 func getPCFDevHome() (string, error) {
 	if pcfdevHome := os.Getenv("PCFDEV_HOME"); pcfdevHome != "" {
 		return pcfdevHome, nil
@@ -60,6 +60,7 @@ var _ = Describe("Config", func() {
 			Expect(conf.PCFDevHome).To(Equal("some-pcfdev-home"))
 			Expect(conf.OVADir).To(Equal(filepath.Join("some-pcfdev-home", "ova")))
 			Expect(conf.VMDir).To(Equal(filepath.Join("some-pcfdev-home", "vms")))
+			Expect(conf.AddressTablePath).To(Equal(filepath.Join("some-pcfdev-home", "addresses.json")))
 			Expect(conf.OVAPath).To(Equal(filepath.Join("some-pcfdev-home", "ova", "some-vm.ova")))
 			Expect(conf.PartialOVAPath).To(Equal(filepath.Join("some-pcfdev-home", "ova", "some-vm.ova.partial")))
 			Expect(conf.HTTPProxy).To(Equal("some-http-proxy"))
@@ -72,6 +73,8 @@ var _ = Describe("Config", func() {
 			Expect(conf.Version).To(BeIdenticalTo(expectedVersion))
 			Expect(conf.InsecurePrivateKey).To(Equal([]byte("some-insecure-private-key")))
 			Expect(conf.PrivateKeyPath).To(Equal(filepath.Join("some-pcfdev-home", "vms", "key.pem")))
+			Expect(conf.ScrubConfigPath).To(Equal(filepath.Join("some-pcfdev-home", "scrub.json")))
+			Expect(conf.HistoryPath).To(Equal(filepath.Join("some-pcfdev-home", "history.jsonl")))
 		})
 
 		Context("when caps proxy env vars are unset", func() {
@@ -338,4 +341,18 @@ var _ = Describe("Config", func() {
 			})
 		})
 	})
+
+	Describe("#ConsoleLogPath", func() {
+		It("should return the path of the serial console log in the VM directory", func() {
+			conf := &config.Config{VMDir: "some-vm-dir"}
+			Expect(conf.ConsoleLogPath("some-vm")).To(Equal(filepath.Join("some-vm-dir", "some-vm", "console.log")))
+		})
+	})
+
+	Describe("#ProvisionLogPath", func() {
+		It("should return the path of the provision log in the VM directory", func() {
+			conf := &config.Config{VMDir: "some-vm-dir"}
+			Expect(conf.ProvisionLogPath("some-vm")).To(Equal(filepath.Join("some-vm-dir", "some-vm", "provision.log")))
+		})
+	})
 })
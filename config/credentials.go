@@ -0,0 +1,52 @@
+package config
+
+import "fmt"
+
+type Credentials struct {
+	AdminUsername  string `json:"admin_username"`
+	AdminPassword  string `json:"admin_password"`
+	UserUsername   string `json:"user_username"`
+	UserPassword   string `json:"user_password"`
+	MasterPassword bool   `json:"master_password"`
+}
+
+func DefaultCredentials() *Credentials {
+	return &Credentials{
+		AdminUsername: "admin",
+		AdminPassword: "admin",
+		UserUsername:  "user",
+		UserPassword:  "pass",
+	}
+}
+
+// MasterPasswordCredentials are used for OVAs that cannot report their credentials when the VM was started with a master password.
+func MasterPasswordCredentials() *Credentials {
+	return &Credentials{
+		AdminUsername:  "admin",
+		UserUsername:   "user",
+		MasterPassword: true,
+	}
+}
+
+func (c *Credentials) Password(username string) string {
+	switch username {
+	case c.AdminUsername:
+		return c.AdminPassword
+	case c.UserUsername:
+		return c.UserPassword
+	default:
+		return ""
+	}
+}
+
+func (c *Credentials) String() string {
+	return fmt.Sprintf("Admin user => Email: %s / Password: %s\nRegular user => Email: %s / Password: %s",
+		c.AdminUsername, c.describePassword(c.AdminPassword), c.UserUsername, c.describePassword(c.UserPassword))
+}
+
+func (c *Credentials) describePassword(password string) string {
+	if c.MasterPassword && password == "" {
+		return "<master password>"
+	}
+	return password
+}
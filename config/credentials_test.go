@@ -0,0 +1,35 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+var _ = Describe("Credentials", func() {
+	Describe("#Password", func() {
+		It("should return the password of a known user", func() {
+			credentials := config.DefaultCredentials()
+			Expect(credentials.Password("admin")).To(Equal("admin"))
+			Expect(credentials.Password("user")).To(Equal("pass"))
+			Expect(credentials.Password("some-other-user")).To(BeEmpty())
+		})
+	})
+
+	Describe("#String", func() {
+		It("should describe the credentials", func() {
+			Expect(config.DefaultCredentials().String()).To(Equal("Admin user => Email: admin / Password: admin\nRegular user => Email: user / Password: pass"))
+		})
+
+		Context("when a master password is used", func() {
+			It("should not print the passwords", func() {
+				credentials := &config.Credentials{
+					AdminUsername:  "admin",
+					UserUsername:   "user",
+					MasterPassword: true,
+				}
+				Expect(credentials.String()).To(Equal("Admin user => Email: admin / Password: <master password>\nRegular user => Email: user / Password: <master password>"))
+			})
+		})
+	})
+})
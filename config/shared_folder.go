@@ -0,0 +1,8 @@
+package config
+
+type SharedFolder struct {
+	Name      string `json:"name"`
+	HostPath  string `json:"host_path"`
+	GuestPath string `json:"guest_path"`
+	ReadOnly  bool   `json:"read_only"`
+}
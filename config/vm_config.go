@@ -1,12 +1,23 @@
 package config
 
+const (
+	NetworkHostOnly = "hostonly"
+	NetworkBridged  = "bridged"
+)
+
 type VMConfig struct {
-	Name     string
-	OVAPath  string
-	Domain   string
-	IP       string
-	Memory   uint64
-	CPUs     int
-	SSHPort  string
-	Provider string
+	Name          string
+	OVAPath       string
+	Domain        string
+	IP            string
+	Memory        uint64
+	CPUs          int
+	SSHPort       string
+	Provider      string
+	Network       string
+	BridgeAdapter string
+	// OVAVersion is the version of the OVA the VM was imported from, which may be older than the plugin's.
+	OVAVersion string
+	// MasterPassword records that the VM was provisioned with a master password instead of the default credentials.
+	MasterPassword bool
 }
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const VMConfigFileVersion = 3
+
+type VMConfigFile struct {
+	Version        int    `json:"version"`
+	IP             string `json:"ip"`
+	Domain         string `json:"domain"`
+	CPUs           int    `json:"cpus"`
+	Memory         uint64 `json:"memory"`
+	OVAPath        string `json:"ova_path"`
+	Network        string `json:"network"`
+	BridgeAdapter  string `json:"bridge_adapter,omitempty"`
+	MasterPassword bool   `json:"master_password,omitempty"`
+	OVAVersion     string `json:"ova_version,omitempty"`
+}
+
+// vmConfigMigrations[n] upgrades a version n+1 document to version n+2.
+// Settings an older version did not record are taken from what the VM itself reports.
+var vmConfigMigrations = []func(document map[string]interface{}, reported *VMConfig) error{
+	migrateVMConfigFromV1,
+	migrateVMConfigFromV2,
+}
+
+func NewVMConfigFile(vmConfig *VMConfig) *VMConfigFile {
+	network := vmConfig.Network
+	if network == "" {
+		network = NetworkHostOnly
+	}
+
+	return &VMConfigFile{
+		Version:        VMConfigFileVersion,
+		IP:             vmConfig.IP,
+		Domain:         vmConfig.Domain,
+		CPUs:           vmConfig.CPUs,
+		Memory:         vmConfig.Memory,
+		OVAPath:        vmConfig.OVAPath,
+		Network:        network,
+		BridgeAdapter:  vmConfig.BridgeAdapter,
+		MasterPassword: vmConfig.MasterPassword,
+		OVAVersion:     vmConfig.OVAVersion,
+	}
+}
+
+func ParseVMConfigFile(contents []byte, reported *VMConfig) (vmConfigFile *VMConfigFile, migrated bool, err error) {
+	document := map[string]interface{}{}
+	if err := json.Unmarshal(contents, &document); err != nil {
+		return nil, false, fmt.Errorf("invalid vm_config: %s", err)
+	}
+
+	version, err := vmConfigFileVersion(document)
+	if err != nil {
+		return nil, false, err
+	}
+	if version > VMConfigFileVersion {
+		return nil, false, fmt.Errorf("vm_config version %d is newer than the supported version %d, please upgrade the plugin", version, VMConfigFileVersion)
+	}
+
+	for ; version < VMConfigFileVersion; version++ {
+		if err := vmConfigMigrations[version-1](document, reported); err != nil {
+			return nil, false, fmt.Errorf("failed to migrate vm_config from version %d: %s", version, err)
+		}
+		document["version"] = version + 1
+		migrated = true
+	}
+
+	migratedContents, err := json.Marshal(document)
+	if err != nil {
+		return nil, false, err
+	}
+	vmConfigFile = &VMConfigFile{}
+	if err := json.Unmarshal(migratedContents, vmConfigFile); err != nil {
+		return nil, false, fmt.Errorf("invalid vm_config: %s", err)
+	}
+
+	return vmConfigFile, migrated, nil
+}
+
+func (v *VMConfigFile) Marshal() ([]byte, error) {
+	return json.MarshalIndent(v, "", "  ")
+}
+
+func vmConfigFileVersion(document map[string]interface{}) (int, error) {
+	value, ok := document["version"]
+	if !ok {
+		return 1, nil
+	}
+
+	version, ok := value.(float64)
+	if !ok || version < 1 || version != float64(int(version)) {
+		return 0, fmt.Errorf("invalid vm_config: %v is not a valid version", value)
+	}
+	return int(version), nil
+}
+
+func migrateVMConfigFromV1(document map[string]interface{}, reported *VMConfig) error {
+	for _, key := range []string{"ip", "domain"} {
+		if _, ok := document[key].(string); !ok {
+			return fmt.Errorf("%s is missing", key)
+		}
+	}
+
+	document["memory"] = reported.Memory
+	document["ova_path"] = reported.OVAPath
+	return nil
+}
+
+func migrateVMConfigFromV2(document map[string]interface{}, reported *VMConfig) error {
+	document["network"] = NetworkHostOnly
+	return nil
+}
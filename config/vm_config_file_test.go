@@ -0,0 +1,153 @@
+package config_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+var _ = Describe("VMConfigFile", func() {
+	Describe(".NewVMConfigFile", func() {
+		It("should build a current version document from the VM config", func() {
+			Expect(config.NewVMConfigFile(&config.VMConfig{
+				Name:       "some-vm",
+				IP:         "192.168.11.11",
+				Domain:     "local.pcfdev.io",
+				CPUs:       2,
+				Memory:     uint64(4096),
+				OVAPath:    "some-ova-path",
+				OVAVersion: "some-ova-version",
+			})).To(Equal(&config.VMConfigFile{
+				Version:    3,
+				IP:         "192.168.11.11",
+				Domain:     "local.pcfdev.io",
+				CPUs:       2,
+				Memory:     uint64(4096),
+				OVAPath:    "some-ova-path",
+				Network:    "hostonly",
+				OVAVersion: "some-ova-version",
+			}))
+		})
+
+		Context("when the VM uses bridged networking", func() {
+			It("should record the network mode and the bridged adapter", func() {
+				Expect(config.NewVMConfigFile(&config.VMConfig{
+					Name:          "some-vm",
+					IP:            "10.0.0.5",
+					Domain:        "10.0.0.5.xip.io",
+					Network:       "bridged",
+					BridgeAdapter: "some-adapter",
+				})).To(Equal(&config.VMConfigFile{
+					Version:       3,
+					IP:            "10.0.0.5",
+					Domain:        "10.0.0.5.xip.io",
+					Network:       "bridged",
+					BridgeAdapter: "some-adapter",
+				}))
+			})
+		})
+	})
+
+	Describe(".ParseVMConfigFile", func() {
+		It("should parse a current version document", func() {
+			vmConfigFile, migrated, err := config.ParseVMConfigFile([]byte(`{"version":3,"ip":"10.0.0.5","domain":"10.0.0.5.xip.io","cpus":2,"memory":4096,"ova_path":"some-ova-path","network":"bridged","bridge_adapter":"some-adapter"}`), &config.VMConfig{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(migrated).To(BeFalse())
+			Expect(vmConfigFile).To(Equal(&config.VMConfigFile{
+				Version:       3,
+				IP:            "10.0.0.5",
+				Domain:        "10.0.0.5.xip.io",
+				CPUs:          2,
+				Memory:        uint64(4096),
+				OVAPath:       "some-ova-path",
+				Network:       "bridged",
+				BridgeAdapter: "some-adapter",
+			}))
+		})
+
+		Context("when the document is version 2", func() {
+			It("should migrate it to the host-only network mode", func() {
+				vmConfigFile, migrated, err := config.ParseVMConfigFile([]byte(`{"version":2,"ip":"192.168.11.11","domain":"local.pcfdev.io","cpus":2,"memory":4096,"ova_path":"some-ova-path"}`), &config.VMConfig{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(migrated).To(BeTrue())
+				Expect(vmConfigFile).To(Equal(&config.VMConfigFile{
+					Version: 3,
+					IP:      "192.168.11.11",
+					Domain:  "local.pcfdev.io",
+					CPUs:    2,
+					Memory:  uint64(4096),
+					OVAPath: "some-ova-path",
+					Network: "hostonly",
+				}))
+			})
+		})
+
+		Context("when the document has no version", func() {
+			It("should migrate it from version 1 using the settings the VM reports", func() {
+				vmConfigFile, migrated, err := config.ParseVMConfigFile([]byte(`{"ip":"192.168.11.11","domain":"local.pcfdev.io"}`), &config.VMConfig{
+					Memory:  uint64(4096),
+					OVAPath: "some-ova-path",
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(migrated).To(BeTrue())
+				Expect(vmConfigFile).To(Equal(&config.VMConfigFile{
+					Version: 3,
+					IP:      "192.168.11.11",
+					Domain:  "local.pcfdev.io",
+					Memory:  uint64(4096),
+					OVAPath: "some-ova-path",
+					Network: "hostonly",
+				}))
+			})
+
+			Context("when the version 1 document is missing a field", func() {
+				It("should return an error", func() {
+					_, _, err := config.ParseVMConfigFile([]byte(`{"ip":"192.168.11.11"}`), &config.VMConfig{})
+					Expect(err).To(MatchError("failed to migrate vm_config from version 1: domain is missing"))
+				})
+			})
+		})
+
+		Context("when the document is newer than the supported version", func() {
+			It("should return an error", func() {
+				_, _, err := config.ParseVMConfigFile([]byte(`{"version":4,"ip":"192.168.11.11","domain":"local.pcfdev.io"}`), &config.VMConfig{})
+				Expect(err).To(MatchError("vm_config version 4 is newer than the supported version 3, please upgrade the plugin"))
+			})
+		})
+
+		Context("when the version is not valid", func() {
+			It("should return an error", func() {
+				_, _, err := config.ParseVMConfigFile([]byte(`{"version":"two"}`), &config.VMConfig{})
+				Expect(err).To(MatchError("invalid vm_config: two is not a valid version"))
+			})
+		})
+
+		Context("when the document is not valid JSON", func() {
+			It("should return an error", func() {
+				_, _, err := config.ParseVMConfigFile([]byte(`some-invalid-json`), &config.VMConfig{})
+				Expect(err).To(MatchError("invalid vm_config: invalid character 's' looking for beginning of value"))
+			})
+		})
+
+		Context("when a field has the wrong type", func() {
+			It("should return an error", func() {
+				_, _, err := config.ParseVMConfigFile([]byte(`{"version":3,"ip":"192.168.11.11","domain":"local.pcfdev.io","cpus":"two"}`), &config.VMConfig{})
+				Expect(err).To(MatchError(ContainSubstring("invalid vm_config:")))
+			})
+		})
+	})
+
+	Describe("#Marshal", func() {
+		It("should encode the document as indented JSON", func() {
+			Expect((&config.VMConfigFile{
+				Version: 3,
+				IP:      "192.168.11.11",
+				Domain:  "local.pcfdev.io",
+				CPUs:    2,
+				Memory:  uint64(4096),
+				OVAPath: "some-ova-path",
+				Network: "hostonly",
+			}).Marshal()).To(MatchJSON(`{"version":3,"ip":"192.168.11.11","domain":"local.pcfdev.io","cpus":2,"memory":4096,"ova_path":"some-ova-path","network":"hostonly"}`))
+		})
+	})
+})
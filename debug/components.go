@@ -0,0 +1,118 @@
+package debug
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultComponentMaxSize = 10 * 1024 * 1024
+
+	componentLogDir = "/var/vcap/sys/log"
+)
+
+var componentJobs = map[string][]string{
+	"cc":          {"cloud_controller_ng", "cloud_controller_worker", "cloud_controller_clock"},
+	"diego":       {"bbs", "auctioneer", "rep", "garden", "route_emitter", "ssh_proxy", "file_server"},
+	"loggregator": {"doppler", "loggregator_trafficcontroller", "metron_agent"},
+	"router":      {"gorouter"},
+	"uaa":         {"uaa"},
+}
+
+func ComponentNames() []string {
+	names := []string{}
+	for name := range componentJobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func ParseComponents(value string) ([]string, error) {
+	if value == "all" {
+		return ComponentNames(), nil
+	}
+
+	components := []string{}
+	seen := map[string]bool{}
+	for _, component := range strings.Split(value, ",") {
+		component = strings.TrimSpace(component)
+		if _, ok := componentJobs[component]; !ok {
+			return nil, fmt.Errorf("unknown component '%s', expected one of: %s, all", component, strings.Join(ComponentNames(), ", "))
+		}
+		if !seen[component] {
+			seen[component] = true
+			components = append(components, component)
+		}
+	}
+	return components, nil
+}
+
+func ComponentLogGlobs(components []string) []string {
+	globs := []string{}
+	for _, component := range components {
+		for _, job := range componentJobs[component] {
+			globs = append(globs, path.Join(componentLogDir, job, "*.log"))
+		}
+	}
+	return globs
+}
+
+// sinceFilterProgram keeps the lines logged after the cutoff. Lines without a timestamp of their own,
+// such as stack traces, share the fate of the line before them. Timestamps like [2006-01-02 15:04:05
+// are in local time, so they are compared with localcutoff, while ISO timestamps are in UTC.
+const sinceFilterProgram = `/^==> .* <==$/ { keep = 1; print; next }
+{
+	if (match($0, /"timestamp": *"?/)) {
+		value = substr($0, RSTART + RLENGTH)
+		if (value ~ /^[0-9]+(\.[0-9]+)?([^0-9-]|$)/) {
+			keep = value + 0 >= since
+		} else if (value ~ /^[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T/) {
+			keep = substr(value, 1, 19) >= cutoff
+		}
+	} else if (match($0, /^\[?[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9][T ][0-9][0-9]:[0-9][0-9]:[0-9][0-9]/)) {
+		value = substr($0, RSTART, RLENGTH)
+		sub(/^\[/, "", value)
+		if (sub(/ /, "T", value)) {
+			keep = value >= localcutoff
+		} else {
+			keep = value >= cutoff
+		}
+	}
+	if (keep) print
+}`
+
+// FilterSince returns a shell command that passes through the log lines, read from tail -v, that were written
+// within the given duration. The cutoff is computed with the guest's clock, which is the clock the logs were written with.
+func FilterSince(since time.Duration) string {
+	seconds := int(math.Ceil(since.Seconds()))
+	return fmt.Sprintf(
+		`awk -v since=$(($(date +%%s) - %[1]d)) -v cutoff=$(date -u -d @$(($(date +%%s) - %[1]d)) +%%Y-%%m-%%dT%%H:%%M:%%S) -v localcutoff=$(date -d @$(($(date +%%s) - %[1]d)) +%%Y-%%m-%%dT%%H:%%M:%%S) '%[2]s'`,
+		seconds, sinceFilterProgram,
+	)
+}
+
+func componentLogFile(component string, since time.Duration, maxSize uint64) logFile {
+	dirs := []string{}
+	for _, job := range componentJobs[component] {
+		dirs = append(dirs, path.Join(componentLogDir, job))
+	}
+
+	find := fmt.Sprintf(`sudo sh -c 'find %s -type f -name "*.log" -exec tail -v -c %d {} + 2>/dev/null'`, strings.Join(dirs, " "), maxSize)
+	if since > 0 {
+		// Files that were not modified since then cannot contain newer lines, so they are skipped before reading them.
+		find = fmt.Sprintf(`sudo sh -c 'find %s -type f -name "*.log" -mmin -%d -exec tail -v -c %d {} + 2>/dev/null' | %s`,
+			strings.Join(dirs, " "), int(math.Ceil(since.Minutes())), maxSize, FilterSince(since))
+	}
+
+	return logFile{
+		command:   []string{fmt.Sprintf("%s | tail -c %d", find, maxSize)},
+		filename:  component + ".log",
+		reciever:  ReceiverGuest,
+		sensitive: true,
+	}
+}
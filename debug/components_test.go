@@ -0,0 +1,92 @@
+package debug_test
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/debug"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Components", func() {
+	Describe(".ComponentNames", func() {
+		It("should return the components in alphabetical order", func() {
+			Expect(debug.ComponentNames()).To(Equal([]string{"cc", "diego", "loggregator", "router", "uaa"}))
+		})
+	})
+
+	Describe(".ComponentLogGlobs", func() {
+		It("should return a log glob for each job of the given components", func() {
+			Expect(debug.ComponentLogGlobs([]string{"router", "uaa"})).To(Equal([]string{
+				"/var/vcap/sys/log/gorouter/*.log",
+				"/var/vcap/sys/log/uaa/*.log",
+			}))
+		})
+	})
+
+	Describe(".ParseComponents", func() {
+		It("should return the components in the order they were given without duplicates", func() {
+			Expect(debug.ParseComponents("router, cc,router")).To(Equal([]string{"router", "cc"}))
+		})
+
+		Context("when all components are requested", func() {
+			It("should return every component", func() {
+				Expect(debug.ParseComponents("all")).To(Equal(debug.ComponentNames()))
+			})
+		})
+
+		Context("when a component is unknown", func() {
+			It("should return an error", func() {
+				_, err := debug.ParseComponents("cc,some-component")
+				Expect(err).To(MatchError("unknown component 'some-component', expected one of: cc, diego, loggregator, router, uaa, all"))
+			})
+		})
+	})
+
+	Describe(".FilterSince", func() {
+		It("should keep only the lines logged within the duration, together with their continuation lines", func() {
+			old := time.Now().Add(-2 * time.Hour).UTC()
+			recent := time.Now().Add(-10 * time.Minute).UTC()
+			local := time.FixedZone("", 5*60*60)
+			input := strings.Join([]string{
+				"==> /var/vcap/sys/log/uaa/uaa.log <==",
+				fmt.Sprintf(`[%s.123] old uaa line`, old.In(local).Format("2006-01-02 15:04:05")),
+				"\tat some.old.StackFrame",
+				fmt.Sprintf(`[%s.456] recent uaa line`, recent.In(local).Format("2006-01-02 15:04:05")),
+				"\tat some.recent.StackFrame",
+				"==> /var/vcap/sys/log/bbs/bbs.log <==",
+				fmt.Sprintf(`{"timestamp":"%d.123456","message":"old epoch line"}`, old.Unix()),
+				fmt.Sprintf(`{"timestamp":"%d.654321","message":"recent epoch line"}`, recent.Unix()),
+				fmt.Sprintf(`{"timestamp":"%s","message":"old rfc3339 line"}`, old.Format(time.RFC3339Nano)),
+				fmt.Sprintf(`{"timestamp":"%s","message":"recent rfc3339 line"}`, recent.Format(time.RFC3339Nano)),
+				"==> /var/vcap/sys/log/gorouter/gorouter.log <==",
+				"a line without any timestamp",
+				fmt.Sprintf(`%s old router line`, old.Format(time.RFC3339)),
+				"",
+			}, "\n")
+
+			command := exec.Command("sh", "-c", debug.FilterSince(time.Hour))
+			command.Env = append(os.Environ(), "TZ=UTC-5")
+			command.Stdin = strings.NewReader(input)
+			output, err := command.Output()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(output)).To(Equal(strings.Join([]string{
+				"==> /var/vcap/sys/log/uaa/uaa.log <==",
+				fmt.Sprintf(`[%s.456] recent uaa line`, recent.In(local).Format("2006-01-02 15:04:05")),
+				"\tat some.recent.StackFrame",
+				"==> /var/vcap/sys/log/bbs/bbs.log <==",
+				fmt.Sprintf(`{"timestamp":"%d.654321","message":"recent epoch line"}`, recent.Unix()),
+				fmt.Sprintf(`{"timestamp":"%s","message":"recent rfc3339 line"}`, recent.Format(time.RFC3339Nano)),
+				"==> /var/vcap/sys/log/gorouter/gorouter.log <==",
+				"a line without any timestamp",
+				"",
+			}, "\n")))
+		})
+	})
+})
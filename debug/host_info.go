@@ -0,0 +1,89 @@
+package debug
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"runtime"
+)
+
+type hostInfo struct {
+	PluginVersion     string            `json:"plugin_version"`
+	PluginSHA         string            `json:"plugin_sha"`
+	OVAVersion        string            `json:"ova_version"`
+	VirtualBoxVersion string            `json:"virtualbox_version"`
+	OS                string            `json:"os"`
+	Arch              string            `json:"arch"`
+	CPUs              int               `json:"cpus"`
+	TotalMemoryMB     uint64            `json:"total_memory_mb"`
+	FreeMemoryMB      uint64            `json:"free_memory_mb"`
+	HTTPProxy         string            `json:"http_proxy"`
+	HTTPSProxy        string            `json:"https_proxy"`
+	NoProxy           string            `json:"no_proxy"`
+	CATrusted         *bool             `json:"ca_trusted"`
+	Errors            map[string]string `json:"errors,omitempty"`
+}
+
+var proxyCredentialsRegex = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9+.-]*://)?[^/]*@`)
+
+func (l *LogFetcher) hostInfo(f *fetch, stop <-chan struct{}) (string, error) {
+	info := &hostInfo{
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		HTTPProxy:  redactProxyCredentials(l.Config.HTTPProxy),
+		HTTPSProxy: redactProxyCredentials(l.Config.HTTPSProxy),
+		NoProxy:    l.Config.NoProxy,
+		Errors:     map[string]string{},
+	}
+
+	if l.Config.Version != nil {
+		info.PluginVersion = l.Config.Version.BuildVersion
+		info.PluginSHA = l.Config.Version.BuildSHA
+		info.OVAVersion = l.Config.Version.OVABuildVersion
+	}
+
+	if version, err := l.VBox.Version(); err != nil {
+		info.Errors["virtualbox_version"] = err.Error()
+	} else {
+		info.VirtualBoxVersion = fmt.Sprintf("%d.%d.%d", version.Major, version.Minor, version.Build)
+	}
+
+	if resources, err := l.System.Resources(); err != nil {
+		info.Errors["resources"] = err.Error()
+	} else {
+		info.CPUs = resources.CPUs
+		info.TotalMemoryMB = resources.TotalMemory
+		info.FreeMemoryMB = resources.FreeMemory
+	}
+
+	if trusted, err := l.caTrusted(f, stop); err != nil {
+		info.Errors["ca_trusted"] = err.Error()
+	} else {
+		info.CATrusted = &trusted
+	}
+
+	contents, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(contents) + "\n", nil
+}
+
+func (l *LogFetcher) caTrusted(f *fetch, stop <-chan struct{}) (bool, error) {
+	privateKey, err := l.privateKey(f)
+	if err != nil {
+		return false, err
+	}
+
+	cert := &bytes.Buffer{}
+	if err := l.SSH.RunSSHCommandUntil("cat /var/pcfdev/openssl/ca_cert.pem", l.guestAddresses(), privateKey, f.timeout, cert, ioutil.Discard, stop); err != nil {
+		return false, fmt.Errorf("failed to read the PCF Dev CA certificate: %s", err)
+	}
+	return l.CertStore.IsTrusted(cert.String())
+}
+
+func redactProxyCredentials(proxy string) string {
+	return proxyCredentialsRegex.ReplaceAllString(proxy, "${1}<redacted>@")
+}
@@ -1,42 +1,88 @@
 package debug
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	"github.com/pivotal-cf/pcfdev-cli/system"
+	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
 )
 
 //go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/debug FS
 type FS interface {
+	Exists(path string) (exists bool, err error)
 	Read(path string) (contents []byte, err error)
 	Write(path string, contents io.Reader, append bool) error
+	Chmod(path string, mode os.FileMode) error
 	Compress(name string, path string, contentPaths []string) error
 	TempDir() (tempDir string, err error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/ssh.go github.com/pivotal-cf/pcfdev-cli/debug SSH
 type SSH interface {
-	GetSSHOutput(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration) (combinedOutput string, err error)
+	RunSSHCommandUntil(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, stdout io.Writer, stderr io.Writer, stop <-chan struct{}) error
 }
 
 //go:generate mockgen -package mocks -destination mocks/driver.go github.com/pivotal-cf/pcfdev-cli/debug Driver
 type Driver interface {
-	VBoxManage(arg ...string) (output []byte, err error)
+	VBoxManageUntil(stop <-chan struct{}, arg ...string) (output []byte, err error)
+}
+
+//go:generate mockgen -package mocks -destination mocks/system.go github.com/pivotal-cf/pcfdev-cli/debug System
+type System interface {
+	Resources() (*system.Resources, error)
+}
+
+//go:generate mockgen -package mocks -destination mocks/vbox.go github.com/pivotal-cf/pcfdev-cli/debug VBox
+type VBox interface {
+	Version() (version *vboxdriver.VBoxDriverVersion, err error)
+}
+
+//go:generate mockgen -package mocks -destination mocks/cert_store.go github.com/pivotal-cf/pcfdev-cli/debug CertStore
+type CertStore interface {
+	IsTrusted(cert string) (bool, error)
 }
 
 type LogFetcher struct {
-	FS     FS
-	SSH    SSH
-	Driver Driver
+	FS        FS
+	SSH       SSH
+	Driver    Driver
+	System    System
+	VBox      VBox
+	CertStore CertStore
 
 	VMConfig *config.VMConfig
 	Config   *config.Config
 }
 
+type FetchOpts struct {
+	Output       string
+	Timeout      time.Duration
+	Components   []string
+	Since        time.Duration
+	MaxSize      uint64
+	Pseudonymize bool
+}
+
+type Result struct {
+	Redactions  map[string]int
+	MappingPath string
+}
+
+const (
+	DefaultOutput  = "pcfdev-debug.tgz"
+	DefaultTimeout = 20 * time.Second
+)
+
 type logFile struct {
 	command   []string
 	reciever  string
@@ -45,11 +91,50 @@ type logFile struct {
 }
 
 const (
-	ReceiverGuest = "Guest"
-	ReceiverHost  = "Host"
+	ReceiverGuest      = "Guest"
+	ReceiverHost       = "Host"
+	ReceiverSystem     = "System"
+	ReceiverConsole    = "Console"
+	ReceiverScreenshot = "Screenshot"
+	ReceiverHostInfo   = "HostInfo"
+)
+
+const (
+	StatusOK      = "ok"
+	StatusError   = "error"
+	StatusTimeout = "timeout"
+	StatusSkipped = "skipped"
 )
 
-func (l *LogFetcher) FetchLogs() error {
+type collectorResult struct {
+	File       string `json:"file"`
+	Status     string `json:"status"`
+	DurationMS int64  `json:"duration_ms"`
+	Bytes      int    `json:"bytes"`
+	Error      string `json:"error,omitempty"`
+
+	output   string
+	streamed bool
+}
+
+type manifest struct {
+	Collectors []*collectorResult `json:"collectors"`
+	Redactions map[string]int     `json:"redactions"`
+}
+
+type fetch struct {
+	dir      string
+	timeout  time.Duration
+	scrubber *SensitiveInformationScrubber
+
+	privateKeyOnce sync.Once
+	privateKey     []byte
+	privateKeyErr  error
+}
+
+var errSkipped = errors.New("skipped")
+
+func (l *LogFetcher) FetchLogs(opts *FetchOpts) (*Result, error) {
 	logFiles := []logFile{
 		logFile{
 			command:   []string{"sudo", "cat", "/var/pcfdev/provision.log"},
@@ -105,82 +190,297 @@ func (l *LogFetcher) FetchLogs() error {
 			reciever:  ReceiverHost,
 			sensitive: false,
 		},
+		logFile{
+			filename:  "host-resources",
+			reciever:  ReceiverSystem,
+			sensitive: false,
+		},
+		logFile{
+			filename:  "console.log",
+			reciever:  ReceiverConsole,
+			sensitive: true,
+		},
+		logFile{
+			command:   []string{"controlvm", l.VMConfig.Name, "screenshotpng"},
+			filename:  "screenshot.png",
+			reciever:  ReceiverScreenshot,
+			sensitive: false,
+		},
+		logFile{
+			filename:  "host-info.json",
+			reciever:  ReceiverHostInfo,
+			sensitive: false,
+		},
 	}
 
-	sensitiveInformationScrubber := &SensitiveInformationScrubber{}
+	maxSize := opts.MaxSize
+	if maxSize == 0 {
+		maxSize = DefaultComponentMaxSize
+	}
+	for _, component := range opts.Components {
+		logFiles = append(logFiles, componentLogFile(component, opts.Since, maxSize))
+	}
 
-	privateKeyBytes, err := l.FS.Read(l.Config.PrivateKeyPath)
+	dir, err := l.FS.TempDir()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	dir, err := l.FS.TempDir()
+	scrubber, err := LoadSensitiveInformationScrubber(l.FS, l.Config.ScrubConfigPath, opts.Pseudonymize)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	addresses := []ssh.SSHAddress{
-		{
-			IP:   "127.0.0.1",
-			Port: l.VMConfig.SSHPort,
-		},
-		{
-			IP:   l.VMConfig.IP,
-			Port: "22",
-		},
+	f := &fetch{
+		dir:      dir,
+		timeout:  opts.Timeout,
+		scrubber: scrubber,
+	}
+	if f.timeout == 0 {
+		f.timeout = DefaultTimeout
+	}
+
+	results := make([]*collectorResult, len(logFiles))
+	var wg sync.WaitGroup
+	for i := range logFiles {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = l.runCollector(logFiles[i], f)
+		}(i)
+	}
+	wg.Wait()
+
+	logFilePaths := []string{}
+	for _, result := range results {
+		var filename, contents string
+		switch {
+		case result.streamed:
+			logFilePaths = append(logFilePaths, filepath.Join(dir, result.File))
+			continue
+		case result.Status == StatusOK:
+			filename = result.File
+			contents = result.output
+		case result.Status == StatusError, result.Status == StatusTimeout:
+			filename = result.File + ".error"
+			contents = result.Error + "\n"
+		default:
+			continue
+		}
+
+		logFilePath := filepath.Join(dir, filename)
+		if err := l.FS.Write(logFilePath, strings.NewReader(contents), false); err != nil {
+			return nil, err
+		}
+		logFilePaths = append(logFilePaths, logFilePath)
+	}
+
+	result := &Result{Redactions: scrubber.Redactions()}
+
+	manifestContents, err := json.MarshalIndent(&manifest{Collectors: results, Redactions: result.Redactions}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	manifestPath := filepath.Join(dir, "manifest.json")
+	if err := l.FS.Write(manifestPath, strings.NewReader(string(manifestContents)), false); err != nil {
+		return nil, err
+	}
+	logFilePaths = append(logFilePaths, manifestPath)
+
+	output := opts.Output
+	if output == "" {
+		output = DefaultOutput
+	}
+
+	if err := l.FS.Compress(strings.TrimSuffix(filepath.Base(output), ".tgz"), filepath.Dir(output), logFilePaths); err != nil {
+		return nil, err
+	}
+
+	if opts.Pseudonymize {
+		mappingContents, err := json.MarshalIndent(scrubber.Mapping(), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		result.MappingPath = strings.TrimSuffix(output, ".tgz") + "-pseudonyms.json"
+		if err := l.FS.Write(result.MappingPath, strings.NewReader(string(mappingContents)), false); err != nil {
+			return nil, err
+		}
+		if err := l.FS.Chmod(result.MappingPath, 0600); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// privateKey is only read once a collector needs the guest, so that a VM which never came up far enough
+// to be reached over SSH still gets its host logs, console log and screenshot collected.
+func (l *LogFetcher) privateKey(f *fetch) ([]byte, error) {
+	f.privateKeyOnce.Do(func() {
+		f.privateKey, f.privateKeyErr = l.FS.Read(l.Config.PrivateKeyPath)
+		if f.privateKeyErr != nil {
+			f.privateKeyErr = fmt.Errorf("unable to read private key: %s", f.privateKeyErr)
+		}
+	})
+	return f.privateKey, f.privateKeyErr
+}
+
+func (l *LogFetcher) runCollector(logFile logFile, f *fetch) *collectorResult {
+	type collectorOutput struct {
+		output string
+		bytes  int
+		err    error
 	}
 
-	for _, logFile := range logFiles {
-		switch logFile.reciever {
-		case ReceiverGuest:
-			output, err := l.SSH.GetSSHOutput(strings.Join(logFile.command, " "), addresses, privateKeyBytes, 20*time.Second)
-			if err != nil {
-				return err
-			}
+	start := time.Now()
+	done := make(chan collectorOutput, 1)
+	stop := make(chan struct{})
+	go func() {
+		if logFile.reciever == ReceiverGuest {
+			bytes, err := l.streamGuestLog(logFile, f, stop)
+			done <- collectorOutput{bytes: bytes, err: err}
+			return
+		}
+		output, err := l.collect(logFile, f, stop)
+		done <- collectorOutput{output: output, bytes: len(output), err: err}
+	}()
 
-			if logFile.sensitive {
-				output = sensitiveInformationScrubber.Scrub(output)
-			}
+	result := &collectorResult{File: logFile.filename}
+	select {
+	case collected := <-done:
+		switch collected.err {
+		case nil:
+			result.Status = StatusOK
+			result.output = collected.output
+			result.streamed = logFile.reciever == ReceiverGuest
+			result.Bytes = collected.bytes
+		case errSkipped:
+			result.Status = StatusSkipped
+		default:
+			result.Status = StatusError
+			result.Error = collected.err.Error()
+		}
+	case <-time.After(f.timeout):
+		// Stopping closes the SSH session or kills VBoxManage, so that nothing is left running, and the
+		// collector is waited for so that nothing is written to the directory while it is compressed.
+		close(stop)
+		<-done
+		result.Status = StatusTimeout
+		result.Error = fmt.Sprintf("timed out after %s", f.timeout)
+	}
+	result.DurationMS = int64(time.Since(start) / time.Millisecond)
 
-			if err := l.FS.Write(
-				filepath.Join(dir, logFile.filename),
-				strings.NewReader(output),
-				false,
-			); err != nil {
-				return err
-			}
-		case ReceiverHost:
-			output, err := l.Driver.VBoxManage(logFile.command...)
-			if err != nil {
-				return err
-			}
+	return result
+}
+
+func (l *LogFetcher) streamGuestLog(logFile logFile, f *fetch, stop <-chan struct{}) (int, error) {
+	privateKey, err := l.privateKey(f)
+	if err != nil {
+		return 0, err
+	}
 
-			scrubbedOutput := string(output)
-			if logFile.sensitive {
-				scrubbedOutput = sensitiveInformationScrubber.Scrub(scrubbedOutput)
-			}
+	reader, writer := io.Pipe()
+	var output io.Writer = writer
+	var scrubWriter io.WriteCloser
+	if logFile.sensitive {
+		scrubWriter = f.scrubber.ScrubWriter(writer)
+		output = scrubWriter
+	}
 
-			if err := l.FS.Write(
-				filepath.Join(dir, logFile.filename),
-				strings.NewReader(scrubbedOutput),
-				false,
-			); err != nil {
-				return err
-			}
+	sshErr := make(chan error, 1)
+	go func() {
+		err := l.SSH.RunSSHCommandUntil(strings.Join(logFile.command, " "), l.guestAddresses(), privateKey, f.timeout, output, output, stop)
+		if scrubWriter != nil {
+			scrubWriter.Close()
 		}
+		writer.CloseWithError(err)
+		sshErr <- err
+	}()
+
+	counter := &countingReader{reader: reader}
+	writeErr := l.FS.Write(filepath.Join(f.dir, logFile.filename), counter, false)
+	reader.Close()
+	err = <-sshErr
+	if writeErr != nil {
+		return 0, writeErr
 	}
+	if err != nil {
+		return 0, err
+	}
+	return counter.bytes, nil
+}
+
+func (l *LogFetcher) collect(logFile logFile, f *fetch, stop <-chan struct{}) (string, error) {
+	var output string
+	switch logFile.reciever {
+	case ReceiverHost:
+		vboxOutput, err := l.Driver.VBoxManageUntil(stop, logFile.command...)
+		if err != nil {
+			return "", err
+		}
+		output = string(vboxOutput)
+	case ReceiverSystem:
+		resources, err := l.System.Resources()
+		if err != nil {
+			return "", err
+		}
+		output = resources.String()
+	case ReceiverConsole:
+		consoleLogPath := l.Config.ConsoleLogPath(l.VMConfig.Name)
+		exists, err := l.FS.Exists(consoleLogPath)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return "", errSkipped
+		}
+
+		contents, err := l.FS.Read(consoleLogPath)
+		if err != nil {
+			return "", err
+		}
+		output = string(contents)
+	case ReceiverScreenshot:
+		screenshotPath := filepath.Join(f.dir, "screenshot-capture.png")
+		if _, err := l.Driver.VBoxManageUntil(stop, append(logFile.command, screenshotPath)...); err != nil {
+			return "", err
+		}
 
-	if err := l.FS.Compress("pcfdev-debug", ".", l.getLogFileNames(logFiles, dir)); err != nil {
-		return err
+		contents, err := l.FS.Read(screenshotPath)
+		if err != nil {
+			return "", err
+		}
+		output = string(contents)
+	case ReceiverHostInfo:
+		return l.hostInfo(f, stop)
 	}
 
-	return nil
+	if logFile.sensitive {
+		output = f.scrubber.Scrub(output)
+	}
+	return output, nil
 }
 
-func (l *LogFetcher) getLogFileNames(logFiles []logFile, parentDir string) []string {
-	logFileNames := []string{}
-	for _, logFile := range logFiles {
-		logFileNames = append(logFileNames, filepath.Join(parentDir, logFile.filename))
+func (l *LogFetcher) guestAddresses() []ssh.SSHAddress {
+	return []ssh.SSHAddress{
+		{
+			IP:   "127.0.0.1",
+			Port: l.VMConfig.SSHPort,
+		},
+		{
+			IP:   l.VMConfig.IP,
+			Port: "22",
+		},
 	}
-	return logFileNames
+}
+
+type countingReader struct {
+	reader io.Reader
+	bytes  int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.bytes += n
+	return n, err
 }
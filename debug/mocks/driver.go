@@ -28,17 +28,18 @@ func (_m *MockDriver) EXPECT() *_MockDriverRecorder {
 	return _m.recorder
 }
 
-func (_m *MockDriver) VBoxManage(_param0 ...string) ([]byte, error) {
-	_s := []interface{}{}
-	for _, _x := range _param0 {
+func (_m *MockDriver) VBoxManageUntil(_param0 <-chan struct{}, _param1 ...string) ([]byte, error) {
+	_s := []interface{}{_param0}
+	for _, _x := range _param1 {
 		_s = append(_s, _x)
 	}
-	ret := _m.ctrl.Call(_m, "VBoxManage", _s...)
+	ret := _m.ctrl.Call(_m, "VBoxManageUntil", _s...)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (_mr *_MockDriverRecorder) VBoxManage(arg0 ...interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "VBoxManage", arg0...)
+func (_mr *_MockDriverRecorder) VBoxManageUntil(arg0 interface{}, arg1 ...interface{}) *gomock.Call {
+	_s := append([]interface{}{arg0}, arg1...)
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VBoxManageUntil", _s...)
 }
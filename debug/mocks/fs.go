@@ -6,6 +6,7 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	io "io"
+	fs "io/fs"
 )
 
 // Mock of FS interface
@@ -29,6 +30,16 @@ func (_m *MockFS) EXPECT() *_MockFSRecorder {
 	return _m.recorder
 }
 
+func (_m *MockFS) Chmod(_param0 string, _param1 fs.FileMode) error {
+	ret := _m.ctrl.Call(_m, "Chmod", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockFSRecorder) Chmod(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Chmod", arg0, arg1)
+}
+
 func (_m *MockFS) Compress(_param0 string, _param1 string, _param2 []string) error {
 	ret := _m.ctrl.Call(_m, "Compress", _param0, _param1, _param2)
 	ret0, _ := ret[0].(error)
@@ -39,6 +50,17 @@ func (_mr *_MockFSRecorder) Compress(arg0, arg1, arg2 interface{}) *gomock.Call
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Compress", arg0, arg1, arg2)
 }
 
+func (_m *MockFS) Exists(_param0 string) (bool, error) {
+	ret := _m.ctrl.Call(_m, "Exists", _param0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFSRecorder) Exists(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Exists", arg0)
+}
+
 func (_m *MockFS) Read(_param0 string) ([]byte, error) {
 	ret := _m.ctrl.Call(_m, "Read", _param0)
 	ret0, _ := ret[0].([]byte)
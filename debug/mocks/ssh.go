@@ -6,6 +6,7 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	ssh "github.com/pivotal-cf/pcfdev-cli/ssh"
+	io "io"
 	time "time"
 )
 
@@ -30,13 +31,12 @@ func (_m *MockSSH) EXPECT() *_MockSSHRecorder {
 	return _m.recorder
 }
 
-func (_m *MockSSH) GetSSHOutput(_param0 string, _param1 []ssh.SSHAddress, _param2 []byte, _param3 time.Duration) (string, error) {
-	ret := _m.ctrl.Call(_m, "GetSSHOutput", _param0, _param1, _param2, _param3)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+func (_m *MockSSH) RunSSHCommandUntil(_param0 string, _param1 []ssh.SSHAddress, _param2 []byte, _param3 time.Duration, _param4 io.Writer, _param5 io.Writer, _param6 <-chan struct{}) error {
+	ret := _m.ctrl.Call(_m, "RunSSHCommandUntil", _param0, _param1, _param2, _param3, _param4, _param5, _param6)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-func (_mr *_MockSSHRecorder) GetSSHOutput(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSSHOutput", arg0, arg1, arg2, arg3)
+func (_mr *_MockSSHRecorder) RunSSHCommandUntil(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RunSSHCommandUntil", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
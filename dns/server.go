@@ -0,0 +1,216 @@
+package dns
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	typeA   = 1
+	classIN = 1
+
+	rcodeFormatError = 1
+	rcodeServerError = 2
+)
+
+var resolvConfPaths = []string{
+	"/etc/resolv.conf",
+	// systemd-resolved lists its stub in /etc/resolv.conf and the real nameservers here.
+	"/run/systemd/resolve/resolv.conf",
+}
+
+type Server struct {
+	Timeout time.Duration
+}
+
+func (s *Server) ListenAndServe(address string, domain string, ip string, upstream string) error {
+	if upstream == "" {
+		var err error
+		if upstream, err = DefaultUpstream(); err != nil {
+			return err
+		}
+	}
+
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return s.Serve(conn, domain, ip, upstream)
+}
+
+func (s *Server) Serve(conn net.PacketConn, domain string, ip string, upstream string) error {
+	vmIP := net.ParseIP(ip).To4()
+	if vmIP == nil {
+		return fmt.Errorf("%s is not a valid IPv4 address", ip)
+	}
+
+	for {
+		buffer := make([]byte, 512)
+		n, addr, err := conn.ReadFrom(buffer)
+		if err != nil {
+			return err
+		}
+
+		go func(query []byte, addr net.Addr) {
+			response, err := s.respond(query, strings.ToLower(strings.TrimSuffix(domain, ".")), vmIP, upstream)
+			if err != nil {
+				return
+			}
+			conn.WriteTo(response, addr)
+		}(buffer[:n], addr)
+	}
+}
+
+func (s *Server) respond(query []byte, domain string, ip net.IP, upstream string) ([]byte, error) {
+	name, qtype, qclass, questionEnd, err := parseQuestion(query)
+	if err != nil {
+		if len(query) < 12 {
+			return nil, err
+		}
+		return reply(query, len(query), rcodeFormatError, nil), nil
+	}
+
+	if name != domain && !strings.HasSuffix(name, "."+domain) {
+		response, err := s.forward(query, upstream)
+		if err != nil {
+			return reply(query, questionEnd, rcodeServerError, nil), nil
+		}
+		return response, nil
+	}
+
+	if qtype != typeA || qclass != classIN {
+		return reply(query, questionEnd, 0, nil), nil
+	}
+
+	answer := []byte{0xc0, 0x0c}
+	answer = appendUint16(answer, typeA)
+	answer = appendUint16(answer, classIN)
+	answer = append(answer, 0, 0, 0, 60)
+	answer = appendUint16(answer, 4)
+	answer = append(answer, ip...)
+
+	return reply(query, questionEnd, 0, answer), nil
+}
+
+func (s *Server) forward(query []byte, upstream string) ([]byte, error) {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+
+	conn, err := net.DialTimeout("udp", upstream, timeout)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(timeout))
+	if _, err := conn.Write(query); err != nil {
+		return nil, err
+	}
+
+	buffer := make([]byte, 4096)
+	n, err := conn.Read(buffer)
+	if err != nil {
+		return nil, err
+	}
+	return buffer[:n], nil
+}
+
+func parseQuestion(query []byte) (name string, qtype uint16, qclass uint16, end int, err error) {
+	if len(query) < 12 {
+		return "", 0, 0, 0, errors.New("query is too short")
+	}
+	if query[2]&0x80 != 0 || binary.BigEndian.Uint16(query[4:6]) != 1 {
+		return "", 0, 0, 0, errors.New("query must contain exactly one question")
+	}
+
+	labels := []string{}
+	offset := 12
+	for {
+		if offset >= len(query) {
+			return "", 0, 0, 0, errors.New("question is truncated")
+		}
+		length := int(query[offset])
+		offset++
+		if length == 0 {
+			break
+		}
+		if length > 63 || offset+length > len(query) {
+			return "", 0, 0, 0, errors.New("question contains an invalid label")
+		}
+		labels = append(labels, string(query[offset:offset+length]))
+		offset += length
+	}
+
+	if offset+4 > len(query) {
+		return "", 0, 0, 0, errors.New("question is truncated")
+	}
+
+	qtype = binary.BigEndian.Uint16(query[offset : offset+2])
+	qclass = binary.BigEndian.Uint16(query[offset+2 : offset+4])
+	return strings.ToLower(strings.Join(labels, ".")), qtype, qclass, offset + 4, nil
+}
+
+func reply(query []byte, questionEnd int, rcode byte, answer []byte) []byte {
+	response := make([]byte, questionEnd, questionEnd+len(answer))
+	copy(response, query[:questionEnd])
+
+	response[2] = 0x80 | (query[2] & 0x79) | 0x04
+	response[3] = 0x80 | rcode
+
+	if rcode == rcodeFormatError {
+		binary.BigEndian.PutUint16(response[4:6], 0)
+		response = response[:12]
+	}
+
+	answerCount := uint16(0)
+	if answer != nil {
+		answerCount = 1
+	}
+	binary.BigEndian.PutUint16(response[6:8], answerCount)
+	binary.BigEndian.PutUint16(response[8:10], 0)
+	binary.BigEndian.PutUint16(response[10:12], 0)
+
+	return append(response, answer...)
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func DefaultUpstream() (string, error) {
+	for _, path := range resolvConfPaths {
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if upstream, ok := UpstreamFromResolvConf(contents); ok {
+			return upstream, nil
+		}
+	}
+	return "", fmt.Errorf("no nameserver to forward queries to was found in %s, use --upstream to choose one", strings.Join(resolvConfPaths, " or "))
+}
+
+func UpstreamFromResolvConf(contents []byte) (upstream string, ok bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+		if ip := net.ParseIP(fields[1]); ip != nil && !ip.IsLoopback() {
+			return net.JoinHostPort(fields[1], "53"), true
+		}
+	}
+	return "", false
+}
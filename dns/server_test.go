@@ -0,0 +1,161 @@
+package dns_test
+
+import (
+	"encoding/binary"
+	"net"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/dns"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		server   *dns.Server
+		conn     net.PacketConn
+		upstream net.PacketConn
+		client   net.Conn
+	)
+
+	BeforeEach(func() {
+		var err error
+		upstream, err = net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		go func() {
+			buffer := make([]byte, 512)
+			for {
+				n, addr, err := upstream.ReadFrom(buffer)
+				if err != nil {
+					return
+				}
+				response := append([]byte{}, buffer[:n]...)
+				response[2] |= 0x80
+				response[3] = 0x80
+				upstream.WriteTo(response, addr)
+			}
+		}()
+
+		conn, err = net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		server = &dns.Server{
+			Timeout: time.Second,
+		}
+		go server.Serve(conn, "local.pcfdev.io", "192.168.11.11", upstream.LocalAddr().String())
+
+		client, err = net.Dial("udp", conn.LocalAddr().String())
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		client.Close()
+		conn.Close()
+		upstream.Close()
+	})
+
+	exchange := func(query []byte) []byte {
+		client.SetDeadline(time.Now().Add(2 * time.Second))
+		_, err := client.Write(query)
+		Expect(err).NotTo(HaveOccurred())
+
+		buffer := make([]byte, 512)
+		n, err := client.Read(buffer)
+		Expect(err).NotTo(HaveOccurred())
+		return buffer[:n]
+	}
+
+	Context("when the name is a subdomain of the PCF Dev domain", func() {
+		It("should answer with the VM IP", func() {
+			query := buildQuery("Some-App.local.pcfdev.io", 1)
+			response := exchange(query)
+
+			Expect(response[0:2]).To(Equal([]byte{0x12, 0x34}))
+			Expect(response[2] & 0x84).To(Equal(byte(0x84)))
+			Expect(response[3] & 0x0f).To(Equal(byte(0)))
+			Expect(binary.BigEndian.Uint16(response[6:8])).To(Equal(uint16(1)))
+			Expect(response[len(query) : len(query)+2]).To(Equal([]byte{0xc0, 0x0c}))
+			Expect(response[len(response)-4:]).To(Equal([]byte{192, 168, 11, 11}))
+		})
+	})
+
+	Context("when the name is the PCF Dev domain", func() {
+		It("should answer with the VM IP", func() {
+			response := exchange(buildQuery("local.pcfdev.io", 1))
+
+			Expect(binary.BigEndian.Uint16(response[6:8])).To(Equal(uint16(1)))
+			Expect(response[len(response)-4:]).To(Equal([]byte{192, 168, 11, 11}))
+		})
+	})
+
+	Context("when the query is not for an A record", func() {
+		It("should answer with no records", func() {
+			response := exchange(buildQuery("some-app.local.pcfdev.io", 28))
+
+			Expect(response[3] & 0x0f).To(Equal(byte(0)))
+			Expect(binary.BigEndian.Uint16(response[6:8])).To(Equal(uint16(0)))
+		})
+	})
+
+	Context("when the name is outside of the PCF Dev domain", func() {
+		It("should forward the query upstream", func() {
+			query := buildQuery("example.com", 1)
+			response := exchange(query)
+
+			Expect(response[4:]).To(Equal(query[4:]))
+			Expect(binary.BigEndian.Uint16(response[6:8])).To(Equal(uint16(0)))
+		})
+
+		Context("when the upstream does not respond", func() {
+			It("should answer with a server failure", func() {
+				upstream.Close()
+
+				response := exchange(buildQuery("notlocal.pcfdev.io", 1))
+				Expect(response[3] & 0x0f).To(Equal(byte(2)))
+			})
+		})
+	})
+
+	Context("when the query is malformed", func() {
+		It("should answer with a format error", func() {
+			query := buildQuery("some-app.local.pcfdev.io", 1)
+			response := exchange(query[:20])
+
+			Expect(response).To(HaveLen(12))
+			Expect(response[3] & 0x0f).To(Equal(byte(1)))
+		})
+	})
+
+	Context("when the IP is not a valid IPv4 address", func() {
+		It("should return an error", func() {
+			Expect((&dns.Server{}).Serve(conn, "local.pcfdev.io", "some-bad-ip", "some-upstream")).To(MatchError("some-bad-ip is not a valid IPv4 address"))
+		})
+	})
+
+	Describe("UpstreamFromResolvConf", func() {
+		It("should return the first non-loopback nameserver", func() {
+			contents := []byte("# comment\nnameserver 127.0.0.53\nnameserver 10.0.0.2\nnameserver 10.0.0.3\n")
+			upstream, ok := dns.UpstreamFromResolvConf(contents)
+			Expect(ok).To(BeTrue())
+			Expect(upstream).To(Equal("10.0.0.2:53"))
+		})
+
+		Context("when there are only loopback nameservers", func() {
+			It("should not return a nameserver", func() {
+				_, ok := dns.UpstreamFromResolvConf([]byte("nameserver 127.0.0.53\noptions edns0\n"))
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+})
+
+func buildQuery(name string, qtype uint16) []byte {
+	query := []byte{0x12, 0x34, 0x01, 0x00, 0, 1, 0, 0, 0, 0, 0, 0}
+	for _, label := range strings.Split(name, ".") {
+		query = append(query, byte(len(label)))
+		query = append(query, label...)
+	}
+	query = append(query, 0, byte(qtype>>8), byte(qtype), 0, 1)
+	return query
+}
@@ -0,0 +1,23 @@
+package dns
+
+import "io"
+
+const DefaultAddress = "127.0.0.1:5354"
+
+//go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/dns FS
+type FS interface {
+	Exists(path string) (exists bool, err error)
+	Remove(path string) error
+	TempDir() (string, error)
+	Write(path string, contents io.Reader, append bool) error
+}
+
+//go:generate mockgen -package mocks -destination mocks/cmd_runner.go github.com/pivotal-cf/pcfdev-cli/dns CmdRunner
+type CmdRunner interface {
+	Run(command string, args ...string) (output []byte, err error)
+}
+
+type SplitDNS struct {
+	FS        FS
+	CmdRunner CmdRunner
+}
@@ -0,0 +1,157 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// resolvedConfPath is only removed, since earlier versions configured systemd-resolved with a drop-in.
+	resolvedConfPath = "/etc/systemd/resolved.conf.d/pcfdev.conf"
+	dnsmasqDir       = "/etc/NetworkManager/dnsmasq.d"
+	dnsmasqConfPath  = "/etc/NetworkManager/dnsmasq.d/pcfdev.conf"
+)
+
+var resolvedLinkRegex = regexp.MustCompile(`^Link \d+ \((vboxnet\d+)\):(.*)$`)
+
+func (s *SplitDNS) Configure(domain string, address string, ip string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.CmdRunner.Run("systemctl", "is-active", "--quiet", "systemd-resolved"); err == nil {
+		return s.configureResolved(domain, host, port, ip)
+	}
+
+	exists, err := s.FS.Exists(dnsmasqDir)
+	if err != nil {
+		return err
+	}
+	if exists {
+		contents := fmt.Sprintf("server=/%s/%s#%s\n", domain, host, port)
+		if err := s.install(contents, dnsmasqConfPath); err != nil {
+			return err
+		}
+		_, err := s.CmdRunner.Run("sudo", "systemctl", "reload", "NetworkManager")
+		return err
+	}
+
+	return errors.New("neither systemd-resolved nor NetworkManager with dnsmasq was found")
+}
+
+func (s *SplitDNS) Unconfigure() error {
+	var errs []string
+
+	if err := s.revertResolvedLinks(); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if err := s.uninstall(resolvedConfPath, "restart", "systemd-resolved"); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if err := s.uninstall(dnsmasqConfPath, "reload", "NetworkManager"); err != nil {
+		errs = append(errs, err.Error())
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+// configureResolved routes only the domain to the responder, on the host-only interface the VM is reached through.
+// A global DNS= setting would make the responder a nameserver for every other query as well.
+func (s *SplitDNS) configureResolved(domain string, host string, port string, ip string) error {
+	output, err := s.CmdRunner.Run("ip", "-o", "route", "get", ip)
+	if err != nil {
+		return err
+	}
+	link, src := routeField(output, "dev"), routeField(output, "src")
+	if !strings.HasPrefix(link, "vboxnet") {
+		return fmt.Errorf("systemd-resolved can only be configured for PCF Dev on a host-only network, but %s is reached through %s", ip, link)
+	}
+	if responder := net.ParseIP(host); responder == nil || responder.IsLoopback() || responder.IsUnspecified() {
+		address := net.JoinHostPort(src, port)
+		return fmt.Errorf("systemd-resolved sends queries for %s through %s, so they must be answered on its address: run 'cf dev dns configure --address %s' and 'cf dev dns start --address %s'", domain, link, address, address)
+	}
+
+	server := net.JoinHostPort(host, port)
+	if port == "53" {
+		server = host
+	}
+	if err := s.uninstall(resolvedConfPath, "restart", "systemd-resolved"); err != nil {
+		return err
+	}
+	if _, err := s.CmdRunner.Run("sudo", "resolvectl", "dns", link, server); err != nil {
+		return err
+	}
+	_, err = s.CmdRunner.Run("sudo", "resolvectl", "domain", link, "~"+domain)
+	return err
+}
+
+// revertResolvedLinks reverts the host-only interfaces that have routing domains, which only configureResolved sets.
+func (s *SplitDNS) revertResolvedLinks() error {
+	if _, err := s.CmdRunner.Run("systemctl", "is-active", "--quiet", "systemd-resolved"); err != nil {
+		return nil
+	}
+
+	output, err := s.CmdRunner.Run("resolvectl", "domain")
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(string(output), "\n") {
+		match := resolvedLinkRegex.FindStringSubmatch(line)
+		if match == nil || !strings.Contains(match[2], "~") {
+			continue
+		}
+		if _, err := s.CmdRunner.Run("sudo", "resolvectl", "revert", match[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func routeField(output []byte, name string) string {
+	fields := strings.Fields(string(output))
+	for i := 0; i < len(fields)-1; i++ {
+		if fields[i] == name {
+			return fields[i+1]
+		}
+	}
+	return ""
+}
+
+func (s *SplitDNS) install(contents string, path string) error {
+	tempDir, err := s.FS.TempDir()
+	if err != nil {
+		return err
+	}
+	defer s.FS.Remove(tempDir)
+
+	tempPath := filepath.Join(tempDir, filepath.Base(path))
+	if err := s.FS.Write(tempPath, strings.NewReader(contents), false); err != nil {
+		return err
+	}
+	if _, err := s.CmdRunner.Run("sudo", "mkdir", "-p", filepath.Dir(path)); err != nil {
+		return err
+	}
+	_, err = s.CmdRunner.Run("sudo", "install", "-m", "0644", tempPath, path)
+	return err
+}
+
+func (s *SplitDNS) uninstall(path string, action string, service string) error {
+	exists, err := s.FS.Exists(path)
+	if err != nil || !exists {
+		return err
+	}
+
+	if _, err := s.CmdRunner.Run("sudo", "rm", "-f", path); err != nil {
+		return err
+	}
+	_, err = s.CmdRunner.Run("sudo", "systemctl", action, service)
+	return err
+}
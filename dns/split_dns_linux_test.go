@@ -0,0 +1,174 @@
+package dns_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/dns"
+	"github.com/pivotal-cf/pcfdev-cli/dns/mocks"
+)
+
+var _ = Describe("SplitDNS", func() {
+	var (
+		splitDNS      *dns.SplitDNS
+		mockCtrl      *gomock.Controller
+		mockFS        *mocks.MockFS
+		mockCmdRunner *mocks.MockCmdRunner
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockFS = mocks.NewMockFS(mockCtrl)
+		mockCmdRunner = mocks.NewMockCmdRunner(mockCtrl)
+		splitDNS = &dns.SplitDNS{
+			FS:        mockFS,
+			CmdRunner: mockCmdRunner,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#Configure", func() {
+		Context("when systemd-resolved is active", func() {
+			It("should route the domain to the responder on the host-only interface", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved"),
+					mockCmdRunner.EXPECT().Run("ip", "-o", "route", "get", "192.168.11.11").Return([]byte("192.168.11.11 dev vboxnet0 src 192.168.11.1 uid 1000 \\    cache \n"), nil),
+					mockFS.EXPECT().Exists("/etc/systemd/resolved.conf.d/pcfdev.conf").Return(false, nil),
+					mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "dns", "vboxnet0", "192.168.11.1:5354"),
+					mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "domain", "vboxnet0", "~local.pcfdev.io"),
+				)
+
+				Expect(splitDNS.Configure("local.pcfdev.io", "192.168.11.1:5354", "192.168.11.11")).To(Succeed())
+			})
+
+			Context("when a drop-in from an earlier version is installed", func() {
+				It("should remove it", func() {
+					gomock.InOrder(
+						mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved"),
+						mockCmdRunner.EXPECT().Run("ip", "-o", "route", "get", "192.168.11.11").Return([]byte("192.168.11.11 dev vboxnet0 src 192.168.11.1 uid 1000\n"), nil),
+						mockFS.EXPECT().Exists("/etc/systemd/resolved.conf.d/pcfdev.conf").Return(true, nil),
+						mockCmdRunner.EXPECT().Run("sudo", "rm", "-f", "/etc/systemd/resolved.conf.d/pcfdev.conf"),
+						mockCmdRunner.EXPECT().Run("sudo", "systemctl", "restart", "systemd-resolved"),
+						mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "dns", "vboxnet0", "192.168.11.1"),
+						mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "domain", "vboxnet0", "~local.pcfdev.io"),
+					)
+
+					Expect(splitDNS.Configure("local.pcfdev.io", "192.168.11.1:53", "192.168.11.11")).To(Succeed())
+				})
+			})
+
+			Context("when the responder listens on a loopback address", func() {
+				It("should return an error suggesting the host-only interface address", func() {
+					gomock.InOrder(
+						mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved"),
+						mockCmdRunner.EXPECT().Run("ip", "-o", "route", "get", "192.168.11.11").Return([]byte("192.168.11.11 dev vboxnet0 src 192.168.11.1 uid 1000\n"), nil),
+					)
+
+					Expect(splitDNS.Configure("local.pcfdev.io", "127.0.0.1:5354", "192.168.11.11")).To(MatchError(
+						"systemd-resolved sends queries for local.pcfdev.io through vboxnet0, so they must be answered on its address: " +
+							"run 'cf dev dns configure --address 192.168.11.1:5354' and 'cf dev dns start --address 192.168.11.1:5354'"))
+				})
+			})
+
+			Context("when the VM is not on a host-only network", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved"),
+						mockCmdRunner.EXPECT().Run("ip", "-o", "route", "get", "10.0.0.11").Return([]byte("10.0.0.11 dev eth0 src 10.0.0.2 uid 1000\n"), nil),
+					)
+
+					Expect(splitDNS.Configure("local.pcfdev.io", "10.0.0.2:5354", "10.0.0.11")).To(MatchError("systemd-resolved can only be configured for PCF Dev on a host-only network, but 10.0.0.11 is reached through eth0"))
+				})
+			})
+
+			Context("when setting the link's nameserver fails", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved"),
+						mockCmdRunner.EXPECT().Run("ip", "-o", "route", "get", "192.168.11.11").Return([]byte("192.168.11.11 dev vboxnet0 src 192.168.11.1 uid 1000\n"), nil),
+						mockFS.EXPECT().Exists("/etc/systemd/resolved.conf.d/pcfdev.conf").Return(false, nil),
+						mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "dns", "vboxnet0", "192.168.11.1:5354").Return(nil, errors.New("some-error")),
+					)
+
+					Expect(splitDNS.Configure("local.pcfdev.io", "192.168.11.1:5354", "192.168.11.11")).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when NetworkManager uses dnsmasq", func() {
+			It("should install a dnsmasq server entry for the domain", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved").Return(nil, errors.New("inactive")),
+					mockFS.EXPECT().Exists("/etc/NetworkManager/dnsmasq.d").Return(true, nil),
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Write("some-temp-dir/pcfdev.conf", strings.NewReader("server=/local.pcfdev.io/127.0.0.1#5354\n"), false),
+					mockCmdRunner.EXPECT().Run("sudo", "mkdir", "-p", "/etc/NetworkManager/dnsmasq.d"),
+					mockCmdRunner.EXPECT().Run("sudo", "install", "-m", "0644", "some-temp-dir/pcfdev.conf", "/etc/NetworkManager/dnsmasq.d/pcfdev.conf"),
+					mockFS.EXPECT().Remove("some-temp-dir"),
+					mockCmdRunner.EXPECT().Run("sudo", "systemctl", "reload", "NetworkManager"),
+				)
+
+				Expect(splitDNS.Configure("local.pcfdev.io", "127.0.0.1:5354", "192.168.11.11")).To(Succeed())
+			})
+		})
+
+		Context("when neither resolver is available", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved").Return(nil, errors.New("inactive")),
+					mockFS.EXPECT().Exists("/etc/NetworkManager/dnsmasq.d").Return(false, nil),
+				)
+
+				Expect(splitDNS.Configure("local.pcfdev.io", "127.0.0.1:5354", "192.168.11.11")).To(MatchError("neither systemd-resolved nor NetworkManager with dnsmasq was found"))
+			})
+		})
+	})
+
+	Describe("#Unconfigure", func() {
+		It("should remove any installed configuration", func() {
+			gomock.InOrder(
+				mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved"),
+				mockCmdRunner.EXPECT().Run("resolvectl", "domain").Return([]byte("Global:\nLink 2 (eth0): example.com\nLink 5 (vboxnet0): ~local.pcfdev.io\nLink 6 (vboxnet1):\n"), nil),
+				mockCmdRunner.EXPECT().Run("sudo", "resolvectl", "revert", "vboxnet0"),
+				mockFS.EXPECT().Exists("/etc/systemd/resolved.conf.d/pcfdev.conf").Return(true, nil),
+				mockCmdRunner.EXPECT().Run("sudo", "rm", "-f", "/etc/systemd/resolved.conf.d/pcfdev.conf"),
+				mockCmdRunner.EXPECT().Run("sudo", "systemctl", "restart", "systemd-resolved"),
+				mockFS.EXPECT().Exists("/etc/NetworkManager/dnsmasq.d/pcfdev.conf").Return(false, nil),
+			)
+
+			Expect(splitDNS.Unconfigure()).To(Succeed())
+		})
+
+		Context("when nothing is configured", func() {
+			It("should not change any configuration", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved"),
+					mockCmdRunner.EXPECT().Run("resolvectl", "domain").Return([]byte("Global:\nLink 2 (eth0): example.com\nLink 6 (vboxnet1):\n"), nil),
+					mockFS.EXPECT().Exists("/etc/systemd/resolved.conf.d/pcfdev.conf").Return(false, nil),
+					mockFS.EXPECT().Exists("/etc/NetworkManager/dnsmasq.d/pcfdev.conf").Return(false, nil),
+				)
+
+				Expect(splitDNS.Unconfigure()).To(Succeed())
+			})
+		})
+
+		Context("when removing the configuration fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("systemctl", "is-active", "--quiet", "systemd-resolved").Return(nil, errors.New("inactive")),
+					mockFS.EXPECT().Exists("/etc/systemd/resolved.conf.d/pcfdev.conf").Return(false, nil),
+					mockFS.EXPECT().Exists("/etc/NetworkManager/dnsmasq.d/pcfdev.conf").Return(true, nil),
+					mockCmdRunner.EXPECT().Run("sudo", "rm", "-f", "/etc/NetworkManager/dnsmasq.d/pcfdev.conf").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(splitDNS.Unconfigure()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,11 @@
+package dns
+
+import "errors"
+
+func (s *SplitDNS) Configure(domain string, address string, ip string) error {
+	return errors.New("split DNS configuration is only supported on Linux")
+}
+
+func (s *SplitDNS) Unconfigure() error {
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 
 type ConcreteOVADownloader struct {
 	FS                   FS
+	System               System
 	PivnetClient         Client
 	Config               *config.Config
 	Token                Token
@@ -35,6 +36,11 @@ type FS interface {
 	DeleteAllExcept(path string, filenames []string) error
 }
 
+//go:generate mockgen -package mocks -destination mocks/system.go github.com/pivotal-cf/pcfdev-cli/downloader System
+type System interface {
+	CheckDiskSpace(path string, required uint64) error
+}
+
 //go:generate mockgen -package mocks -destination mocks/token.go github.com/pivotal-cf/pcfdev-cli/downloader Token
 type Token interface {
 	Save() error
@@ -69,6 +75,7 @@ func (d *ConcreteOVADownloader) Setup() error {
 }
 
 func (d *ConcreteOVADownloader) Download() (string, error) {
+	var diskSpaceErr error
 	err := helpers.ExecuteWithAttempts(func() error {
 		exists, err := d.FS.Exists(d.Config.PartialOVAPath)
 		if err != nil {
@@ -91,6 +98,12 @@ func (d *ConcreteOVADownloader) Download() (string, error) {
 		}
 		defer ova.Close()
 
+		if ova.ContentLength > 0 {
+			if diskSpaceErr = d.System.CheckDiskSpace(d.Config.OVADir, uint64(ova.ContentLength)); diskSpaceErr != nil {
+				return nil
+			}
+		}
+
 		if err := d.Token.Save(); err != nil {
 			return err
 		}
@@ -105,6 +118,9 @@ func (d *ConcreteOVADownloader) Download() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if diskSpaceErr != nil {
+		return "", diskSpaceErr
+	}
 
 	return d.FS.MD5(d.Config.PartialOVAPath)
 }
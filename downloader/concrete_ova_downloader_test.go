@@ -22,6 +22,7 @@ var _ = Describe("ConcreteOVADownloader", func() {
 		mockClient *mocks.MockClient
 		mockFS     *mocks.MockFS
 		mockToken  *mocks.MockToken
+		mockSystem *mocks.MockSystem
 	)
 
 	BeforeEach(func() {
@@ -29,10 +30,12 @@ var _ = Describe("ConcreteOVADownloader", func() {
 		mockClient = mocks.NewMockClient(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
 		mockToken = mocks.NewMockToken(mockCtrl)
+		mockSystem = mocks.NewMockSystem(mockCtrl)
 
 		downloader = &dl.ConcreteOVADownloader{
 			PivnetClient: mockClient,
 			FS:           mockFS,
+			System:       mockSystem,
 			Config: &config.Config{
 				OVADir:         "some-ova-dir",
 				OVAPath:        "some-ova-path",
@@ -152,6 +155,38 @@ var _ = Describe("ConcreteOVADownloader", func() {
 				Expect(md5).To(Equal("some-md5"))
 			})
 
+			Context("when the size of the download is known", func() {
+				It("should check that there is enough free disk space for it", func() {
+					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), ContentLength: 17}
+					gomock.InOrder(
+						mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
+						mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
+						mockSystem.EXPECT().CheckDiskSpace("some-ova-dir", uint64(17)),
+						mockToken.EXPECT().Save(),
+						mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
+						mockFS.EXPECT().MD5("some-partial-ova-path").Return("some-md5", nil),
+					)
+
+					md5, err := downloader.Download()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(md5).To(Equal("some-md5"))
+				})
+
+				Context("when there is not enough free disk space", func() {
+					It("should return the error without retrying", func() {
+						readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), ContentLength: 17}
+						gomock.InOrder(
+							mockFS.EXPECT().Exists("some-partial-ova-path").Return(false, nil),
+							mockClient.EXPECT().DownloadOVA(int64(0)).Return(readCloser, nil),
+							mockSystem.EXPECT().CheckDiskSpace("some-ova-dir", uint64(17)).Return(errors.New("some-error")),
+						)
+
+						_, err := downloader.Download()
+						Expect(err).To(MatchError("some-error"))
+					})
+				})
+			})
+
 			Context("when there is an issue seeing if the partial ova exists", func() {
 				It("should retry the check", func() {
 					readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
@@ -320,11 +355,12 @@ var _ = Describe("ConcreteOVADownloader", func() {
 
 		Context("when there is a partial ova present", func() {
 			It("should resume the download of the partial ova", func() {
-				readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents"))}
+				readCloser := &pivnet.DownloadReader{ReadCloser: ioutil.NopCloser(strings.NewReader("some-ova-contents")), ContentLength: 17, ExistingLength: 24}
 				gomock.InOrder(
 					mockFS.EXPECT().Exists("some-partial-ova-path").Return(true, nil),
 					mockFS.EXPECT().Length("some-partial-ova-path").Return(int64(24), nil),
 					mockClient.EXPECT().DownloadOVA(int64(24)).Return(readCloser, nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-ova-dir", uint64(17)),
 					mockToken.EXPECT().Save(),
 					mockFS.EXPECT().Write("some-partial-ova-path", readCloser, true),
 					mockFS.EXPECT().MD5("some-partial-ova-path").Return("some-md5", nil),
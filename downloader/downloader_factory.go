@@ -20,6 +20,7 @@ type Downloader interface {
 
 type DownloaderFactory struct {
 	FS                   FS
+	System               System
 	Config               *config.Config
 	PivnetClient         Client
 	Token                Token
@@ -35,6 +36,7 @@ func (f *DownloaderFactory) Create() (Downloader, error) {
 
 	ovaDownloader := &ConcreteOVADownloader{
 		FS:                   f.FS,
+		System:               f.System,
 		Config:               f.Config,
 		PivnetClient:         f.PivnetClient,
 		Token:                f.Token,
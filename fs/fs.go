@@ -2,6 +2,7 @@ package fs
 
 import (
 	"archive/tar"
+	"bufio"
 	"compress/gzip"
 	cMD5 "crypto/md5"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"time"
 )
 
 type FS struct{}
@@ -28,6 +30,10 @@ func (fs *FS) Read(path string) (contents []byte, err error) {
 	return ioutil.ReadFile(path)
 }
 
+func (fs *FS) Open(path string) (file io.ReadCloser, err error) {
+	return os.Open(path)
+}
+
 func (fs *FS) Write(path string, contents io.Reader, append bool) error {
 	var flag int
 	if append {
@@ -48,6 +54,31 @@ func (fs *FS) Write(path string, contents io.Reader, append bool) error {
 	return nil
 }
 
+func (fs *FS) WriteAtomic(path string, contents io.Reader) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+"-")
+	if err != nil {
+		return fmt.Errorf("failed to open file: %s", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := io.Copy(file, contents); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to copy contents to file: %s", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to copy contents to file: %s", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to copy contents to file: %s", err)
+	}
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to set permissions on %s: %s", file.Name(), err)
+	}
+
+	return fs.Move(file.Name(), path)
+}
+
 func (fs *FS) CreateDir(path string) error {
 	if err := os.MkdirAll(path, 0755); err != nil {
 		return fmt.Errorf("failed to create directory %s: %s", path, err)
@@ -140,8 +171,20 @@ func (fs *FS) Extract(archivePath string, destinationPath string, pattern string
 	if err != nil {
 		return fmt.Errorf("failed to open %s: %s", archivePath, err)
 	}
+	defer archive.Close()
 
-	reader := tar.NewReader(archive)
+	bufferedArchive := bufio.NewReader(archive)
+	var reader *tar.Reader
+	if magic, err := bufferedArchive.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(bufferedArchive)
+		if err != nil {
+			return fmt.Errorf("malformed tar %s:%s", archivePath, err)
+		}
+		defer gzipReader.Close()
+		reader = tar.NewReader(gzipReader)
+	} else {
+		reader = tar.NewReader(bufferedArchive)
+	}
 
 	regex := regexp.MustCompile(pattern)
 	for {
@@ -206,6 +249,21 @@ func (fs *FS) Compress(name string, path string, contentPaths []string) error {
 	return nil
 }
 
+const TempDirPrefix = "pcfdev-"
+
 func (fs *FS) TempDir() (string, error) {
-	return ioutil.TempDir("", "")
+	return ioutil.TempDir("", TempDirPrefix)
+}
+
+func (fs *FS) Glob(pattern string) (paths []string, err error) {
+	return filepath.Glob(pattern)
+}
+
+func (fs *FS) ModTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to stat %s: %s", path, err)
+	}
+
+	return info.ModTime(), nil
 }
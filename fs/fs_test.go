@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	pcfdevfs "github.com/pivotal-cf/pcfdev-cli/fs"
 
@@ -43,6 +44,26 @@ var _ = Describe("Filesystem", func() {
 		})
 	})
 
+	Describe("#Open", func() {
+		Context("when the file exists", func() {
+			It("should return a reader for the contents of the file", func() {
+				Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file"), []byte("some-contents"), 0644)).To(Succeed())
+
+				file, err := fs.Open(filepath.Join(tmpDir, "some-file"))
+				Expect(err).NotTo(HaveOccurred())
+				defer file.Close()
+				Expect(ioutil.ReadAll(file)).To(Equal([]byte("some-contents")))
+			})
+		})
+
+		Context("when the file does not exist", func() {
+			It("should return an error", func() {
+				_, err := fs.Open(filepath.Join(tmpDir, "some-bad-file"))
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 	Describe("#Exists", func() {
 		Context("when the file exists", func() {
 			BeforeEach(func() {
@@ -112,6 +133,39 @@ var _ = Describe("Filesystem", func() {
 		})
 	})
 
+	Describe("#WriteAtomic", func() {
+		It("should create a file with path and write contents", func() {
+			Expect(fs.WriteAtomic(filepath.Join(tmpDir, "some-file"), strings.NewReader("some-contents"))).To(Succeed())
+			data, err := ioutil.ReadFile(filepath.Join(tmpDir, "some-file"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("some-contents"))
+		})
+
+		Context("when the file exists already", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file"), []byte("some content that will be replaced"), 0644)).To(Succeed())
+			})
+
+			It("should replace the file without leaving temporary files behind", func() {
+				Expect(fs.WriteAtomic(filepath.Join(tmpDir, "some-file"), strings.NewReader("new contents"))).To(Succeed())
+				data, err := ioutil.ReadFile(filepath.Join(tmpDir, "some-file"))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal("new contents"))
+
+				files, err := ioutil.ReadDir(tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(files).To(HaveLen(1))
+			})
+		})
+
+		Context("when path is invalid", func() {
+			It("should return an error", func() {
+				err := fs.WriteAtomic(filepath.Join(tmpDir, "some-bad-dir", "some-file"), strings.NewReader("some-contents"))
+				Expect(err.Error()).To(ContainSubstring("failed to open file:"))
+			})
+		})
+	})
+
 	Describe("#CreateDir", func() {
 		Context("when the directory does not exist", func() {
 			It("should create the directory", func() {
@@ -320,6 +374,21 @@ var _ = Describe("Filesystem", func() {
 			Expect(os.IsNotExist(err)).To(BeTrue())
 		})
 
+		Context("when the archive is gzipped", func() {
+			It("should extract the matching file from the archive to the destination", func() {
+				Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file"), []byte("some-contents"), 0644)).To(Succeed())
+				Expect(fs.Compress("some-tgz", tmpDir, []string{filepath.Join(tmpDir, "some-file")})).To(Succeed())
+
+				Expect(
+					fs.Extract(
+						filepath.Join(tmpDir, "some-tgz.tgz"),
+						filepath.Join(tmpDir, "some-extracted-file"),
+						`some-file$`),
+				).To(Succeed())
+				Expect(ioutil.ReadFile(filepath.Join(tmpDir, "some-extracted-file"))).To(Equal([]byte("some-contents")))
+			})
+		})
+
 		Context("when no matching file exists in the archive", func() {
 			It("should return an error", func() {
 				Expect(
@@ -388,8 +457,39 @@ var _ = Describe("Filesystem", func() {
 	})
 
 	Describe("#TempDir", func() {
-		It("should create a temp directory", func() {
-			Expect(fs.TempDir()).To(BeAnExistingFile())
+		It("should create a temp directory with the PCF Dev prefix", func() {
+			dir, err := fs.TempDir()
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(dir)
+
+			Expect(dir).To(BeAnExistingFile())
+			Expect(filepath.Base(dir)).To(HavePrefix("pcfdev-"))
+		})
+	})
+
+	Describe("#Glob", func() {
+		It("should return the paths matching the pattern", func() {
+			Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file.partial"), []byte{}, 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file.ova"), []byte{}, 0644)).To(Succeed())
+
+			Expect(fs.Glob(filepath.Join(tmpDir, "*.partial"))).To(Equal([]string{filepath.Join(tmpDir, "some-file.partial")}))
+		})
+	})
+
+	Describe("#ModTime", func() {
+		It("should return the modification time of the path", func() {
+			modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+			Expect(ioutil.WriteFile(filepath.Join(tmpDir, "some-file"), []byte{}, 0644)).To(Succeed())
+			Expect(os.Chtimes(filepath.Join(tmpDir, "some-file"), modTime, modTime)).To(Succeed())
+
+			Expect(fs.ModTime(filepath.Join(tmpDir, "some-file"))).To(BeTemporally("==", modTime))
+		})
+
+		Context("when the path does not exist", func() {
+			It("should return an error", func() {
+				_, err := fs.ModTime(filepath.Join(tmpDir, "some-bad-file"))
+				Expect(err).To(MatchError(ContainSubstring("failed to stat")))
+			})
 		})
 	})
 
@@ -1,6 +1,9 @@
 package helpers
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 func RemoveDuplicates(collection []string) []string {
 	mapping := make(map[string]bool, 0)
@@ -47,6 +50,11 @@ func ExecuteWithTimeout(command func() error, timeout time.Duration, delay time.
 	}
 }
 
+// ShellQuote quotes value as a single word for sh, whatever characters it contains.
+func ShellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
 func IgnoreErrorFrom(_ ...interface{}) {
 	// Used as documentation of methods that return errors we are ignoring
 	// This makes Errcheck stop complaining.
@@ -21,6 +21,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/runner"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/system"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	"github.com/pivotal-cf/pcfdev-cli/ui"
 	"github.com/pivotal-cf/pcfdev-cli/vbox"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
@@ -54,6 +55,9 @@ func main() {
 	confirmInstalled(cfui)
 
 	fileSystem := &fs.FS{}
+	sys := &system.System{
+		FS: fileSystem,
+	}
 	driver := &vboxdriver.VBoxDriver{
 		FS:        fileSystem,
 		CmdRunner: &runner.CmdRunner{},
@@ -62,9 +66,7 @@ func main() {
 		vmName,
 		md5,
 		[]byte(insecurePrivateKey),
-		&system.System{
-			FS: fileSystem,
-		},
+		sys,
 		&config.Version{
 			BuildVersion:    buildVersion,
 			BuildSHA:        buildSHA,
@@ -74,6 +76,11 @@ func main() {
 		cfui.Failed("Error: %s", err)
 		os.Exit(1)
 	}
+	addressTable, err := address.LoadTable(conf.AddressTablePath)
+	if err != nil {
+		cfui.Failed("Error: %s", err)
+		os.Exit(1)
+	}
 	token := &pivnet.Token{
 		Config: conf,
 		FS:     fileSystem,
@@ -86,11 +93,13 @@ func main() {
 		Token:         token,
 	}
 	token.Client = client
+	tracer := &timing.Tracer{}
 	sshClient := &ssh.SSH{
 		Terminal: &ssh.TerminalWrapper{},
 		WindowResizer: &ssh.ConcreteWindowResizer{
 			DoneChannel: make(chan bool),
 		},
+		Tracer: tracer,
 	}
 	vbx := &vbox.VBox{
 		SSH:    sshClient,
@@ -99,8 +108,11 @@ func main() {
 		Picker: &address.Picker{
 			Network: &network.Network{},
 			Driver:  driver,
+			Table:   addressTable,
 		},
+		System: sys,
 		Config: conf,
+		Tracer: tracer,
 	}
 	httpClientIgnoringEnvironmentProxies := &http.Client{
 		Transport: &http.Transport{
@@ -112,11 +124,13 @@ func main() {
 		Config: conf,
 		Exit:   &exit.Exit{},
 		CmdBuilder: &cmd.Builder{
-			Client: client,
-			Config: conf,
+			AddressTable: addressTable,
+			Client:       client,
+			Config:       conf,
 			DownloaderFactory: &downloader.DownloaderFactory{
 				PivnetClient:         client,
 				FS:                   fileSystem,
+				System:               sys,
 				Token:                token,
 				Config:               conf,
 				DownloadAttempts:     10,
@@ -124,14 +138,23 @@ func main() {
 			},
 			EULAUI: &ui.UI{},
 			FS:     fileSystem,
+			History: &timing.History{
+				FS:   fileSystem,
+				Path: conf.HistoryPath,
+			},
+			System: sys,
+			Tracer: tracer,
 			UI:     cfui,
 			VBox:   vbx,
 			VMBuilder: &vm.VBoxBuilder{
-				VBox:   vbx,
-				Config: conf,
-				FS:     fileSystem,
-				SSH:    sshClient,
-				UI:     &plugin.NonTranslatingUI{cfui},
+				VBox:         vbx,
+				Config:       conf,
+				FS:           fileSystem,
+				SSH:          sshClient,
+				UI:           &plugin.NonTranslatingUI{cfui},
+				AddressTable: addressTable,
+				System:       sys,
+				Tracer:       tracer,
 				Client: &vmClient.Client{
 					Timeout:    time.Second * 20,
 					HttpClient: httpClientIgnoringEnvironmentProxies,
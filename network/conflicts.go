@@ -0,0 +1,77 @@
+package network
+
+import (
+	"fmt"
+	"net"
+)
+
+func Conflicts(subnetIP string, interfaces []*Interface, routes []*Route, ignored []*Interface) ([]string, error) {
+	_, subnet, err := net.ParseCIDR(subnetIP + "/24")
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a supported IP address", subnetIP)
+	}
+
+	conflicts := []string{}
+	linkRoutes := map[string]bool{}
+	for _, iface := range interfaces {
+		linkRoutes[iface.Name+" "+iface.CIDR] = true
+
+		if isIgnored(iface.Name, iface.HardwareAddress, ignored) {
+			continue
+		}
+
+		if iface.CIDR == "" {
+			if subnet.Contains(net.ParseIP(iface.IP)) {
+				conflicts = append(conflicts, fmt.Sprintf("interface %s has address %s", interfaceName(iface.Name), iface.IP))
+			}
+			continue
+		}
+
+		_, ifaceNet, err := net.ParseCIDR(iface.CIDR)
+		if err != nil {
+			return nil, err
+		}
+		if overlaps(subnet, ifaceNet) {
+			conflicts = append(conflicts, fmt.Sprintf("interface %s has address %s on network %s", interfaceName(iface.Name), iface.IP, iface.CIDR))
+		}
+	}
+
+	for _, route := range routes {
+		if isIgnored(route.Interface, "", ignored) || linkRoutes[route.Interface+" "+route.Destination] {
+			continue
+		}
+
+		_, destination, err := net.ParseCIDR(route.Destination)
+		if err != nil {
+			return nil, err
+		}
+		if ones, _ := destination.Mask.Size(); ones == 0 {
+			continue
+		}
+		if overlaps(subnet, destination) {
+			conflicts = append(conflicts, fmt.Sprintf("route to %s via interface %s", route.Destination, route.Interface))
+		}
+	}
+
+	return conflicts, nil
+}
+
+func overlaps(a *net.IPNet, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func isIgnored(name string, hardwareAddress string, ignored []*Interface) bool {
+	for _, iface := range ignored {
+		if (name != "" && name == iface.Name) || (hardwareAddress != "" && hardwareAddress == iface.HardwareAddress) {
+			return true
+		}
+	}
+	return false
+}
+
+func interfaceName(name string) string {
+	if name == "" {
+		return "<unknown>"
+	}
+	return name
+}
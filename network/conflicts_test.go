@@ -0,0 +1,97 @@
+package network_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/network"
+)
+
+var _ = Describe("Conflicts", func() {
+	var (
+		interfaces []*network.Interface
+		routes     []*network.Route
+	)
+
+	BeforeEach(func() {
+		interfaces = []*network.Interface{
+			{Name: "eth0", IP: "10.0.0.5", CIDR: "10.0.0.0/24", HardwareAddress: "some-eth0-address"},
+			{Name: "docker0", IP: "192.168.33.1", CIDR: "192.168.0.0/16", HardwareAddress: "some-docker0-address"},
+			{Name: "vboxnet0", IP: "192.168.11.1", CIDR: "192.168.11.0/24", HardwareAddress: "some-vboxnet0-address"},
+		}
+		routes = []*network.Route{
+			{Interface: "eth0", Destination: "0.0.0.0/0"},
+			{Interface: "eth0", Destination: "10.0.0.0/24"},
+			{Interface: "tun0", Destination: "172.16.0.0/12"},
+			{Interface: "vboxnet0", Destination: "192.168.11.0/24"},
+		}
+	})
+
+	It("should report interfaces whose network overlaps the subnet", func() {
+		Expect(network.Conflicts("192.168.44.1", interfaces, routes, nil)).To(Equal([]string{
+			"interface docker0 has address 192.168.33.1 on network 192.168.0.0/16",
+		}))
+	})
+
+	It("should report routes that overlap the subnet", func() {
+		Expect(network.Conflicts("172.20.5.1", interfaces, routes, nil)).To(Equal([]string{
+			"route to 172.16.0.0/12 via interface tun0",
+		}))
+	})
+
+	It("should ignore the default route", func() {
+		Expect(network.Conflicts("10.1.0.1", interfaces, routes, nil)).To(BeEmpty())
+	})
+
+	It("should not report the link route of a conflicting interface twice", func() {
+		Expect(network.Conflicts("10.0.0.1", interfaces, routes, nil)).To(Equal([]string{
+			"interface eth0 has address 10.0.0.5 on network 10.0.0.0/24",
+		}))
+	})
+
+	Context("when interfaces are ignored", func() {
+		It("should not report their addresses or routes", func() {
+			interfaces = interfaces[2:]
+			Expect(network.Conflicts("192.168.11.1", interfaces, routes, []*network.Interface{
+				{Name: "vboxnet0", HardwareAddress: "some-vboxnet0-address"},
+			})).To(BeEmpty())
+		})
+	})
+
+	Context("when an interface has no network", func() {
+		It("should compare its address", func() {
+			Expect(network.Conflicts("192.168.55.1", []*network.Interface{{IP: "192.168.55.1"}}, nil, nil)).To(Equal([]string{
+				"interface <unknown> has address 192.168.55.1",
+			}))
+		})
+	})
+
+	Context("when the subnet is invalid", func() {
+		It("should return an error", func() {
+			_, err := network.Conflicts("some-bad-ip", interfaces, routes, nil)
+			Expect(err).To(MatchError("some-bad-ip is not a supported IP address"))
+		})
+	})
+})
+
+var _ = Describe("ParseRoutes", func() {
+	It("should parse the kernel routing table", func() {
+		contents := []byte("Iface\tDestination\tGateway \tFlags\tRefCnt\tUse\tMetric\tMask\t\tMTU\tWindow\tIRTT\n" +
+			"eth0\t00000000\t0100000A\t0003\t0\t0\t100\t00000000\t0\t0\t0\n" +
+			"eth0\t0000000A\t00000000\t0001\t0\t0\t100\t00FFFFFF\t0\t0\t0\n" +
+			"tun0\t000010AC\t00000000\t0001\t0\t0\t0\t0000F0FF\t0\t0\t0\n" +
+			"down0\t0000A8C0\t00000000\t0000\t0\t0\t0\t00FFFFFF\t0\t0\t0\n")
+
+		Expect(network.ParseRoutes(contents)).To(Equal([]*network.Route{
+			{Interface: "eth0", Destination: "0.0.0.0/0"},
+			{Interface: "eth0", Destination: "10.0.0.0/24"},
+			{Interface: "tun0", Destination: "172.16.0.0/12"},
+		}))
+	})
+
+	Context("when an address is invalid", func() {
+		It("should return an error", func() {
+			_, err := network.ParseRoutes([]byte("eth0\tsome-bad-address\t00000000\t0001\t0\t0\t0\t00FFFFFF\t0\t0\t0\n"))
+			Expect(err).To(MatchError("invalid route address some-bad-address"))
+		})
+	})
+})
@@ -10,6 +10,7 @@ type Network struct{}
 type Interface struct {
 	HardwareAddress string
 	IP              string
+	CIDR            string
 	Name            string
 	Exists          bool
 }
@@ -20,12 +21,16 @@ func (n *Network) HasIPCollision(ip string) (bool, error) {
 		return false, err
 	}
 
-	for _, networkInterface := range interfaces {
-		if networkInterface.IP == ip {
-			return true, nil
-		}
+	routes, err := n.Routes()
+	if err != nil {
+		return false, err
+	}
+
+	conflicts, err := Conflicts(ip, interfaces, routes, nil)
+	if err != nil {
+		return false, err
 	}
-	return false, nil
+	return len(conflicts) > 0, nil
 }
 
 func (n *Network) Interfaces() (interfaces []*Interface, err error) {
@@ -45,8 +50,15 @@ func (n *Network) Interfaces() (interfaces []*Interface, err error) {
 			addrString := strings.Split(addr.String(), "/")[0]
 
 			if IsIPV4(addrString) {
+				var cidr string
+				if _, ipNet, err := net.ParseCIDR(addr.String()); err == nil {
+					cidr = ipNet.String()
+				}
+
 				interfaces = append(interfaces, &Interface{
 					IP:              addrString,
+					CIDR:            cidr,
+					Name:            iface.Name,
 					HardwareAddress: iface.HardwareAddr.String(),
 					Exists:          true,
 				})
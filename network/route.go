@@ -0,0 +1,62 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const routeFlagUp = 0x1
+
+type Route struct {
+	Interface   string
+	Destination string
+}
+
+func ParseRoutes(contents []byte) ([]*Route, error) {
+	routes := []*Route{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 8 || fields[0] == "Iface" {
+			continue
+		}
+
+		destination, err := parseHexIP(fields[1])
+		if err != nil {
+			return nil, err
+		}
+		flags, err := strconv.ParseUint(fields[3], 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route flags %s", fields[3])
+		}
+		mask, err := parseHexIP(fields[7])
+		if err != nil {
+			return nil, err
+		}
+
+		if flags&routeFlagUp == 0 {
+			continue
+		}
+
+		ipNet := &net.IPNet{IP: destination, Mask: net.IPMask(mask)}
+		routes = append(routes, &Route{
+			Interface:   fields[0],
+			Destination: ipNet.String(),
+		})
+	}
+
+	return routes, scanner.Err()
+}
+
+func parseHexIP(field string) (net.IP, error) {
+	value, err := strconv.ParseUint(field, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid route address %s", field)
+	}
+	return net.IPv4(byte(value), byte(value>>8), byte(value>>16), byte(value>>24)).To4(), nil
+}
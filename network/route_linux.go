@@ -0,0 +1,12 @@
+package network
+
+import "io/ioutil"
+
+func (n *Network) Routes() ([]*Route, error) {
+	contents, err := ioutil.ReadFile("/proc/net/route")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseRoutes(contents)
+}
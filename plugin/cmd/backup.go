@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+const BACKUP_ARGS = 1
+
+type BackupCmd struct {
+	VMBuilder VMBuilder
+	VBox      VBox
+	Config    *config.Config
+	path      string
+}
+
+func (b *BackupCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, BACKUP_ARGS); err != nil {
+		return err
+	}
+
+	path, err := tarballPath(flagContext.Args()[0])
+	if err != nil {
+		return err
+	}
+	b.path, err = filepath.Abs(path)
+	return err
+}
+
+func (b *BackupCmd) Run() error {
+	vm, err := b.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.Backup(b.path)
+}
+
+func (b *BackupCmd) getVM() (vm vm.VM, err error) {
+	name, err := b.VBox.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = b.Config.DefaultVMName
+	}
+	if name != b.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return b.VMBuilder.VM(name)
+}
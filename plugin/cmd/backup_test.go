@@ -0,0 +1,140 @@
+package cmd_test
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("BackupCmd", func() {
+	var (
+		backupCmd     *cmd.BackupCmd
+		mockCtrl      *gomock.Controller
+		mockVBox      *mocks.MockVBox
+		mockVMBuilder *mocks.MockVMBuilder
+		mockVM        *vmMocks.MockVM
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		backupCmd = &cmd.BackupCmd{
+			VBox:      mockVBox,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(backupCmd.Parse([]string{"some-backup.tgz"})).To(Succeed())
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(backupCmd.Parse([]string{})).NotTo(Succeed())
+				Expect(backupCmd.Parse([]string{"some-backup.tgz", "some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(backupCmd.Parse([]string{"some-backup.tgz", "--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+		Context("when the path has another extension", func() {
+			It("should fail", func() {
+				Expect(backupCmd.Parse([]string{"some-backup.tar.gz"})).To(MatchError("some-backup.tar.gz must end in .tgz"))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(backupCmd.Parse([]string{"some-backup.tgz"})).To(Succeed())
+		})
+
+		It("should call Backup on the VM with the absolute path", func() {
+			path, err := filepath.Abs("some-backup.tgz")
+			Expect(err).NotTo(HaveOccurred())
+
+			gomock.InOrder(
+				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Backup(path),
+			)
+
+			Expect(backupCmd.Run()).To(Succeed())
+		})
+
+		Context("when the path has no extension", func() {
+			It("should add the .tgz extension", func() {
+				Expect(backupCmd.Parse([]string{"some-backup"})).To(Succeed())
+				path, err := filepath.Abs("some-backup.tgz")
+				Expect(err).NotTo(HaveOccurred())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Backup(path),
+				)
+
+				Expect(backupCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(backupCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(backupCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an error building the VM", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(backupCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the VM fails to backup", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Backup(gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(backupCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -2,13 +2,21 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"path/filepath"
+	"strings"
 
 	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/address"
 	"github.com/pivotal-cf/pcfdev-cli/cert"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/dns"
 	"github.com/pivotal-cf/pcfdev-cli/downloader"
 	"github.com/pivotal-cf/pcfdev-cli/runner"
+	"github.com/pivotal-cf/pcfdev-cli/system"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
+	"github.com/pivotal-cf/pcfdev-cli/vbox"
 	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 )
@@ -24,7 +32,11 @@ type UI interface {
 type VBox interface {
 	GetVMName() (name string, err error)
 	VMConfig(vmName string) (vmConfig *config.VMConfig, err error)
+	VMConfigFile(vmName string) (vmConfigFile *config.VMConfigFile, err error)
 	DestroyPCFDevVMs() (err error)
+	CheckNetwork() (candidates []*address.Candidate, err error)
+	FindGarbage() (garbage *vbox.Garbage, err error)
+	CollectGarbage(garbage *vbox.Garbage) error
 	Version() (version *vboxdriver.VBoxDriverVersion, err error)
 }
 
@@ -62,6 +74,16 @@ type AutoCmd interface {
 	Run() error
 }
 
+//go:generate mockgen -package mocks -destination mocks/system.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd System
+type System interface {
+	Resources() (*system.Resources, error)
+}
+
+//go:generate mockgen -package mocks -destination mocks/history.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd History
+type History interface {
+	Save(record *timing.Record) error
+}
+
 //go:generate mockgen -package mocks -destination mocks/cert_store.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd CertStore
 type CertStore interface {
 	Unstore() error
@@ -77,12 +99,27 @@ func parse(flagContext flags.FlagContext, args []string, expectedLength int) err
 	return nil
 }
 
+// tarballPath adds the .tgz extension the tarballs are written with to a path without one.
+func tarballPath(path string) (string, error) {
+	if strings.HasSuffix(path, ".tgz") {
+		return path, nil
+	}
+	if filepath.Ext(path) != "" {
+		return "", fmt.Errorf("%s must end in .tgz", path)
+	}
+	return path + ".tgz", nil
+}
+
 type Builder struct {
+	AddressTable      *address.Table
 	Client            Client
 	Config            *config.Config
 	DownloaderFactory DownloaderFactory
 	EULAUI            EULAUI
 	FS                FS
+	History           History
+	System            System
+	Tracer            *timing.Tracer
 	UI                UI
 	VBox              VBox
 	VMBuilder         VMBuilder
@@ -99,11 +136,16 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 			UntrustCmd: &UntrustCmd{
 				CertStore: &cert.CertStore{
 					SystemStore: &cert.ConcreteSystemStore{
-						FS:        b.FS,
-						CmdRunner: &runner.CmdRunner{},
+						FS:           b.FS,
+						CmdRunner:    &runner.CmdRunner{},
+						AddressTable: b.AddressTable,
 					},
 				},
 			},
+			SplitDNS: &dns.SplitDNS{
+				FS:        b.FS,
+				CmdRunner: &runner.CmdRunner{},
+			},
 		}, nil
 	case "download":
 		return &DownloadCmd{
@@ -127,12 +169,17 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 			VBox:      b.VBox,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
+			UI:        b.UI,
+			Tracer:    b.Tracer,
+			History:   b.History,
 		}, nil
 	case "start":
 		return &StartCmd{
 			VBox:      b.VBox,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
+			Tracer:    b.Tracer,
+			History:   b.History,
 			DownloadCmd: &DownloadCmd{
 				VBox:              b.VBox,
 				UI:                b.UI,
@@ -153,6 +200,11 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 				Config:     b.Config,
 				AutoTarget: true,
 			},
+			SeedCmd: &SeedCmd{
+				VBox:      b.VBox,
+				VMBuilder: b.VMBuilder,
+				Config:    b.Config,
+			},
 		}, nil
 	case "status":
 		return &StatusCmd{
@@ -166,6 +218,9 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 			VBox:      b.VBox,
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
+			UI:        b.UI,
+			Tracer:    b.Tracer,
+			History:   b.History,
 		}, nil
 	case "suspend":
 		return &SuspendCmd{
@@ -184,6 +239,12 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 			VBox:      b.VBox,
 			Config:    b.Config,
 		}, nil
+	case "logs":
+		return &LogsCmd{
+			VBox:      b.VBox,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
 	case "trust":
 		return &TrustCmd{
 			VBox:      b.VBox,
@@ -194,8 +255,9 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 		return &UntrustCmd{
 			CertStore: &cert.CertStore{
 				SystemStore: &cert.ConcreteSystemStore{
-					FS:        b.FS,
-					CmdRunner: &runner.CmdRunner{},
+					FS:           b.FS,
+					CmdRunner:    &runner.CmdRunner{},
+					AddressTable: b.AddressTable,
 				},
 			},
 		}, nil
@@ -212,6 +274,76 @@ func (b *Builder) Cmd(subcommand string) (Cmd, error) {
 			VMBuilder: b.VMBuilder,
 			Config:    b.Config,
 		}, nil
+	case "disk":
+		return &DiskCmd{
+			VBox:      b.VBox,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "share":
+		return &ShareCmd{
+			VBox:      b.VBox,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "seed":
+		return &SeedCmd{
+			VBox:      b.VBox,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "password":
+		return &PasswordCmd{
+			VBox:      b.VBox,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+			UI:        b.UI,
+		}, nil
+	case "backup":
+		return &BackupCmd{
+			VBox:      b.VBox,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "restore":
+		return &RestoreCmd{
+			VBox:      b.VBox,
+			VMBuilder: b.VMBuilder,
+			Config:    b.Config,
+		}, nil
+	case "gc":
+		return &GCCmd{
+			VBox: b.VBox,
+			UI:   b.UI,
+		}, nil
+	case "network":
+		return &NetworkCmd{
+			VBox: b.VBox,
+			UI:   b.UI,
+		}, nil
+	case "config":
+		return &ConfigCmd{
+			VBox:   b.VBox,
+			UI:     b.UI,
+			Config: b.Config,
+		}, nil
+	case "doctor":
+		return &DoctorCmd{
+			System: b.System,
+			UI:     b.UI,
+			Config: b.Config,
+		}, nil
+	case "dns":
+		return &DNSCmd{
+			VBox:      b.VBox,
+			UI:        b.UI,
+			Config:    b.Config,
+			DNSServer: &dns.Server{},
+			SplitDNS: &dns.SplitDNS{
+				FS:        b.FS,
+				CmdRunner: &runner.CmdRunner{},
+			},
+		}, nil
 	default:
 		return nil, errors.New("")
 	}
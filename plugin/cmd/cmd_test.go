@@ -12,6 +12,8 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/fs"
 	"github.com/pivotal-cf/pcfdev-cli/pivnet"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/system"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	"github.com/pivotal-cf/pcfdev-cli/ui"
 	"github.com/pivotal-cf/pcfdev-cli/vbox"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
@@ -25,6 +27,7 @@ var _ = Describe("Builder", func() {
 				VBox:              &vbox.VBox{},
 				DownloaderFactory: &downloader.DownloaderFactory{},
 				FS:                &fs.FS{},
+				System:            &system.System{},
 				UI: terminal.NewUI(
 					os.Stdin,
 					os.Stdout,
@@ -35,6 +38,8 @@ var _ = Describe("Builder", func() {
 				Config:    &config.Config{},
 				EULAUI:    &ui.UI{},
 				Client:    &pivnet.Client{},
+				Tracer:    &timing.Tracer{},
+				History:   &timing.History{},
 			}
 		})
 
@@ -50,6 +55,7 @@ var _ = Describe("Builder", func() {
 					Expect(c.FS).To(BeIdenticalTo(builder.FS))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
 					Expect(c.UntrustCmd).NotTo(BeNil())
+					Expect(c.SplitDNS).NotTo(BeNil())
 				default:
 					Fail("wrong type")
 				}
@@ -103,6 +109,9 @@ var _ = Describe("Builder", func() {
 					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Tracer).To(BeIdenticalTo(builder.Tracer))
+					Expect(c.History).To(BeIdenticalTo(builder.History))
 				default:
 					Fail("wrong type")
 				}
@@ -119,6 +128,8 @@ var _ = Describe("Builder", func() {
 					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+					Expect(c.Tracer).To(BeIdenticalTo(builder.Tracer))
+					Expect(c.History).To(BeIdenticalTo(builder.History))
 					Expect(c.DownloadCmd).To(Equal(&cmd.DownloadCmd{
 						VBox:              builder.VBox,
 						UI:                builder.UI,
@@ -139,6 +150,11 @@ var _ = Describe("Builder", func() {
 						Config:     builder.Config,
 						AutoTarget: true,
 					}))
+					Expect(c.SeedCmd).To(Equal(&cmd.SeedCmd{
+						VBox:      builder.VBox,
+						VMBuilder: builder.VMBuilder,
+						Config:    builder.Config,
+					}))
 				default:
 					Fail("wrong type")
 				}
@@ -172,6 +188,9 @@ var _ = Describe("Builder", func() {
 					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
 					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
 					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Tracer).To(BeIdenticalTo(builder.Tracer))
+					Expect(c.History).To(BeIdenticalTo(builder.History))
 				default:
 					Fail("wrong type")
 				}
@@ -223,6 +242,22 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		Context("when it is passed logs", func() {
+			It("should return a logs command", func() {
+				logsCmd, err := builder.Cmd("logs")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := logsCmd.(type) {
+				case *cmd.LogsCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
 		Context("when it is passed debug", func() {
 			It("should return a debug command", func() {
 				debugCmd, err := builder.Cmd("debug")
@@ -302,6 +337,183 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		Context("when is is passed 'disk'", func() {
+			It("should return a disk command", func() {
+				diskCmd, err := builder.Cmd("disk")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := diskCmd.(type) {
+				case *cmd.DiskCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'share'", func() {
+			It("should return a share command", func() {
+				shareCmd, err := builder.Cmd("share")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := shareCmd.(type) {
+				case *cmd.ShareCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'seed'", func() {
+			It("should return a seed command", func() {
+				seedCmd, err := builder.Cmd("seed")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := seedCmd.(type) {
+				case *cmd.SeedCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'password'", func() {
+			It("should return a password command", func() {
+				passwordCmd, err := builder.Cmd("password")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := passwordCmd.(type) {
+				case *cmd.PasswordCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'backup'", func() {
+			It("should return a backup command", func() {
+				backupCmd, err := builder.Cmd("backup")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := backupCmd.(type) {
+				case *cmd.BackupCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'restore'", func() {
+			It("should return a restore command", func() {
+				restoreCmd, err := builder.Cmd("restore")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := restoreCmd.(type) {
+				case *cmd.RestoreCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.VMBuilder).To(BeIdenticalTo(builder.VMBuilder))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'gc'", func() {
+			It("should return a gc command", func() {
+				gcCmd, err := builder.Cmd("gc")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := gcCmd.(type) {
+				case *cmd.GCCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'config'", func() {
+			It("should return a config command", func() {
+				configCmd, err := builder.Cmd("config")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := configCmd.(type) {
+				case *cmd.ConfigCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'doctor'", func() {
+			It("should return a doctor command", func() {
+				doctorCmd, err := builder.Cmd("doctor")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := doctorCmd.(type) {
+				case *cmd.DoctorCmd:
+					Expect(c.System).To(BeIdenticalTo(builder.System))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'network'", func() {
+			It("should return a network command", func() {
+				networkCmd, err := builder.Cmd("network")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := networkCmd.(type) {
+				case *cmd.NetworkCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
+		Context("when is is passed 'dns'", func() {
+			It("should return a dns command", func() {
+				dnsCmd, err := builder.Cmd("dns")
+				Expect(err).NotTo(HaveOccurred())
+
+				switch c := dnsCmd.(type) {
+				case *cmd.DNSCmd:
+					Expect(c.VBox).To(BeIdenticalTo(builder.VBox))
+					Expect(c.UI).To(BeIdenticalTo(builder.UI))
+					Expect(c.Config).To(BeIdenticalTo(builder.Config))
+					Expect(c.DNSServer).NotTo(BeNil())
+					Expect(c.SplitDNS).NotTo(BeNil())
+				default:
+					Fail("wrong type")
+				}
+			})
+		})
+
 		Context("when it is passed an unknown subcommand", func() {
 			It("should return an error", func() {
 				_, err := builder.Cmd("some-bad-subcommand")
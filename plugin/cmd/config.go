@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+const CONFIG_ARGS = 1
+
+type ConfigCmd struct {
+	VBox   VBox
+	UI     UI
+	Config *config.Config
+}
+
+func (c *ConfigCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, CONFIG_ARGS); err != nil {
+		return err
+	}
+
+	if subcommand := flagContext.Args()[0]; subcommand != "show" {
+		return fmt.Errorf("unknown config subcommand '%s'", subcommand)
+	}
+	return nil
+}
+
+func (c *ConfigCmd) Run() error {
+	name, err := c.VBox.GetVMName()
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return errors.New("PCF Dev VM has not been created")
+	}
+	if name != c.Config.DefaultVMName && name != "pcfdev-custom" {
+		return &OldVMError{}
+	}
+
+	vmConfigFile, err := c.VBox.VMConfigFile(name)
+	if err != nil {
+		return err
+	}
+
+	contents, err := vmConfigFile.Marshal()
+	if err != nil {
+		return err
+	}
+
+	c.UI.Say(string(contents))
+	return nil
+}
@@ -0,0 +1,129 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("ConfigCmd", func() {
+	var (
+		configCmd *cmd.ConfigCmd
+		mockCtrl  *gomock.Controller
+		mockVBox  *mocks.MockVBox
+		mockUI    *mocks.MockUI
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		configCmd = &cmd.ConfigCmd{
+			VBox: mockVBox,
+			UI:   mockUI,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when show is passed", func() {
+			It("should succeed", func() {
+				Expect(configCmd.Parse([]string{"show"})).To(Succeed())
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(configCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+				Expect(configCmd.Parse([]string{"show", "some-bad-arg"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+		Context("when an unknown config subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(configCmd.Parse([]string{"some-bad-subcommand"})).To(MatchError("unknown config subcommand 'some-bad-subcommand'"))
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(configCmd.Parse([]string{"show", "--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(configCmd.Parse([]string{"show"})).To(Succeed())
+		})
+
+		It("should print the stored vm_config document as JSON", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVBox.EXPECT().VMConfigFile("some-default-vm-name").Return(&config.VMConfigFile{
+					Version: 3,
+					IP:      "192.168.11.11",
+					Domain:  "local.pcfdev.io",
+					CPUs:    2,
+					Memory:  uint64(4096),
+					OVAPath: "some-ova-path",
+					Network: "hostonly",
+				}, nil),
+				mockUI.EXPECT().Say(`{
+  "version": 3,
+  "ip": "192.168.11.11",
+  "domain": "local.pcfdev.io",
+  "cpus": 2,
+  "memory": 4096,
+  "ova_path": "some-ova-path",
+  "network": "hostonly"
+}`),
+			)
+
+			Expect(configCmd.Run()).To(Succeed())
+		})
+
+		Context("when the VM has not been created", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().GetVMName().Return("", nil)
+
+				Expect(configCmd.Run()).To(MatchError("PCF Dev VM has not been created"))
+			})
+		})
+
+		Context("when the VM was created by an older version of the plugin", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(configCmd.Run()).To(MatchError(&cmd.OldVMError{}))
+			})
+		})
+
+		Context("when getting the VM name fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(configCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reading the vm_config document fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVBox.EXPECT().VMConfigFile("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(configCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -1,8 +1,13 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/cloudfoundry/cli/cf/flags"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/debug"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 )
 
@@ -10,12 +15,66 @@ type DebugCmd struct {
 	VBox      VBox
 	VMBuilder VMBuilder
 	Config    *config.Config
+	opts      *vm.DebugOpts
 }
 
 const DEBUG_ARGS = 0
 
 func (d *DebugCmd) Parse(args []string) error {
-	return parse(flags.New(), args, DEBUG_ARGS)
+	flagContext := flags.New()
+	flagContext.NewStringFlag("output", "", "<path to debug tarball>")
+	flagContext.NewIntFlag("timeout", "", "<seconds to wait for each log>")
+	flagContext.NewStringFlag("components", "", "<components to collect logs from>")
+	flagContext.NewStringFlag("since", "", "<only logs written within this duration>")
+	flagContext.NewStringFlag("max-size", "", "<maximum size of logs per component>")
+	flagContext.NewBoolFlag("pseudonymize", "", "<replace sensitive values with stable pseudonyms>")
+	if err := parse(flagContext, args, DEBUG_ARGS); err != nil {
+		return err
+	}
+
+	d.opts = &vm.DebugOpts{
+		Output:       debug.DefaultOutput,
+		Timeout:      debug.DefaultTimeout,
+		Pseudonymize: flagContext.Bool("pseudonymize"),
+	}
+	if flagContext.IsSet("output") {
+		output, err := tarballPath(flagContext.String("output"))
+		if err != nil {
+			return err
+		}
+		d.opts.Output = output
+	}
+	if flagContext.IsSet("timeout") {
+		if flagContext.Int("timeout") < 1 {
+			return errors.New("the --timeout flag must be at least 1 second")
+		}
+		d.opts.Timeout = time.Duration(flagContext.Int("timeout")) * time.Second
+	}
+	if flagContext.IsSet("components") {
+		components, err := debug.ParseComponents(flagContext.String("components"))
+		if err != nil {
+			return err
+		}
+		d.opts.Components = components
+	}
+	if flagContext.IsSet("since") {
+		since, err := time.ParseDuration(flagContext.String("since"))
+		if err != nil || since <= 0 {
+			return fmt.Errorf("invalid --since duration '%s', expected a value like 30m or 2h", flagContext.String("since"))
+		}
+		d.opts.Since = since
+	}
+	if flagContext.IsSet("max-size") {
+		maxSize, err := parseSize("--max-size", flagContext.String("max-size"))
+		if err != nil {
+			return err
+		}
+		d.opts.MaxSize = maxSize * 1024 * 1024
+	}
+	if (flagContext.IsSet("since") || flagContext.IsSet("max-size")) && len(d.opts.Components) == 0 {
+		return errors.New("the --since and --max-size flags can only be used with --components")
+	}
+	return nil
 }
 
 func (d *DebugCmd) Run() error {
@@ -23,7 +82,7 @@ func (d *DebugCmd) Run() error {
 	if err != nil {
 		return err
 	}
-	return vm.GetDebugLogs()
+	return vm.GetDebugLogs(d.opts)
 }
 
 func (d *DebugCmd) getVM() (vm vm.VM, err error) {
@@ -2,6 +2,7 @@ package cmd_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -9,6 +10,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
 	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
 )
 
@@ -55,15 +57,102 @@ var _ = Describe("DebugCmd", func() {
 				Expect(debugCmd.Parse([]string{"--some-bad-flag"})).NotTo(Succeed())
 			})
 		})
+		Context("when an unknown component is passed", func() {
+			It("should fail", func() {
+				Expect(debugCmd.Parse([]string{"--components", "cc,some-component"})).To(MatchError(ContainSubstring("unknown component 'some-component'")))
+			})
+		})
+		Context("when the since duration is invalid", func() {
+			It("should fail", func() {
+				Expect(debugCmd.Parse([]string{"--components", "cc", "--since", "1d"})).To(MatchError("invalid --since duration '1d', expected a value like 30m or 2h"))
+			})
+		})
+		Context("when the max size is invalid", func() {
+			It("should fail", func() {
+				Expect(debugCmd.Parse([]string{"--components", "cc", "--max-size", "some-size"})).To(MatchError("--max-size must be a number of megabytes or end in M or G"))
+			})
+		})
+		Context("when --since or --max-size are passed without --components", func() {
+			It("should fail", func() {
+				Expect(debugCmd.Parse([]string{"--since", "1h"})).To(MatchError("the --since and --max-size flags can only be used with --components"))
+				Expect(debugCmd.Parse([]string{"--max-size", "50MB"})).To(MatchError("the --since and --max-size flags can only be used with --components"))
+			})
+		})
+		Context("when the output has another extension", func() {
+			It("should fail", func() {
+				Expect(debugCmd.Parse([]string{"--output", "some-bundle.tar.gz"})).To(MatchError("some-bundle.tar.gz must end in .tgz"))
+			})
+		})
+		Context("when the timeout is less than one second", func() {
+			It("should fail", func() {
+				Expect(debugCmd.Parse([]string{"--timeout", "0"})).To(MatchError("the --timeout flag must be at least 1 second"))
+			})
+		})
 	})
 
 	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(debugCmd.Parse([]string{})).To(Succeed())
+		})
+
+		Context("when the output and timeout flags are passed", func() {
+			It("should pass them to the VM", func() {
+				Expect(debugCmd.Parse([]string{"--output", "some-dir/some-bundle", "--timeout", "5"})).To(Succeed())
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().GetDebugLogs(&vm.DebugOpts{
+						Output:  "some-dir/some-bundle.tgz",
+						Timeout: 5 * time.Second,
+					}),
+				)
+
+				Expect(debugCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when --pseudonymize is passed", func() {
+			It("should ask the VM to pseudonymize the logs", func() {
+				Expect(debugCmd.Parse([]string{"--pseudonymize"})).To(Succeed())
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().GetDebugLogs(&vm.DebugOpts{
+						Output:       "pcfdev-debug.tgz",
+						Timeout:      20 * time.Second,
+						Pseudonymize: true,
+					}),
+				)
+
+				Expect(debugCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when components are passed", func() {
+			It("should pass them with the time and size limits to the VM", func() {
+				Expect(debugCmd.Parse([]string{"--components", "cc,router,uaa", "--since", "1h", "--max-size", "50MB"})).To(Succeed())
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().GetDebugLogs(&vm.DebugOpts{
+						Output:     "pcfdev-debug.tgz",
+						Timeout:    20 * time.Second,
+						Components: []string{"cc", "router", "uaa"},
+						Since:      time.Hour,
+						MaxSize:    50 * 1024 * 1024,
+					}),
+				)
+
+				Expect(debugCmd.Run()).To(Succeed())
+			})
+		})
+
 		Context("when the default vm is present", func() {
 			It("should succeed", func() {
 				gomock.InOrder(
 					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
-					mockVM.EXPECT().GetDebugLogs(),
+					mockVM.EXPECT().GetDebugLogs(&vm.DebugOpts{Output: "pcfdev-debug.tgz", Timeout: 20 * time.Second}),
 				)
 
 				Expect(debugCmd.Run()).To(Succeed())
@@ -75,7 +164,7 @@ var _ = Describe("DebugCmd", func() {
 				gomock.InOrder(
 					mockVBox.EXPECT().GetVMName().Return("pcfdev-custom", nil),
 					mockVMBuilder.EXPECT().VM("pcfdev-custom").Return(mockVM, nil),
-					mockVM.EXPECT().GetDebugLogs(),
+					mockVM.EXPECT().GetDebugLogs(&vm.DebugOpts{Output: "pcfdev-debug.tgz", Timeout: 20 * time.Second}),
 				)
 
 				Expect(debugCmd.Run()).To(Succeed())
@@ -87,7 +176,7 @@ var _ = Describe("DebugCmd", func() {
 				gomock.InOrder(
 					mockVBox.EXPECT().GetVMName().Return("", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
-					mockVM.EXPECT().GetDebugLogs(),
+					mockVM.EXPECT().GetDebugLogs(&vm.DebugOpts{Output: "pcfdev-debug.tgz", Timeout: 20 * time.Second}),
 				)
 
 				Expect(debugCmd.Run()).To(Succeed())
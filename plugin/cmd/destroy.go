@@ -16,6 +16,7 @@ type DestroyCmd struct {
 	UI         UI
 	FS         FS
 	UntrustCmd Cmd
+	SplitDNS   SplitDNS
 	Config     *config.Config
 }
 
@@ -30,6 +31,10 @@ func (d *DestroyCmd) Run() error {
 		errs = append(errs, fmt.Sprintf("error removing certificates from trust store: %s", err))
 	}
 
+	if err := d.SplitDNS.Unconfigure(); err != nil {
+		errs = append(errs, fmt.Sprintf("error removing split DNS configuration: %s", err))
+	}
+
 	if err := d.VBox.DestroyPCFDevVMs(); err != nil {
 		errs = append(errs, fmt.Sprintf("error destroying PCF Dev VM: %s", err))
 	} else {
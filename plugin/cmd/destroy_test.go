@@ -18,6 +18,7 @@ var _ = Describe("DestroyCmd", func() {
 		mockVBox       *mocks.MockVBox
 		mockFS         *mocks.MockFS
 		mockUntrustCmd *mocks.MockCmd
+		mockSplitDNS   *mocks.MockSplitDNS
 		destroyCmd     *cmd.DestroyCmd
 	)
 
@@ -27,11 +28,13 @@ var _ = Describe("DestroyCmd", func() {
 		mockVBox = mocks.NewMockVBox(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
 		mockUntrustCmd = mocks.NewMockCmd(mockCtrl)
+		mockSplitDNS = mocks.NewMockSplitDNS(mockCtrl)
 		destroyCmd = &cmd.DestroyCmd{
 			UI:         mockUI,
 			VBox:       mockVBox,
 			FS:         mockFS,
 			UntrustCmd: mockUntrustCmd,
+			SplitDNS:   mockSplitDNS,
 			Config: &config.Config{
 				VMDir: "some-vm-dir",
 			},
@@ -64,6 +67,7 @@ var _ = Describe("DestroyCmd", func() {
 		It("should destroy all PCF Dev VMs created by the CLI and the VM dir", func() {
 			gomock.InOrder(
 				mockUntrustCmd.EXPECT().Run(),
+				mockSplitDNS.EXPECT().Unconfigure(),
 				mockVBox.EXPECT().DestroyPCFDevVMs(),
 				mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
 				mockFS.EXPECT().Remove("some-vm-dir"),
@@ -76,6 +80,7 @@ var _ = Describe("DestroyCmd", func() {
 			It("should remove the VM dir and return an errpr", func() {
 				gomock.InOrder(
 					mockUntrustCmd.EXPECT().Run(),
+					mockSplitDNS.EXPECT().Unconfigure(),
 					mockVBox.EXPECT().DestroyPCFDevVMs().Return(errors.New("some-error")),
 					mockFS.EXPECT().Remove("some-vm-dir"),
 				)
@@ -88,6 +93,7 @@ var _ = Describe("DestroyCmd", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockUntrustCmd.EXPECT().Run(),
+					mockSplitDNS.EXPECT().Unconfigure(),
 					mockVBox.EXPECT().DestroyPCFDevVMs(),
 					mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
 					mockFS.EXPECT().Remove("some-vm-dir").Return(errors.New("some-error")),
@@ -101,6 +107,7 @@ var _ = Describe("DestroyCmd", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockUntrustCmd.EXPECT().Run(),
+					mockSplitDNS.EXPECT().Unconfigure(),
 					mockVBox.EXPECT().DestroyPCFDevVMs().Return(errors.New("some-error")),
 					mockFS.EXPECT().Remove("some-vm-dir").Return(errors.New("some-error")),
 				)
@@ -113,6 +120,7 @@ var _ = Describe("DestroyCmd", func() {
 			It("should remove the VM dir and keep going and return an error", func() {
 				gomock.InOrder(
 					mockUntrustCmd.EXPECT().Run().Return(errors.New("some-error")),
+					mockSplitDNS.EXPECT().Unconfigure(),
 					mockVBox.EXPECT().DestroyPCFDevVMs(),
 					mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
 					mockFS.EXPECT().Remove("some-vm-dir"),
@@ -121,5 +129,19 @@ var _ = Describe("DestroyCmd", func() {
 				Expect(destroyCmd.Run()).To(MatchError("error removing certificates from trust store: some-error"))
 			})
 		})
+
+		Context("when there is an error removing the split DNS configuration", func() {
+			It("should keep going and return an error", func() {
+				gomock.InOrder(
+					mockUntrustCmd.EXPECT().Run(),
+					mockSplitDNS.EXPECT().Unconfigure().Return(errors.New("some-error")),
+					mockVBox.EXPECT().DestroyPCFDevVMs(),
+					mockUI.EXPECT().Say("PCF Dev VM has been destroyed."),
+					mockFS.EXPECT().Remove("some-vm-dir"),
+				)
+
+				Expect(destroyCmd.Run()).To(MatchError("error removing split DNS configuration: some-error"))
+			})
+		})
 	})
 })
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+const DISK_ARGS = 2
+
+type DiskCmd struct {
+	VMBuilder VMBuilder
+	VBox      VBox
+	Config    *config.Config
+	size      uint64
+}
+
+func (d *DiskCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, DISK_ARGS); err != nil {
+		return err
+	}
+
+	if flagContext.Args()[0] != "resize" {
+		return fmt.Errorf("unknown disk subcommand '%s'", flagContext.Args()[0])
+	}
+
+	size, err := parseSize("disk size", flagContext.Args()[1])
+	if err != nil {
+		return err
+	}
+	d.size = size
+	return nil
+}
+
+func (d *DiskCmd) Run() error {
+	vm, err := d.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.ResizeDisk(d.size)
+}
+
+func (d *DiskCmd) getVM() (vm vm.VM, err error) {
+	name, err := d.VBox.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = d.Config.DefaultVMName
+	}
+	if name != d.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return d.VMBuilder.VM(name)
+}
+
+func parseSize(name string, size string) (uint64, error) {
+	regex := regexp.MustCompile(`^(\d+)([MG]B?)?$`)
+	matches := regex.FindStringSubmatch(strings.ToUpper(size))
+	if len(matches) < 3 {
+		return 0, fmt.Errorf("%s must be a number of megabytes or end in M or G", name)
+	}
+
+	value, err := strconv.ParseUint(matches[1], 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if strings.HasPrefix(matches[2], "G") {
+		value *= 1024
+	}
+
+	if value == 0 {
+		return 0, fmt.Errorf("%s must be greater than zero", name)
+	}
+	return value, nil
+}
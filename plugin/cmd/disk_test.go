@@ -0,0 +1,130 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("DiskCmd", func() {
+	var (
+		diskCmd       *cmd.DiskCmd
+		mockCtrl      *gomock.Controller
+		mockVBox      *mocks.MockVBox
+		mockVMBuilder *mocks.MockVMBuilder
+		mockVM        *vmMocks.MockVM
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		diskCmd = &cmd.DiskCmd{
+			VBox:      mockVBox,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when resize is passed a size", func() {
+			It("should succeed", func() {
+				Expect(diskCmd.Parse([]string{"resize", "81920"})).To(Succeed())
+				Expect(diskCmd.Parse([]string{"resize", "81920M"})).To(Succeed())
+				Expect(diskCmd.Parse([]string{"resize", "80G"})).To(Succeed())
+				Expect(diskCmd.Parse([]string{"resize", "80gb"})).To(Succeed())
+			})
+		})
+		Context("when the size is not valid", func() {
+			It("should fail", func() {
+				Expect(diskCmd.Parse([]string{"resize", "80T"})).NotTo(Succeed())
+				Expect(diskCmd.Parse([]string{"resize", "-10G"})).NotTo(Succeed())
+				Expect(diskCmd.Parse([]string{"resize", "0"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown disk subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(diskCmd.Parse([]string{"shrink", "80G"})).To(MatchError("unknown disk subcommand 'shrink'"))
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(diskCmd.Parse([]string{"resize"})).NotTo(Succeed())
+				Expect(diskCmd.Parse([]string{"resize", "80G", "some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(diskCmd.Parse([]string{"resize", "80G", "--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should resize the disk of the VM to the size in megabytes", func() {
+			Expect(diskCmd.Parse([]string{"resize", "80G"})).To(Succeed())
+
+			gomock.InOrder(
+				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().ResizeDisk(uint64(81920)),
+			)
+
+			Expect(diskCmd.Run()).To(Succeed())
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(diskCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(diskCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an error building the VM", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(diskCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an error resizing the disk", func() {
+			It("should return the error", func() {
+				Expect(diskCmd.Parse([]string{"resize", "81920"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().ResizeDisk(uint64(81920)).Return(errors.New("some-error")),
+				)
+
+				Expect(diskCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
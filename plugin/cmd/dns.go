@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/dns"
+)
+
+//go:generate mockgen -package mocks -destination mocks/dns_server.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd DNSServer
+type DNSServer interface {
+	ListenAndServe(address string, domain string, ip string, upstream string) error
+}
+
+//go:generate mockgen -package mocks -destination mocks/split_dns.go github.com/pivotal-cf/pcfdev-cli/plugin/cmd SplitDNS
+type SplitDNS interface {
+	Configure(domain string, address string, ip string) error
+	Unconfigure() error
+}
+
+const DNS_ARGS = 1
+
+type DNSCmd struct {
+	VBox       VBox
+	UI         UI
+	DNSServer  DNSServer
+	SplitDNS   SplitDNS
+	Config     *config.Config
+	subcommand string
+	address    string
+	upstream   string
+}
+
+func (d *DNSCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	flagContext.NewStringFlag("address", "", "<address to listen on>")
+	flagContext.NewStringFlag("upstream", "", "<nameserver to forward other queries to>")
+	if err := parse(flagContext, args, DNS_ARGS); err != nil {
+		return err
+	}
+
+	d.subcommand = flagContext.Args()[0]
+	switch d.subcommand {
+	case "start":
+	case "configure":
+		if flagContext.IsSet("upstream") {
+			return errors.New("the --upstream flag can only be used with 'dns start'")
+		}
+	case "unconfigure":
+		if flagContext.IsSet("address") {
+			return errors.New("the --address flag cannot be used with 'dns unconfigure'")
+		}
+		if flagContext.IsSet("upstream") {
+			return errors.New("the --upstream flag can only be used with 'dns start'")
+		}
+	default:
+		return fmt.Errorf("unknown dns subcommand '%s'", d.subcommand)
+	}
+
+	d.address = flagContext.String("address")
+	if d.address == "" {
+		d.address = dns.DefaultAddress
+	}
+
+	d.upstream = flagContext.String("upstream")
+	if _, _, err := net.SplitHostPort(d.upstream); d.upstream != "" && err != nil {
+		d.upstream = net.JoinHostPort(d.upstream, "53")
+	}
+	return nil
+}
+
+func (d *DNSCmd) Run() error {
+	if d.subcommand == "unconfigure" {
+		if err := d.SplitDNS.Unconfigure(); err != nil {
+			return err
+		}
+		d.UI.Say("Removed split DNS configuration for PCF Dev.")
+		return nil
+	}
+
+	vmConfig, err := d.getVMConfig()
+	if err != nil {
+		return err
+	}
+
+	if d.subcommand == "configure" {
+		if err := d.SplitDNS.Configure(vmConfig.Domain, d.address, vmConfig.IP); err != nil {
+			return err
+		}
+		start := "cf dev dns start"
+		if d.address != dns.DefaultAddress {
+			start += " --address " + d.address
+		}
+		d.UI.Say("Queries for *.%s will be sent to %s. Run '%s' to answer them.", vmConfig.Domain, d.address, start)
+		return nil
+	}
+
+	d.UI.Say("Resolving *.%s to %s on %s. Press Ctrl-C to stop.", vmConfig.Domain, vmConfig.IP, d.address)
+	return d.DNSServer.ListenAndServe(d.address, vmConfig.Domain, vmConfig.IP, d.upstream)
+}
+
+func (d *DNSCmd) getVMConfig() (*config.VMConfig, error) {
+	name, err := d.VBox.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		return nil, errors.New("PCF Dev VM has not been created")
+	}
+	if name != d.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return d.VBox.VMConfig(name)
+}
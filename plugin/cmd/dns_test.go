@@ -0,0 +1,207 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("DNSCmd", func() {
+	var (
+		dnsCmd        *cmd.DNSCmd
+		mockCtrl      *gomock.Controller
+		mockVBox      *mocks.MockVBox
+		mockUI        *mocks.MockUI
+		mockDNSServer *mocks.MockDNSServer
+		mockSplitDNS  *mocks.MockSplitDNS
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		mockDNSServer = mocks.NewMockDNSServer(mockCtrl)
+		mockSplitDNS = mocks.NewMockSplitDNS(mockCtrl)
+		dnsCmd = &cmd.DNSCmd{
+			VBox:      mockVBox,
+			UI:        mockUI,
+			DNSServer: mockDNSServer,
+			SplitDNS:  mockSplitDNS,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when a known subcommand is passed", func() {
+			It("should succeed", func() {
+				Expect(dnsCmd.Parse([]string{"start"})).To(Succeed())
+				Expect(dnsCmd.Parse([]string{"start", "--address", "127.0.0.1:53"})).To(Succeed())
+				Expect(dnsCmd.Parse([]string{"start", "--upstream", "10.0.0.2"})).To(Succeed())
+				Expect(dnsCmd.Parse([]string{"configure"})).To(Succeed())
+				Expect(dnsCmd.Parse([]string{"unconfigure"})).To(Succeed())
+			})
+		})
+		Context("when --address is passed to unconfigure", func() {
+			It("should fail", func() {
+				Expect(dnsCmd.Parse([]string{"unconfigure", "--address", "127.0.0.1:53"})).To(MatchError("the --address flag cannot be used with 'dns unconfigure'"))
+			})
+		})
+		Context("when --upstream is passed to a subcommand other than start", func() {
+			It("should fail", func() {
+				Expect(dnsCmd.Parse([]string{"configure", "--upstream", "10.0.0.2"})).To(MatchError("the --upstream flag can only be used with 'dns start'"))
+				Expect(dnsCmd.Parse([]string{"unconfigure", "--upstream", "10.0.0.2"})).To(MatchError("the --upstream flag can only be used with 'dns start'"))
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(dnsCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+				Expect(dnsCmd.Parse([]string{"start", "some-bad-arg"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+		Context("when an unknown dns subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(dnsCmd.Parse([]string{"some-bad-subcommand"})).To(MatchError("unknown dns subcommand 'some-bad-subcommand'"))
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(dnsCmd.Parse([]string{"start", "--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("when start is passed", func() {
+			It("should serve DNS for the PCF Dev domain on the default address", func() {
+				Expect(dnsCmd.Parse([]string{"start"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVBox.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{Domain: "some-domain", IP: "some-ip"}, nil),
+					mockUI.EXPECT().Say("Resolving *.%s to %s on %s. Press Ctrl-C to stop.", "some-domain", "some-ip", "127.0.0.1:5354"),
+					mockDNSServer.EXPECT().ListenAndServe("127.0.0.1:5354", "some-domain", "some-ip", ""),
+				)
+
+				Expect(dnsCmd.Run()).To(Succeed())
+			})
+
+			Context("when an address is passed", func() {
+				It("should serve DNS on that address", func() {
+					Expect(dnsCmd.Parse([]string{"start", "--address", "127.0.0.1:53"})).To(Succeed())
+
+					gomock.InOrder(
+						mockVBox.EXPECT().GetVMName().Return("pcfdev-custom", nil),
+						mockVBox.EXPECT().VMConfig("pcfdev-custom").Return(&config.VMConfig{Domain: "some-domain", IP: "some-ip"}, nil),
+						mockUI.EXPECT().Say("Resolving *.%s to %s on %s. Press Ctrl-C to stop.", "some-domain", "some-ip", "127.0.0.1:53"),
+						mockDNSServer.EXPECT().ListenAndServe("127.0.0.1:53", "some-domain", "some-ip", "").Return(errors.New("some-error")),
+					)
+
+					Expect(dnsCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when an upstream nameserver is passed", func() {
+			It("should forward other queries to it", func() {
+				Expect(dnsCmd.Parse([]string{"start", "--upstream", "10.0.0.2"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVBox.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{Domain: "some-domain", IP: "some-ip"}, nil),
+					mockUI.EXPECT().Say("Resolving *.%s to %s on %s. Press Ctrl-C to stop.", "some-domain", "some-ip", "127.0.0.1:5354"),
+					mockDNSServer.EXPECT().ListenAndServe("127.0.0.1:5354", "some-domain", "some-ip", "10.0.0.2:53"),
+				)
+
+				Expect(dnsCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when configure is passed", func() {
+			It("should configure split DNS for the PCF Dev domain", func() {
+				Expect(dnsCmd.Parse([]string{"configure"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVBox.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{Domain: "some-domain", IP: "some-ip"}, nil),
+					mockSplitDNS.EXPECT().Configure("some-domain", "127.0.0.1:5354", "some-ip"),
+					mockUI.EXPECT().Say("Queries for *.%s will be sent to %s. Run '%s' to answer them.", "some-domain", "127.0.0.1:5354", "cf dev dns start"),
+				)
+
+				Expect(dnsCmd.Run()).To(Succeed())
+			})
+
+			Context("when an address is passed", func() {
+				It("should tell the user to answer queries on that address", func() {
+					Expect(dnsCmd.Parse([]string{"configure", "--address", "192.168.11.1:5354"})).To(Succeed())
+
+					gomock.InOrder(
+						mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockVBox.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{Domain: "some-domain", IP: "some-ip"}, nil),
+						mockSplitDNS.EXPECT().Configure("some-domain", "192.168.11.1:5354", "some-ip"),
+						mockUI.EXPECT().Say("Queries for *.%s will be sent to %s. Run '%s' to answer them.", "some-domain", "192.168.11.1:5354", "cf dev dns start --address 192.168.11.1:5354"),
+					)
+
+					Expect(dnsCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when configuring split DNS fails", func() {
+				It("should return an error", func() {
+					Expect(dnsCmd.Parse([]string{"configure"})).To(Succeed())
+
+					gomock.InOrder(
+						mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+						mockVBox.EXPECT().VMConfig("some-default-vm-name").Return(&config.VMConfig{Domain: "some-domain", IP: "some-ip"}, nil),
+						mockSplitDNS.EXPECT().Configure("some-domain", "127.0.0.1:5354", "some-ip").Return(errors.New("some-error")),
+					)
+
+					Expect(dnsCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when unconfigure is passed", func() {
+			It("should remove the split DNS configuration", func() {
+				Expect(dnsCmd.Parse([]string{"unconfigure"})).To(Succeed())
+
+				gomock.InOrder(
+					mockSplitDNS.EXPECT().Unconfigure(),
+					mockUI.EXPECT().Say("Removed split DNS configuration for PCF Dev."),
+				)
+
+				Expect(dnsCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when no VM has been created", func() {
+			It("should return an error", func() {
+				Expect(dnsCmd.Parse([]string{"start"})).To(Succeed())
+
+				mockVBox.EXPECT().GetVMName().Return("", nil)
+
+				Expect(dnsCmd.Run()).To(MatchError("PCF Dev VM has not been created"))
+			})
+		})
+
+		Context("when there is an old VM present", func() {
+			It("should return an error", func() {
+				Expect(dnsCmd.Parse([]string{"start"})).To(Succeed())
+
+				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(dnsCmd.Run()).To(MatchError(&cmd.OldVMError{}))
+			})
+		})
+	})
+})
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+const DOCTOR_ARGS = 0
+
+type DoctorCmd struct {
+	System System
+	UI     UI
+	Config *config.Config
+}
+
+func (d *DoctorCmd) Parse(args []string) error {
+	return parse(flags.New(), args, DOCTOR_ARGS)
+}
+
+func (d *DoctorCmd) Run() error {
+	resources, err := d.System.Resources()
+	if err != nil {
+		return err
+	}
+
+	d.UI.Say("CPUs: %d (source: %s)", resources.CPUs, resources.CPUsSource)
+	d.UI.Say("Total memory: %d MB (source: %s)", resources.TotalMemory, resources.TotalMemorySource)
+	d.UI.Say("Free memory: %d MB (source: %s)", resources.FreeMemory, resources.FreeMemorySource)
+
+	if resources.TotalMemory < d.Config.MinMemory {
+		d.UI.Say("Warning: PCF Dev requires at least %d MB of memory, but only %d MB is available to this process.", d.Config.MinMemory, resources.TotalMemory)
+	} else if resources.FreeMemory < d.Config.MinMemory {
+		d.UI.Say("Warning: less than %d MB of memory is free. Close other applications before starting PCF Dev.", d.Config.MinMemory)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/system"
+)
+
+var _ = Describe("DoctorCmd", func() {
+	var (
+		doctorCmd  *cmd.DoctorCmd
+		mockCtrl   *gomock.Controller
+		mockSystem *mocks.MockSystem
+		mockUI     *mocks.MockUI
+		resources  *system.Resources
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockSystem = mocks.NewMockSystem(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		doctorCmd = &cmd.DoctorCmd{
+			System: mockSystem,
+			UI:     mockUI,
+			Config: &config.Config{
+				MinMemory: 3072,
+			},
+		}
+		resources = &system.Resources{
+			CPUs:              2,
+			CPUsSource:        "cgroup v2 cpu.max",
+			TotalMemory:       8192,
+			TotalMemorySource: "host",
+			FreeMemory:        4096,
+			FreeMemorySource:  "host",
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when no arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(doctorCmd.Parse([]string{})).To(Succeed())
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(doctorCmd.Parse([]string{"some-bad-arg"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(doctorCmd.Parse([]string{"--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should print the detected resources and their sources", func() {
+			gomock.InOrder(
+				mockSystem.EXPECT().Resources().Return(resources, nil),
+				mockUI.EXPECT().Say("CPUs: %d (source: %s)", 2, "cgroup v2 cpu.max"),
+				mockUI.EXPECT().Say("Total memory: %d MB (source: %s)", uint64(8192), "host"),
+				mockUI.EXPECT().Say("Free memory: %d MB (source: %s)", uint64(4096), "host"),
+			)
+
+			Expect(doctorCmd.Run()).To(Succeed())
+		})
+
+		Context("when the memory available is below the minimum", func() {
+			It("should print a warning", func() {
+				resources.TotalMemory = 2048
+				resources.TotalMemorySource = "cgroup v2 memory.max"
+				resources.FreeMemory = 1024
+				resources.FreeMemorySource = "cgroup v2 memory.max"
+
+				gomock.InOrder(
+					mockSystem.EXPECT().Resources().Return(resources, nil),
+					mockUI.EXPECT().Say("CPUs: %d (source: %s)", 2, "cgroup v2 cpu.max"),
+					mockUI.EXPECT().Say("Total memory: %d MB (source: %s)", uint64(2048), "cgroup v2 memory.max"),
+					mockUI.EXPECT().Say("Free memory: %d MB (source: %s)", uint64(1024), "cgroup v2 memory.max"),
+					mockUI.EXPECT().Say("Warning: PCF Dev requires at least %d MB of memory, but only %d MB is available to this process.", uint64(3072), uint64(2048)),
+				)
+
+				Expect(doctorCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when the free memory is below the minimum", func() {
+			It("should print a warning", func() {
+				resources.FreeMemory = 1024
+
+				gomock.InOrder(
+					mockSystem.EXPECT().Resources().Return(resources, nil),
+					mockUI.EXPECT().Say("CPUs: %d (source: %s)", 2, "cgroup v2 cpu.max"),
+					mockUI.EXPECT().Say("Total memory: %d MB (source: %s)", uint64(8192), "host"),
+					mockUI.EXPECT().Say("Free memory: %d MB (source: %s)", uint64(1024), "host"),
+					mockUI.EXPECT().Say("Warning: less than %d MB of memory is free. Close other applications before starting PCF Dev.", uint64(3072)),
+				)
+
+				Expect(doctorCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when detecting the resources fails", func() {
+			It("should return the error", func() {
+				mockSystem.EXPECT().Resources().Return(nil, errors.New("some-error"))
+
+				Expect(doctorCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
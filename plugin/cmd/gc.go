@@ -0,0 +1,61 @@
+package cmd
+
+import "github.com/cloudfoundry/cli/cf/flags"
+
+const GC_ARGS = 0
+
+type GCCmd struct {
+	VBox   VBox
+	UI     UI
+	dryRun bool
+}
+
+func (g *GCCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	flagContext.NewBoolFlag("dry-run", "", "<list without removing>")
+	if err := parse(flagContext, args, GC_ARGS); err != nil {
+		return err
+	}
+
+	g.dryRun = flagContext.Bool("dry-run")
+	return nil
+}
+
+func (g *GCCmd) Run() error {
+	garbage, err := g.VBox.FindGarbage()
+	if err != nil {
+		return err
+	}
+
+	if garbage.Empty() {
+		g.UI.Say("Nothing to clean up.")
+		return nil
+	}
+
+	g.UI.Say("Found the following leftover PCF Dev resources:")
+	for _, iface := range garbage.HostOnlyInterfaces {
+		g.UI.Say("  host-only interface %s", iface)
+	}
+	for _, disk := range garbage.Disks {
+		g.UI.Say("  disk %s", disk)
+	}
+	for _, file := range garbage.Files {
+		g.UI.Say("  file %s", file)
+	}
+
+	if g.dryRun {
+		return nil
+	}
+
+	if !g.UI.Confirm("Remove them? (y/N): ") {
+		g.UI.Say("Nothing was removed.")
+		return nil
+	}
+
+	if err := g.VBox.CollectGarbage(garbage); err != nil {
+		return err
+	}
+
+	g.UI.Say("Leftover PCF Dev resources have been removed.")
+	return nil
+}
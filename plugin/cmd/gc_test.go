@@ -0,0 +1,144 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/vbox"
+)
+
+var _ = Describe("GCCmd", func() {
+	var (
+		gcCmd    *cmd.GCCmd
+		mockCtrl *gomock.Controller
+		mockVBox *mocks.MockVBox
+		mockUI   *mocks.MockUI
+		garbage  *vbox.Garbage
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		gcCmd = &cmd.GCCmd{
+			VBox: mockVBox,
+			UI:   mockUI,
+		}
+		garbage = &vbox.Garbage{
+			HostOnlyInterfaces: []string{"some-interface"},
+			Disks:              []string{"some-disk"},
+			Files:              []string{"some-file"},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(gcCmd.Parse([]string{})).To(Succeed())
+				Expect(gcCmd.Parse([]string{"--dry-run"})).To(Succeed())
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(gcCmd.Parse([]string{"some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(gcCmd.Parse([]string{"--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should list the leftover resources and remove them after confirmation", func() {
+			Expect(gcCmd.Parse([]string{})).To(Succeed())
+
+			gomock.InOrder(
+				mockVBox.EXPECT().FindGarbage().Return(garbage, nil),
+				mockUI.EXPECT().Say("Found the following leftover PCF Dev resources:"),
+				mockUI.EXPECT().Say("  host-only interface %s", "some-interface"),
+				mockUI.EXPECT().Say("  disk %s", "some-disk"),
+				mockUI.EXPECT().Say("  file %s", "some-file"),
+				mockUI.EXPECT().Confirm("Remove them? (y/N): ").Return(true),
+				mockVBox.EXPECT().CollectGarbage(garbage),
+				mockUI.EXPECT().Say("Leftover PCF Dev resources have been removed."),
+			)
+
+			Expect(gcCmd.Run()).To(Succeed())
+		})
+
+		Context("when --dry-run is passed", func() {
+			It("should only list the leftover resources", func() {
+				Expect(gcCmd.Parse([]string{"--dry-run"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().FindGarbage().Return(garbage, nil),
+					mockUI.EXPECT().Say("Found the following leftover PCF Dev resources:"),
+					mockUI.EXPECT().Say("  host-only interface %s", "some-interface"),
+					mockUI.EXPECT().Say("  disk %s", "some-disk"),
+					mockUI.EXPECT().Say("  file %s", "some-file"),
+				)
+
+				Expect(gcCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when the user does not confirm", func() {
+			It("should not remove anything", func() {
+				Expect(gcCmd.Parse([]string{})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().FindGarbage().Return(&vbox.Garbage{Files: []string{"some-file"}}, nil),
+					mockUI.EXPECT().Say("Found the following leftover PCF Dev resources:"),
+					mockUI.EXPECT().Say("  file %s", "some-file"),
+					mockUI.EXPECT().Confirm("Remove them? (y/N): ").Return(false),
+					mockUI.EXPECT().Say("Nothing was removed."),
+				)
+
+				Expect(gcCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when there is nothing to clean up", func() {
+			It("should say so", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().FindGarbage().Return(&vbox.Garbage{}, nil),
+					mockUI.EXPECT().Say("Nothing to clean up."),
+				)
+
+				Expect(gcCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when finding leftover resources fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().FindGarbage().Return(nil, errors.New("some-error"))
+
+				Expect(gcCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when removing leftover resources fails", func() {
+			It("should return an error", func() {
+				mockUI.EXPECT().Say(gomock.Any()).AnyTimes()
+				mockUI.EXPECT().Say(gomock.Any(), gomock.Any()).AnyTimes()
+				gomock.InOrder(
+					mockVBox.EXPECT().FindGarbage().Return(garbage, nil),
+					mockUI.EXPECT().Confirm("Remove them? (y/N): ").Return(true),
+					mockVBox.EXPECT().CollectGarbage(garbage).Return(errors.New("some-error")),
+				)
+
+				Expect(gcCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
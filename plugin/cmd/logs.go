@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/debug"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+type LogsCmd struct {
+	VBox      VBox
+	VMBuilder VMBuilder
+	Config    *config.Config
+	opts      *vm.LogsOpts
+}
+
+const LOGS_ARGS = 0
+
+func (l *LogsCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	flagContext.NewBoolFlag("follow", "f", "<keep printing new lines until interrupted>")
+	flagContext.NewStringFlag("components", "", "<components to print logs from>")
+	flagContext.NewStringFlag("component", "", "<same as --components>")
+	flagContext.NewBoolFlag("scrub", "", "<scrub sensitive information from the output>")
+	if err := parse(flagContext, args, LOGS_ARGS); err != nil {
+		return err
+	}
+
+	l.opts = &vm.LogsOpts{
+		Follow: flagContext.Bool("follow"),
+		Scrub:  flagContext.Bool("scrub"),
+	}
+	for _, name := range []string{"components", "component"} {
+		if !flagContext.IsSet(name) {
+			continue
+		}
+		components, err := debug.ParseComponents(flagContext.String(name))
+		if err != nil {
+			return err
+		}
+		l.opts.Components = append(l.opts.Components, components...)
+	}
+	return nil
+}
+
+func (l *LogsCmd) Run() error {
+	vm, err := l.getVM()
+	if err != nil {
+		return err
+	}
+
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-interrupts:
+			close(stop)
+		case <-done:
+		}
+	}()
+
+	l.opts.Stop = stop
+	return vm.Logs(l.opts)
+}
+
+func (l *LogsCmd) getVM() (vm vm.VM, err error) {
+	name, err := l.VBox.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = l.Config.DefaultVMName
+	}
+	if name != l.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return l.VMBuilder.VM(name)
+}
@@ -0,0 +1,166 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("LogsCmd", func() {
+	var (
+		logsCmd       *cmd.LogsCmd
+		mockCtrl      *gomock.Controller
+		mockVBox      *mocks.MockVBox
+		mockVMBuilder *mocks.MockVMBuilder
+		mockVM        *vmMocks.MockVM
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		logsCmd = &cmd.LogsCmd{
+			VBox:      mockVBox,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(logsCmd.Parse([]string{})).To(Succeed())
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(logsCmd.Parse([]string{"some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(logsCmd.Parse([]string{"--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown component is passed", func() {
+			It("should fail", func() {
+				Expect(logsCmd.Parse([]string{"--components", "cc,some-component"})).To(MatchError(ContainSubstring("unknown component 'some-component'")))
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should stream the logs of the VM with the given options", func() {
+			Expect(logsCmd.Parse([]string{"--follow", "--components", "router,cc", "--scrub"})).To(Succeed())
+
+			gomock.InOrder(
+				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Logs(gomock.Any()).Do(func(opts *vm.LogsOpts) {
+					Expect(opts.Follow).To(BeTrue())
+					Expect(opts.Components).To(Equal([]string{"router", "cc"}))
+					Expect(opts.Scrub).To(BeTrue())
+					Expect(opts.Stop).NotTo(BeNil())
+					Consistently(opts.Stop).ShouldNot(BeClosed())
+				}),
+			)
+
+			Expect(logsCmd.Run()).To(Succeed())
+		})
+
+		Context("when no flags are passed", func() {
+			It("should print the recent logs without following them", func() {
+				Expect(logsCmd.Parse([]string{})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Logs(gomock.Any()).Do(func(opts *vm.LogsOpts) {
+						Expect(opts.Follow).To(BeFalse())
+						Expect(opts.Components).To(BeEmpty())
+						Expect(opts.Scrub).To(BeFalse())
+					}),
+				)
+
+				Expect(logsCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when the --component flag is passed", func() {
+			It("should print the logs of that component", func() {
+				Expect(logsCmd.Parse([]string{"--component", "router"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Logs(gomock.Any()).Do(func(opts *vm.LogsOpts) {
+						Expect(opts.Components).To(Equal([]string{"router"}))
+					}),
+				)
+
+				Expect(logsCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when there is an old VM present", func() {
+			It("should return an error", func() {
+				Expect(logsCmd.Parse([]string{})).To(Succeed())
+
+				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(logsCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				Expect(logsCmd.Parse([]string{})).To(Succeed())
+
+				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(logsCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an error building the VM", func() {
+			It("should return the error", func() {
+				Expect(logsCmd.Parse([]string{})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(logsCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when streaming the logs fails", func() {
+			It("should return the error", func() {
+				Expect(logsCmd.Parse([]string{})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Logs(gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(logsCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -5,7 +5,9 @@ package mocks
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/address"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vbox"
 	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
 )
 
@@ -30,6 +32,27 @@ func (_m *MockVBox) EXPECT() *_MockVBoxRecorder {
 	return _m.recorder
 }
 
+func (_m *MockVBox) CheckNetwork() ([]*address.Candidate, error) {
+	ret := _m.ctrl.Call(_m, "CheckNetwork")
+	ret0, _ := ret[0].([]*address.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVBoxRecorder) CheckNetwork() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CheckNetwork")
+}
+
+func (_m *MockVBox) CollectGarbage(_param0 *vbox.Garbage) error {
+	ret := _m.ctrl.Call(_m, "CollectGarbage", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVBoxRecorder) CollectGarbage(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "CollectGarbage", arg0)
+}
+
 func (_m *MockVBox) DestroyPCFDevVMs() error {
 	ret := _m.ctrl.Call(_m, "DestroyPCFDevVMs")
 	ret0, _ := ret[0].(error)
@@ -40,6 +63,17 @@ func (_mr *_MockVBoxRecorder) DestroyPCFDevVMs() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "DestroyPCFDevVMs")
 }
 
+func (_m *MockVBox) FindGarbage() (*vbox.Garbage, error) {
+	ret := _m.ctrl.Call(_m, "FindGarbage")
+	ret0, _ := ret[0].(*vbox.Garbage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVBoxRecorder) FindGarbage() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FindGarbage")
+}
+
 func (_m *MockVBox) GetVMName() (string, error) {
 	ret := _m.ctrl.Call(_m, "GetVMName")
 	ret0, _ := ret[0].(string)
@@ -62,6 +96,17 @@ func (_mr *_MockVBoxRecorder) VMConfig(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VMConfig", arg0)
 }
 
+func (_m *MockVBox) VMConfigFile(_param0 string) (*config.VMConfigFile, error) {
+	ret := _m.ctrl.Call(_m, "VMConfigFile", _param0)
+	ret0, _ := ret[0].(*config.VMConfigFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVBoxRecorder) VMConfigFile(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "VMConfigFile", arg0)
+}
+
 func (_m *MockVBox) Version() (*vboxdriver.VBoxDriverVersion, error) {
 	ret := _m.ctrl.Call(_m, "Version")
 	ret0, _ := ret[0].(*vboxdriver.VBoxDriverVersion)
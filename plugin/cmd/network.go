@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+)
+
+const NETWORK_ARGS = 1
+
+type NetworkCmd struct {
+	VBox VBox
+	UI   UI
+}
+
+func (n *NetworkCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, NETWORK_ARGS); err != nil {
+		return err
+	}
+
+	if subcommand := flagContext.Args()[0]; subcommand != "check" {
+		return fmt.Errorf("unknown network subcommand '%s'", subcommand)
+	}
+	return nil
+}
+
+func (n *NetworkCmd) Run() error {
+	candidates, err := n.VBox.CheckNetwork()
+	if err != nil {
+		return err
+	}
+	if len(candidates) == 0 {
+		return errors.New("no candidate subnets are configured")
+	}
+
+	available := 0
+	for _, candidate := range candidates {
+		if candidate.Available() {
+			available++
+			n.UI.Say("%s/24 (%s): available", candidate.Subnet, candidate.Domain)
+			continue
+		}
+
+		n.UI.Say("%s/24 (%s): rejected", candidate.Subnet, candidate.Domain)
+		for _, conflict := range candidate.Conflicts {
+			n.UI.Say("  - %s", conflict)
+		}
+	}
+
+	if available == 0 {
+		n.UI.Say("No subnets are available. Add a subnet_pool to addresses.json in your PCF Dev home directory.")
+	}
+	return nil
+}
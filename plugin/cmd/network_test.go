@@ -0,0 +1,107 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/address"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+)
+
+var _ = Describe("NetworkCmd", func() {
+	var (
+		networkCmd *cmd.NetworkCmd
+		mockCtrl   *gomock.Controller
+		mockVBox   *mocks.MockVBox
+		mockUI     *mocks.MockUI
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		networkCmd = &cmd.NetworkCmd{
+			VBox: mockVBox,
+			UI:   mockUI,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when check is passed", func() {
+			It("should succeed", func() {
+				Expect(networkCmd.Parse([]string{"check"})).To(Succeed())
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(networkCmd.Parse([]string{})).To(MatchError("wrong number of arguments"))
+				Expect(networkCmd.Parse([]string{"check", "some-bad-arg"})).To(MatchError("wrong number of arguments"))
+			})
+		})
+		Context("when an unknown network subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(networkCmd.Parse([]string{"some-bad-subcommand"})).To(MatchError("unknown network subcommand 'some-bad-subcommand'"))
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(networkCmd.Parse([]string{"check", "--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		It("should explain why each candidate subnet was rejected", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().CheckNetwork().Return([]*address.Candidate{
+					{Subnet: "192.168.11.1", Domain: "local.pcfdev.io", Conflicts: []string{"some-conflict", "some-other-conflict"}},
+					{Subnet: "192.168.22.1", Domain: "local2.pcfdev.io"},
+				}, nil),
+				mockUI.EXPECT().Say("%s/24 (%s): rejected", "192.168.11.1", "local.pcfdev.io"),
+				mockUI.EXPECT().Say("  - %s", "some-conflict"),
+				mockUI.EXPECT().Say("  - %s", "some-other-conflict"),
+				mockUI.EXPECT().Say("%s/24 (%s): available", "192.168.22.1", "local2.pcfdev.io"),
+			)
+
+			Expect(networkCmd.Run()).To(Succeed())
+		})
+
+		Context("when no subnets are available", func() {
+			It("should suggest adding a subnet pool", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().CheckNetwork().Return([]*address.Candidate{
+						{Subnet: "192.168.11.1", Domain: "local.pcfdev.io", Conflicts: []string{"some-conflict"}},
+					}, nil),
+					mockUI.EXPECT().Say("%s/24 (%s): rejected", "192.168.11.1", "local.pcfdev.io"),
+					mockUI.EXPECT().Say("  - %s", "some-conflict"),
+					mockUI.EXPECT().Say("No subnets are available. Add a subnet_pool to addresses.json in your PCF Dev home directory."),
+				)
+
+				Expect(networkCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when there are no candidate subnets", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().CheckNetwork().Return([]*address.Candidate{}, nil)
+
+				Expect(networkCmd.Run()).To(MatchError("no candidate subnets are configured"))
+			})
+		})
+
+		Context("when checking the network fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().CheckNetwork().Return(nil, errors.New("some-error"))
+
+				Expect(networkCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
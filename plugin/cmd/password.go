@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+const PASSWORD_ARGS = 1
+
+type PasswordCmd struct {
+	VMBuilder VMBuilder
+	VBox      VBox
+	Config    *config.Config
+	UI        UI
+}
+
+func (p *PasswordCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, PASSWORD_ARGS); err != nil {
+		return err
+	}
+
+	if flagContext.Args()[0] != "rotate" {
+		return fmt.Errorf("unknown password subcommand '%s'", flagContext.Args()[0])
+	}
+	return nil
+}
+
+func (p *PasswordCmd) Run() error {
+	vm, err := p.getVM()
+	if err != nil {
+		return err
+	}
+
+	password := os.Getenv("PCFDEV_NEW_PASSWORD")
+	if password == "" {
+		password, err = askForPassword(p.UI, "Choose new master password", "Confirm new master password")
+		if err != nil {
+			return err
+		}
+	}
+
+	return vm.RotatePassword(password)
+}
+
+func (p *PasswordCmd) getVM() (vm vm.VM, err error) {
+	name, err := p.VBox.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = p.Config.DefaultVMName
+	}
+	if name != p.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return p.VMBuilder.VM(name)
+}
+
+func askForPassword(ui UI, prompt string, confirmationPrompt string) (string, error) {
+	password := ui.AskForPassword(prompt)
+	passwordConfirmation := ui.AskForPassword(confirmationPrompt)
+
+	if password == "" && passwordConfirmation == "" {
+		return "", errors.New("password cannot be empty")
+	}
+
+	if password != passwordConfirmation {
+		return "", errors.New("passwords do not match")
+	}
+
+	return password, nil
+}
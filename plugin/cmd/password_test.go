@@ -0,0 +1,135 @@
+package cmd_test
+
+import (
+	"errors"
+	"os"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("PasswordCmd", func() {
+	var (
+		passwordCmd   *cmd.PasswordCmd
+		mockCtrl      *gomock.Controller
+		mockVBox      *mocks.MockVBox
+		mockVMBuilder *mocks.MockVMBuilder
+		mockUI        *mocks.MockUI
+		mockVM        *vmMocks.MockVM
+		newPassword   string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		passwordCmd = &cmd.PasswordCmd{
+			VBox:      mockVBox,
+			VMBuilder: mockVMBuilder,
+			UI:        mockUI,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+		newPassword = os.Getenv("PCFDEV_NEW_PASSWORD")
+		os.Unsetenv("PCFDEV_NEW_PASSWORD")
+	})
+
+	AfterEach(func() {
+		os.Setenv("PCFDEV_NEW_PASSWORD", newPassword)
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when 'rotate' is passed", func() {
+			It("should succeed", func() {
+				Expect(passwordCmd.Parse([]string{"rotate"})).To(Succeed())
+			})
+		})
+		Context("when an unknown subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(passwordCmd.Parse([]string{"some-bad-subcommand"})).To(MatchError("unknown password subcommand 'some-bad-subcommand'"))
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(passwordCmd.Parse([]string{})).NotTo(Succeed())
+				Expect(passwordCmd.Parse([]string{"rotate", "some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(passwordCmd.Parse([]string{"rotate"})).To(Succeed())
+		})
+
+		It("should prompt for the new password and rotate it", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockUI.EXPECT().AskForPassword("Choose new master password").Return("some-password"),
+				mockUI.EXPECT().AskForPassword("Confirm new master password").Return("some-password"),
+				mockVM.EXPECT().RotatePassword("some-password"),
+			)
+
+			Expect(passwordCmd.Run()).To(Succeed())
+		})
+
+		Context("when PCFDEV_NEW_PASSWORD is set", func() {
+			It("should not prompt for the new password", func() {
+				os.Setenv("PCFDEV_NEW_PASSWORD", "some-env-password")
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().RotatePassword("some-env-password"),
+				)
+
+				Expect(passwordCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when the passwords do not match", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockUI.EXPECT().AskForPassword("Choose new master password").Return("some-password"),
+					mockUI.EXPECT().AskForPassword("Confirm new master password").Return("some-other-password"),
+				)
+
+				Expect(passwordCmd.Run()).To(MatchError("passwords do not match"))
+			})
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(passwordCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when rotating the password fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockUI.EXPECT().AskForPassword("Choose new master password").Return("some-password"),
+					mockUI.EXPECT().AskForPassword("Confirm new master password").Return("some-password"),
+					mockVM.EXPECT().RotatePassword("some-password").Return(errors.New("some-error")),
+				)
+
+				Expect(passwordCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
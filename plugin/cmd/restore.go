@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+const RESTORE_ARGS = 1
+
+type RestoreCmd struct {
+	VMBuilder VMBuilder
+	VBox      VBox
+	Config    *config.Config
+	path      string
+}
+
+func (r *RestoreCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, RESTORE_ARGS); err != nil {
+		return err
+	}
+
+	path, err := filepath.Abs(flagContext.Args()[0])
+	if err != nil {
+		return err
+	}
+	r.path = path
+	return nil
+}
+
+func (r *RestoreCmd) Run() error {
+	vm, err := r.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.Restore(r.path)
+}
+
+func (r *RestoreCmd) getVM() (vm vm.VM, err error) {
+	name, err := r.VBox.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = r.Config.DefaultVMName
+	}
+	if name != r.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return r.VMBuilder.VM(name)
+}
@@ -0,0 +1,119 @@
+package cmd_test
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("RestoreCmd", func() {
+	var (
+		restoreCmd    *cmd.RestoreCmd
+		mockCtrl      *gomock.Controller
+		mockVBox      *mocks.MockVBox
+		mockVMBuilder *mocks.MockVMBuilder
+		mockVM        *vmMocks.MockVM
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		restoreCmd = &cmd.RestoreCmd{
+			VBox:      mockVBox,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(restoreCmd.Parse([]string{"some-backup.tgz"})).To(Succeed())
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(restoreCmd.Parse([]string{})).NotTo(Succeed())
+				Expect(restoreCmd.Parse([]string{"some-backup.tgz", "some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(restoreCmd.Parse([]string{"some-backup.tgz", "--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(restoreCmd.Parse([]string{"some-backup.tgz"})).To(Succeed())
+		})
+
+		It("should call Restore on the VM with the absolute path", func() {
+			path, err := filepath.Abs("some-backup.tgz")
+			Expect(err).NotTo(HaveOccurred())
+
+			gomock.InOrder(
+				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Restore(path),
+			)
+
+			Expect(restoreCmd.Run()).To(Succeed())
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(restoreCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(restoreCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an error building the VM", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(restoreCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the VM fails to restore", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Restore(gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(restoreCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/cloudfoundry/cli/cf/flags"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 )
 
@@ -12,18 +13,30 @@ type ResumeCmd struct {
 	VBox      VBox
 	VMBuilder VMBuilder
 	Config    *config.Config
+	UI        UI
+	Tracer    *timing.Tracer
+	History   History
+	timings   bool
 }
 
 func (r *ResumeCmd) Parse(args []string) error {
-	return parse(flags.New(), args, RESUME_ARGS)
+	flagContext := flags.New()
+	flagContext.NewBoolFlag("timings", "", "<print how long each phase took>")
+	if err := parse(flagContext, args, RESUME_ARGS); err != nil {
+		return err
+	}
+	r.timings = flagContext.Bool("timings")
+	return nil
 }
 
 func (r *ResumeCmd) Run() error {
-	vm, err := r.getVM()
-	if err != nil {
-		return err
-	}
-	return vm.Resume()
+	return runTimed(r.Tracer, r.History, r.UI, r.Config, "resume", r.timings, func() error {
+		vm, err := r.getVM()
+		if err != nil {
+			return err
+		}
+		return vm.Resume()
+	})
 }
 
 func (r *ResumeCmd) getVM() (vm vm.VM, err error) {
@@ -2,6 +2,7 @@ package cmd_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -9,6 +10,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
 )
 
@@ -19,19 +21,34 @@ var _ = Describe("ResumeCmd", func() {
 		mockVBox      *mocks.MockVBox
 		mockVM        *vmMocks.MockVM
 		mockVMBuilder *mocks.MockVMBuilder
+		mockUI        *mocks.MockUI
+		mockHistory   *mocks.MockHistory
+		savedRecord   *timing.Record
 	)
 
 	BeforeEach(func() {
 		mockCtrl = gomock.NewController(GinkgoT())
 		mockVBox = mocks.NewMockVBox(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		mockHistory = mocks.NewMockHistory(mockCtrl)
+		mockHistory.EXPECT().Save(gomock.Any()).Do(func(record *timing.Record) {
+			savedRecord = record
+		}).AnyTimes()
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
 		resumeCmd = &cmd.ResumeCmd{
 			VBox:      mockVBox,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
+				Version: &config.Version{
+					BuildVersion:    "some-plugin-version",
+					OVABuildVersion: "some-ova-version",
+				},
 			},
+			UI:      mockUI,
+			Tracer:  &timing.Tracer{},
+			History: mockHistory,
 		}
 	})
 
@@ -58,6 +75,70 @@ var _ = Describe("ResumeCmd", func() {
 	})
 
 	Describe("Run", func() {
+		It("should save the timings of the resume to the history", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Resume(),
+			)
+
+			Expect(resumeCmd.Run()).To(Succeed())
+			Expect(savedRecord.Command).To(Equal("resume"))
+			Expect(savedRecord.PluginVersion).To(Equal("some-plugin-version"))
+			Expect(savedRecord.OVAVersion).To(Equal("some-ova-version"))
+			Expect(savedRecord.Succeeded).To(BeTrue())
+			Expect(savedRecord.Phases).To(HaveLen(1))
+			Expect(savedRecord.Phases[0].Name).To(Equal("resume"))
+		})
+
+		Context("when --timings is passed", func() {
+			It("should print the timings", func() {
+				resumeCmd.Tracer.Now = func() time.Time { return time.Time{} }
+				Expect(resumeCmd.Parse([]string{"--timings"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Resume(),
+					mockUI.EXPECT().Say("Timings:"),
+					mockUI.EXPECT().Say("  %s", "resume  0s"),
+				)
+
+				Expect(resumeCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when the resume fails", func() {
+			It("should save the timings as unsuccessful and return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Resume().Return(errors.New("some-error")),
+				)
+
+				Expect(resumeCmd.Run()).To(MatchError("some-error"))
+				Expect(savedRecord.Succeeded).To(BeFalse())
+			})
+		})
+
+		Context("when saving the timings fails", func() {
+			It("should say a message and still succeed", func() {
+				failingHistory := mocks.NewMockHistory(mockCtrl)
+				resumeCmd.History = failingHistory
+				resumeCmd.Config.HistoryPath = "some-history-path"
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Resume(),
+					failingHistory.EXPECT().Save(gomock.Any()).Return(errors.New("some-error")),
+					mockUI.EXPECT().Say("Failed to save timings to %s: %s", "some-history-path", errors.New("some-error")),
+				)
+
+				Expect(resumeCmd.Run()).To(Succeed())
+			})
+		})
+
 		Context("when the default VM is present", func() {
 			It("should resume the VM", func() {
 				gomock.InOrder(
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+const SEED_ARGS = 1
+
+type SeedCmd struct {
+	VMBuilder VMBuilder
+	VBox      VBox
+	Config    *config.Config
+	path      string
+}
+
+func (s *SeedCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	if err := parse(flagContext, args, SEED_ARGS); err != nil {
+		return err
+	}
+
+	s.path = flagContext.Args()[0]
+	return nil
+}
+
+func (s *SeedCmd) Run() error {
+	vm, err := s.getVM()
+	if err != nil {
+		return err
+	}
+	return vm.Seed(s.path)
+}
+
+func (s *SeedCmd) getVM() (vm vm.VM, err error) {
+	name, err := s.VBox.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = s.Config.DefaultVMName
+	}
+	if name != s.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return s.VMBuilder.VM(name)
+}
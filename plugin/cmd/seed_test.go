@@ -0,0 +1,115 @@
+package cmd_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("SeedCmd", func() {
+	var (
+		seedCmd       *cmd.SeedCmd
+		mockCtrl      *gomock.Controller
+		mockVBox      *mocks.MockVBox
+		mockVMBuilder *mocks.MockVMBuilder
+		mockVM        *vmMocks.MockVM
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		seedCmd = &cmd.SeedCmd{
+			VBox:      mockVBox,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when the correct number of arguments are passed", func() {
+			It("should succeed", func() {
+				Expect(seedCmd.Parse([]string{"some-seed.yml"})).To(Succeed())
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(seedCmd.Parse([]string{})).NotTo(Succeed())
+				Expect(seedCmd.Parse([]string{"some-seed.yml", "some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(seedCmd.Parse([]string{"some-seed.yml", "--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		BeforeEach(func() {
+			Expect(seedCmd.Parse([]string{"some-seed.yml"})).To(Succeed())
+		})
+
+		It("should call Seed on the VM", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Seed("some-seed.yml"),
+			)
+
+			Expect(seedCmd.Run()).To(Succeed())
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(seedCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(seedCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an error building the VM", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(seedCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the VM fails to seed", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Seed(gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(seedCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/cloudfoundry/cli/cf/flags"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
+)
+
+type ShareCmd struct {
+	VMBuilder    VMBuilder
+	VBox         VBox
+	Config       *config.Config
+	subcommand   string
+	sharedFolder *config.SharedFolder
+}
+
+func (s *ShareCmd) Parse(args []string) error {
+	flagContext := flags.New()
+	flagContext.NewBoolFlag("readonly", "", "<mount read-only>")
+	if err := flagContext.Parse(args...); err != nil {
+		return err
+	}
+
+	args = flagContext.Args()
+	if len(args) == 0 {
+		return errors.New("wrong number of arguments")
+	}
+
+	s.subcommand = args[0]
+	switch s.subcommand {
+	case "add":
+		if len(args) != 3 {
+			return errors.New("wrong number of arguments")
+		}
+		hostPath, err := filepath.Abs(args[1])
+		if err != nil {
+			return err
+		}
+		guestPath, err := parseGuestPath(args[2])
+		if err != nil {
+			return err
+		}
+		s.sharedFolder = &config.SharedFolder{
+			HostPath:  hostPath,
+			GuestPath: guestPath,
+			ReadOnly:  flagContext.Bool("readonly"),
+		}
+	case "remove":
+		if len(args) != 2 {
+			return errors.New("wrong number of arguments")
+		}
+		guestPath, err := parseGuestPath(args[1])
+		if err != nil {
+			return err
+		}
+		s.sharedFolder = &config.SharedFolder{GuestPath: guestPath}
+	case "list":
+		if len(args) != 1 {
+			return errors.New("wrong number of arguments")
+		}
+	default:
+		return fmt.Errorf("unknown share subcommand '%s'", s.subcommand)
+	}
+
+	if flagContext.Bool("readonly") && s.subcommand != "add" {
+		return errors.New("the --readonly flag can only be used with 'share add'")
+	}
+	return nil
+}
+
+func (s *ShareCmd) Run() error {
+	vm, err := s.getVM()
+	if err != nil {
+		return err
+	}
+
+	switch s.subcommand {
+	case "add":
+		return vm.AddShare(s.sharedFolder)
+	case "remove":
+		return vm.RemoveShare(s.sharedFolder.GuestPath)
+	default:
+		return vm.ListShares()
+	}
+}
+
+func (s *ShareCmd) getVM() (vm vm.VM, err error) {
+	name, err := s.VBox.GetVMName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = s.Config.DefaultVMName
+	}
+	if name != s.Config.DefaultVMName && name != "pcfdev-custom" {
+		return nil, &OldVMError{}
+	}
+
+	return s.VMBuilder.VM(name)
+}
+
+func parseGuestPath(guestPath string) (string, error) {
+	if !strings.HasPrefix(guestPath, "/") {
+		return "", errors.New("guest path must be absolute")
+	}
+
+	guestPath = path.Clean(guestPath)
+	if guestPath == "/" {
+		return "", errors.New("guest path cannot be the root directory")
+	}
+	return guestPath, nil
+}
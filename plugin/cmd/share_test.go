@@ -0,0 +1,175 @@
+package cmd_test
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
+	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
+)
+
+var _ = Describe("ShareCmd", func() {
+	var (
+		shareCmd      *cmd.ShareCmd
+		mockCtrl      *gomock.Controller
+		mockVBox      *mocks.MockVBox
+		mockVMBuilder *mocks.MockVMBuilder
+		mockVM        *vmMocks.MockVM
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockVBox = mocks.NewMockVBox(mockCtrl)
+		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
+		mockVM = vmMocks.NewMockVM(mockCtrl)
+		shareCmd = &cmd.ShareCmd{
+			VBox:      mockVBox,
+			VMBuilder: mockVMBuilder,
+			Config: &config.Config{
+				DefaultVMName: "some-default-vm-name",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("Parse", func() {
+		Context("when add is passed a host path and a guest path", func() {
+			It("should succeed", func() {
+				Expect(shareCmd.Parse([]string{"add", "some-host-path", "/some-guest-path"})).To(Succeed())
+				Expect(shareCmd.Parse([]string{"add", "some-host-path", "/some-guest-path", "--readonly"})).To(Succeed())
+			})
+		})
+		Context("when remove is passed a guest path", func() {
+			It("should succeed", func() {
+				Expect(shareCmd.Parse([]string{"remove", "/some-guest-path"})).To(Succeed())
+			})
+		})
+		Context("when list is passed", func() {
+			It("should succeed", func() {
+				Expect(shareCmd.Parse([]string{"list"})).To(Succeed())
+			})
+		})
+		Context("when the guest path is not absolute", func() {
+			It("should fail", func() {
+				Expect(shareCmd.Parse([]string{"add", "some-host-path", "some-guest-path"})).To(MatchError("guest path must be absolute"))
+				Expect(shareCmd.Parse([]string{"remove", "some-guest-path"})).To(MatchError("guest path must be absolute"))
+			})
+		})
+		Context("when the guest path is the root directory", func() {
+			It("should fail", func() {
+				Expect(shareCmd.Parse([]string{"add", "some-host-path", "/"})).To(MatchError("guest path cannot be the root directory"))
+			})
+		})
+		Context("when --readonly is passed to a subcommand other than add", func() {
+			It("should fail", func() {
+				Expect(shareCmd.Parse([]string{"list", "--readonly"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown share subcommand is passed", func() {
+			It("should fail", func() {
+				Expect(shareCmd.Parse([]string{"some-bad-subcommand"})).To(MatchError("unknown share subcommand 'some-bad-subcommand'"))
+			})
+		})
+		Context("when the wrong number of arguments are passed", func() {
+			It("should fail", func() {
+				Expect(shareCmd.Parse([]string{})).NotTo(Succeed())
+				Expect(shareCmd.Parse([]string{"add", "some-host-path"})).NotTo(Succeed())
+				Expect(shareCmd.Parse([]string{"remove"})).NotTo(Succeed())
+				Expect(shareCmd.Parse([]string{"list", "some-bad-arg"})).NotTo(Succeed())
+			})
+		})
+		Context("when an unknown flag is passed", func() {
+			It("should fail", func() {
+				Expect(shareCmd.Parse([]string{"list", "--some-bad-flag"})).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("Run", func() {
+		Context("when adding a shared folder", func() {
+			It("should add the shared folder to the VM with an absolute host path", func() {
+				Expect(shareCmd.Parse([]string{"add", "some-host-path", "/some-guest-path/", "--readonly"})).To(Succeed())
+				hostPath, err := filepath.Abs("some-host-path")
+				Expect(err).NotTo(HaveOccurred())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().AddShare(&config.SharedFolder{
+						HostPath:  hostPath,
+						GuestPath: "/some-guest-path",
+						ReadOnly:  true,
+					}),
+				)
+
+				Expect(shareCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when removing a shared folder", func() {
+			It("should remove the shared folder from the VM", func() {
+				Expect(shareCmd.Parse([]string{"remove", "/some-guest-path"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().RemoveShare("/some-guest-path"),
+				)
+
+				Expect(shareCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when listing shared folders", func() {
+			It("should list the shared folders of the VM", func() {
+				Expect(shareCmd.Parse([]string{"list"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().ListShares().Return(errors.New("some-error")),
+				)
+
+				Expect(shareCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an old vm present", func() {
+			It("should return an error", func() {
+				Expect(shareCmd.Parse([]string{"list"})).To(Succeed())
+				mockVBox.EXPECT().GetVMName().Return("some-old-vm-name", nil)
+
+				Expect(shareCmd.Run()).To(MatchError("old version of PCF Dev already running, please run `cf dev destroy` to continue"))
+			})
+		})
+
+		Context("when there is an error getting the VM name", func() {
+			It("should return the error", func() {
+				Expect(shareCmd.Parse([]string{"list"})).To(Succeed())
+				mockVBox.EXPECT().GetVMName().Return("", errors.New("some-error"))
+
+				Expect(shareCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when there is an error building the VM", func() {
+			It("should return the error", func() {
+				Expect(shareCmd.Parse([]string{"list"})).To(Succeed())
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(shareCmd.Run()).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/cloudfoundry/cli/cf/flags"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 	"os"
 )
@@ -19,7 +23,10 @@ type StartCmd struct {
 	AutoTrustCmd AutoCmd
 	DownloadCmd  Cmd
 	TargetCmd    Cmd
+	SeedCmd      Cmd
 	UI           UI
+	Tracer       *timing.Tracer
+	History      History
 	flagContext  flags.FlagContext
 }
 
@@ -37,10 +44,27 @@ func (s *StartCmd) Parse(args []string) error {
 	s.flagContext.NewStringFlag("d", "", "<domain>")
 	s.flagContext.NewStringFlag("i", "", "<IP>")
 	s.flagContext.NewBoolFlag("x", "", "<master password>")
+	s.flagContext.NewStringFlag("seed", "", "<seed manifest>")
+	s.flagContext.NewStringFlag("network", "", "<network mode>")
+	s.flagContext.NewStringFlag("provision-timeout", "", "<maximum duration of provisioning>")
+	s.flagContext.NewBoolFlag("timings", "", "<print how long each phase took>")
 	if err := parse(s.flagContext, args, START_ARGS); err != nil {
 		return err
 	}
 
+	network, bridgeAdapter, err := parseNetwork(s.flagContext.String("network"))
+	if err != nil {
+		return err
+	}
+
+	var provisionTimeout time.Duration
+	if s.flagContext.IsSet("provision-timeout") {
+		provisionTimeout, err = time.ParseDuration(s.flagContext.String("provision-timeout"))
+		if err != nil || provisionTimeout <= 0 {
+			return fmt.Errorf("invalid --provision-timeout duration '%s', expected a value like 20m or 1h", s.flagContext.String("provision-timeout"))
+		}
+	}
+
 	var password string
 	if s.flagContext.Bool("x") {
 		var err error
@@ -51,21 +75,41 @@ func (s *StartCmd) Parse(args []string) error {
 	}
 
 	s.Opts = &vm.StartOpts{
-		CPUs:           s.flagContext.Int("c"),
-		Memory:         uint64(s.flagContext.Int("m")),
-		NoProvision:    s.flagContext.Bool("n"),
-		OVAPath:        s.flagContext.String("o"),
-		Registries:     s.flagContext.String("r"),
-		Services:       s.flagContext.String("s"),
-		Target:         s.flagContext.Bool("t"),
-		Domain:         s.flagContext.String("d"),
-		IP:             s.flagContext.String("i"),
-		MasterPassword: password,
+		CPUs:             s.flagContext.Int("c"),
+		Memory:           uint64(s.flagContext.Int("m")),
+		NoProvision:      s.flagContext.Bool("n"),
+		OVAPath:          s.flagContext.String("o"),
+		Registries:       s.flagContext.String("r"),
+		Services:         s.flagContext.String("s"),
+		Target:           s.flagContext.Bool("t"),
+		Domain:           s.flagContext.String("d"),
+		IP:               s.flagContext.String("i"),
+		MasterPassword:   password,
+		Network:          network,
+		BridgeAdapter:    bridgeAdapter,
+		ProvisionTimeout: provisionTimeout,
 	}
 	return nil
 }
 
+func parseNetwork(value string) (network string, bridgeAdapter string, err error) {
+	switch {
+	case value == "":
+		return "", "", nil
+	case value == config.NetworkHostOnly, value == config.NetworkBridged:
+		return value, "", nil
+	case strings.HasPrefix(value, config.NetworkBridged+"=") && len(value) > len(config.NetworkBridged+"="):
+		return config.NetworkBridged, strings.TrimPrefix(value, config.NetworkBridged+"="), nil
+	default:
+		return "", "", fmt.Errorf("unknown network mode '%s', expected 'hostonly', 'bridged' or 'bridged=IFACE'", value)
+	}
+}
+
 func (s *StartCmd) Run() error {
+	return runTimed(s.Tracer, s.History, s.UI, s.Config, "start", s.flagContext.Bool("timings"), s.run)
+}
+
+func (s *StartCmd) run() error {
 	version, err := s.VBox.Version()
 	if err != nil {
 		return err
@@ -109,13 +153,13 @@ func (s *StartCmd) Run() error {
 	}
 
 	if s.flagContext.Bool("p") {
-		return v.Provision(&vm.StartOpts{})
+		return v.Provision(&vm.StartOpts{ProvisionTimeout: s.Opts.ProvisionTimeout})
 	} else {
 		if err := v.VerifyStartOpts(s.Opts); err != nil {
 			return err
 		}
 		if s.Opts.OVAPath == "" && existingVMName != "pcfdev-custom" {
-			if err := s.DownloadCmd.Run(); err != nil {
+			if err := s.Tracer.Trace("download", s.DownloadCmd.Run); err != nil {
 				return err
 			}
 		}
@@ -125,13 +169,22 @@ func (s *StartCmd) Run() error {
 		}
 
 		if s.flagContext.Bool("k") {
-			if err := s.AutoTrustCmd.Run(); err != nil {
+			if err := s.Tracer.Trace("trust", s.AutoTrustCmd.Run); err != nil {
+				return err
+			}
+		}
+
+		if s.flagContext.IsSet("seed") {
+			if err := s.SeedCmd.Parse([]string{s.flagContext.String("seed")}); err != nil {
+				return err
+			}
+			if err := s.Tracer.Trace("seed", s.SeedCmd.Run); err != nil {
 				return err
 			}
 		}
 
 		if s.flagContext.Bool("t") {
-			return s.TargetCmd.Run()
+			return s.Tracer.Trace("target", s.TargetCmd.Run)
 		}
 
 		return nil
@@ -143,16 +196,5 @@ func (s *StartCmd) getPCFDevPassword() (string, error) {
 		return os.Getenv("PCFDEV_PASSWORD"), nil
 	}
 
-	password := s.UI.AskForPassword("Choose master password")
-	passwordConfirmation := s.UI.AskForPassword("Confirm master password")
-
-	if password == "" && passwordConfirmation == "" {
-		return "", errors.New("password cannot be empty")
-	}
-
-	if password != passwordConfirmation {
-		return "", errors.New("passwords do not match")
-	}
-
-	return password, nil
+	return askForPassword(s.UI, "Choose master password", "Confirm master password")
 }
@@ -2,6 +2,7 @@ package cmd_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -9,6 +10,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
@@ -27,6 +29,9 @@ var _ = Describe("StartCmd", func() {
 		mockAutoTrustCmd *mocks.MockAutoCmd
 		mockDownloadCmd  *mocks.MockCmd
 		mockTargetCmd    *mocks.MockCmd
+		mockSeedCmd      *mocks.MockCmd
+		mockHistory      *mocks.MockHistory
+		savedRecord      *timing.Record
 	)
 
 	BeforeEach(func() {
@@ -38,18 +43,30 @@ var _ = Describe("StartCmd", func() {
 		mockDownloadCmd = mocks.NewMockCmd(mockCtrl)
 		mockAutoTrustCmd = mocks.NewMockAutoCmd(mockCtrl)
 		mockTargetCmd = mocks.NewMockCmd(mockCtrl)
+		mockSeedCmd = mocks.NewMockCmd(mockCtrl)
 		mockUI = mocks.NewMockUI(mockCtrl)
+		mockHistory = mocks.NewMockHistory(mockCtrl)
+		mockHistory.EXPECT().Save(gomock.Any()).Do(func(record *timing.Record) {
+			savedRecord = record
+		}).AnyTimes()
 		startCmd = &cmd.StartCmd{
 			VBox:      mockVBox,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
+				Version: &config.Version{
+					BuildVersion:    "some-plugin-version",
+					OVABuildVersion: "some-ova-version",
+				},
 			},
 			Opts:         &vm.StartOpts{},
 			DownloadCmd:  mockDownloadCmd,
 			AutoTrustCmd: mockAutoTrustCmd,
 			TargetCmd:    mockTargetCmd,
+			SeedCmd:      mockSeedCmd,
 			UI:           mockUI,
+			Tracer:       &timing.Tracer{},
+			History:      mockHistory,
 		}
 	})
 
@@ -86,6 +103,50 @@ var _ = Describe("StartCmd", func() {
 			})
 		})
 
+		Context("when bridged networking is requested", func() {
+			It("should use the first host interface that is up", func() {
+				Expect(startCmd.Parse([]string{"--network", "bridged"})).To(Succeed())
+				Expect(startCmd.Opts.Network).To(Equal("bridged"))
+				Expect(startCmd.Opts.BridgeAdapter).To(BeEmpty())
+			})
+
+			Context("when a host interface is given", func() {
+				It("should set the host interface", func() {
+					Expect(startCmd.Parse([]string{"--network", "bridged=en0: Wi-Fi (AirPort)"})).To(Succeed())
+					Expect(startCmd.Opts.Network).To(Equal("bridged"))
+					Expect(startCmd.Opts.BridgeAdapter).To(Equal("en0: Wi-Fi (AirPort)"))
+				})
+			})
+		})
+
+		Context("when host-only networking is requested", func() {
+			It("should set the network mode", func() {
+				Expect(startCmd.Parse([]string{"--network", "hostonly"})).To(Succeed())
+				Expect(startCmd.Opts.Network).To(Equal("hostonly"))
+			})
+		})
+
+		Context("when an unknown network mode is passed", func() {
+			It("should return an error", func() {
+				Expect(startCmd.Parse([]string{"--network", "some-bad-mode"})).To(MatchError("unknown network mode 'some-bad-mode', expected 'hostonly', 'bridged' or 'bridged=IFACE'"))
+				Expect(startCmd.Parse([]string{"--network", "bridged="})).To(MatchError("unknown network mode 'bridged=', expected 'hostonly', 'bridged' or 'bridged=IFACE'"))
+			})
+		})
+
+		Context("when a provision timeout is passed", func() {
+			It("should set the provision timeout", func() {
+				Expect(startCmd.Parse([]string{"--provision-timeout", "30m"})).To(Succeed())
+				Expect(startCmd.Opts.ProvisionTimeout).To(Equal(30 * time.Minute))
+			})
+		})
+
+		Context("when the provision timeout is invalid", func() {
+			It("should return an error", func() {
+				Expect(startCmd.Parse([]string{"--provision-timeout", "some-duration"})).To(MatchError("invalid --provision-timeout duration 'some-duration', expected a value like 20m or 1h"))
+				Expect(startCmd.Parse([]string{"--provision-timeout", "0s"})).To(MatchError("invalid --provision-timeout duration '0s', expected a value like 20m or 1h"))
+			})
+		})
+
 		Context("when no flags are passed", func() {
 			It("should set start options", func() {
 				Expect(startCmd.Parse([]string{})).To(Succeed())
@@ -99,6 +160,7 @@ var _ = Describe("StartCmd", func() {
 				Expect(startCmd.Opts.Domain).To(BeEmpty())
 				Expect(startCmd.Opts.IP).To(BeEmpty())
 				Expect(startCmd.Opts.MasterPassword).To(BeEmpty())
+				Expect(startCmd.Opts.Network).To(BeEmpty())
 			})
 		})
 
@@ -197,6 +259,47 @@ var _ = Describe("StartCmd", func() {
 				Expect(startCmd.Run()).To(Succeed())
 			})
 
+			It("should save the timings of each phase to the history", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
+					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
+					mockDownloadCmd.EXPECT().Run(),
+					mockVM.EXPECT().Start(&vm.StartOpts{}),
+				)
+
+				Expect(startCmd.Run()).To(Succeed())
+				Expect(savedRecord.Command).To(Equal("start"))
+				Expect(savedRecord.PluginVersion).To(Equal("some-plugin-version"))
+				Expect(savedRecord.OVAVersion).To(Equal("some-ova-version"))
+				Expect(savedRecord.Succeeded).To(BeTrue())
+				Expect(savedRecord.Phases).To(HaveLen(2))
+				Expect(savedRecord.Phases[0].Name).To(Equal("start"))
+				Expect(savedRecord.Phases[1].Name).To(Equal("start/download"))
+			})
+
+			Context("when --timings is passed", func() {
+				It("should print the timings after starting", func() {
+					startCmd.Tracer.Now = func() time.Time { return time.Time{} }
+					startCmd.Parse([]string{"--timings"})
+
+					gomock.InOrder(
+						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
+						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
+						mockDownloadCmd.EXPECT().Run(),
+						mockVM.EXPECT().Start(&vm.StartOpts{}),
+						mockUI.EXPECT().Say("Timings:"),
+						mockUI.EXPECT().Say("  %s", "start       0s"),
+						mockUI.EXPECT().Say("  %s", "  download  0s"),
+					)
+
+					Expect(startCmd.Run()).To(Succeed())
+				})
+			})
+
 			Context("when the trust option is passed", func() {
 				It("should trust the VM certificate after starting", func() {
 					startCmd.Parse([]string{"-k"})
@@ -251,6 +354,45 @@ var _ = Describe("StartCmd", func() {
 				})
 			})
 
+			Context("when the seed option is passed", func() {
+				It("should seed PCF Dev before targeting it", func() {
+					startCmd.Parse([]string{"--seed", "some-seed-path", "-t"})
+
+					gomock.InOrder(
+						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
+						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{Target: true}),
+						mockDownloadCmd.EXPECT().Run(),
+						mockVM.EXPECT().Start(&vm.StartOpts{Target: true}),
+						mockSeedCmd.EXPECT().Parse([]string{"some-seed-path"}),
+						mockSeedCmd.EXPECT().Run(),
+						mockTargetCmd.EXPECT().Run(),
+					)
+
+					Expect(startCmd.Run()).To(Succeed())
+				})
+			})
+
+			Context("when seeding PCF Dev fails", func() {
+				It("should return the error", func() {
+					startCmd.Parse([]string{"--seed", "some-seed-path"})
+
+					gomock.InOrder(
+						mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
+						mockVBox.EXPECT().GetVMName().Return("", nil),
+						mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+						mockVM.EXPECT().VerifyStartOpts(&vm.StartOpts{}),
+						mockDownloadCmd.EXPECT().Run(),
+						mockVM.EXPECT().Start(&vm.StartOpts{}),
+						mockSeedCmd.EXPECT().Parse([]string{"some-seed-path"}),
+						mockSeedCmd.EXPECT().Run().Return(errors.New("some-error")),
+					)
+
+					Expect(startCmd.Run()).To(MatchError("some-error"))
+				})
+			})
+
 			Context("when targeting PCF Dev and trusting VM certificates", func() {
 				It("should target PCF Dev and trust the VM certificates", func() {
 					startCmd.Parse([]string{"-t", "-k"})
@@ -463,6 +605,21 @@ var _ = Describe("StartCmd", func() {
 			})
 		})
 
+		Context("when the provision option is specified with a provision timeout", func() {
+			It("should provision the VM with the timeout", func() {
+				startCmd.Parse([]string{"-p", "--provision-timeout", "1h"})
+
+				gomock.InOrder(
+					mockVBox.EXPECT().Version().Return(&vboxdriver.VBoxDriverVersion{Major: 5}, nil),
+					mockVBox.EXPECT().GetVMName().Return("", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Provision(&vm.StartOpts{ProvisionTimeout: time.Hour}),
+				)
+
+				Expect(startCmd.Run()).To(Succeed())
+			})
+		})
+
 		Context("when provisioning fails", func() {
 			It("return an error", func() {
 				startCmd.Parse([]string{"-p"})
@@ -3,6 +3,7 @@ package cmd
 import (
 	"github.com/cloudfoundry/cli/cf/flags"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 )
 
@@ -12,18 +13,30 @@ type StopCmd struct {
 	VBox      VBox
 	VMBuilder VMBuilder
 	Config    *config.Config
+	UI        UI
+	Tracer    *timing.Tracer
+	History   History
+	timings   bool
 }
 
 func (s *StopCmd) Parse(args []string) error {
-	return parse(flags.New(), args, STOP_ARGS)
+	flagContext := flags.New()
+	flagContext.NewBoolFlag("timings", "", "<print how long each phase took>")
+	if err := parse(flagContext, args, STOP_ARGS); err != nil {
+		return err
+	}
+	s.timings = flagContext.Bool("timings")
+	return nil
 }
 
 func (s *StopCmd) Run() error {
-	vm, err := s.getVM()
-	if err != nil {
-		return err
-	}
-	return vm.Stop()
+	return runTimed(s.Tracer, s.History, s.UI, s.Config, "stop", s.timings, func() error {
+		vm, err := s.getVM()
+		if err != nil {
+			return err
+		}
+		return vm.Stop()
+	})
 }
 
 func (s *StopCmd) getVM() (vm vm.VM, err error) {
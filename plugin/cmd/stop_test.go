@@ -2,6 +2,7 @@ package cmd_test
 
 import (
 	"errors"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
@@ -9,6 +10,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
 )
 
@@ -19,6 +21,9 @@ var _ = Describe("StopCmd", func() {
 		mockVMBuilder *mocks.MockVMBuilder
 		mockVBox      *mocks.MockVBox
 		mockVM        *vmMocks.MockVM
+		mockUI        *mocks.MockUI
+		mockHistory   *mocks.MockHistory
+		savedRecord   *timing.Record
 	)
 
 	BeforeEach(func() {
@@ -26,12 +31,24 @@ var _ = Describe("StopCmd", func() {
 		mockVMBuilder = mocks.NewMockVMBuilder(mockCtrl)
 		mockVBox = mocks.NewMockVBox(mockCtrl)
 		mockVM = vmMocks.NewMockVM(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		mockHistory = mocks.NewMockHistory(mockCtrl)
+		mockHistory.EXPECT().Save(gomock.Any()).Do(func(record *timing.Record) {
+			savedRecord = record
+		}).AnyTimes()
 		stopCmd = &cmd.StopCmd{
 			VBox:      mockVBox,
 			VMBuilder: mockVMBuilder,
 			Config: &config.Config{
 				DefaultVMName: "some-default-vm-name",
+				Version: &config.Version{
+					BuildVersion:    "some-plugin-version",
+					OVABuildVersion: "some-ova-version",
+				},
 			},
+			UI:      mockUI,
+			Tracer:  &timing.Tracer{},
+			History: mockHistory,
 		}
 	})
 
@@ -56,6 +73,70 @@ var _ = Describe("StopCmd", func() {
 		})
 	})
 	Describe("Run", func() {
+		It("should save the timings of the stop to the history", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+				mockVM.EXPECT().Stop(),
+			)
+
+			Expect(stopCmd.Run()).To(Succeed())
+			Expect(savedRecord.Command).To(Equal("stop"))
+			Expect(savedRecord.PluginVersion).To(Equal("some-plugin-version"))
+			Expect(savedRecord.OVAVersion).To(Equal("some-ova-version"))
+			Expect(savedRecord.Succeeded).To(BeTrue())
+			Expect(savedRecord.Phases).To(HaveLen(1))
+			Expect(savedRecord.Phases[0].Name).To(Equal("stop"))
+		})
+
+		Context("when --timings is passed", func() {
+			It("should print the timings", func() {
+				stopCmd.Tracer.Now = func() time.Time { return time.Time{} }
+				Expect(stopCmd.Parse([]string{"--timings"})).To(Succeed())
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Stop(),
+					mockUI.EXPECT().Say("Timings:"),
+					mockUI.EXPECT().Say("  %s", "stop  0s"),
+				)
+
+				Expect(stopCmd.Run()).To(Succeed())
+			})
+		})
+
+		Context("when the stop fails", func() {
+			It("should save the timings as unsuccessful and return the error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Stop().Return(errors.New("some-error")),
+				)
+
+				Expect(stopCmd.Run()).To(MatchError("some-error"))
+				Expect(savedRecord.Succeeded).To(BeFalse())
+			})
+		})
+
+		Context("when saving the timings fails", func() {
+			It("should say a message and still succeed", func() {
+				failingHistory := mocks.NewMockHistory(mockCtrl)
+				stopCmd.History = failingHistory
+				stopCmd.Config.HistoryPath = "some-history-path"
+
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Stop(),
+					failingHistory.EXPECT().Save(gomock.Any()).Return(errors.New("some-error")),
+					mockUI.EXPECT().Say("Failed to save timings to %s: %s", "some-history-path", errors.New("some-error")),
+				)
+
+				Expect(stopCmd.Run()).To(Succeed())
+			})
+		})
+
 		Context("when the default vm is present", func() {
 			It("should stop the VM", func() {
 				gomock.InOrder(
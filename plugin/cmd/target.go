@@ -13,18 +13,37 @@ type TargetCmd struct {
 	VBox       VBox
 	Config     *config.Config
 	AutoTarget bool
+	user       string
+	org        string
+	space      string
 }
 
 func (t *TargetCmd) Parse(args []string) error {
-	return parse(flags.New(), args, TARGET_ARGS)
+	flagContext := flags.New()
+	flagContext.NewStringFlag("user", "", "<user>")
+	flagContext.NewStringFlag("org", "", "<org>")
+	flagContext.NewStringFlag("space", "", "<space>")
+	if err := parse(flagContext, args, TARGET_ARGS); err != nil {
+		return err
+	}
+
+	t.user = flagContext.String("user")
+	t.org = flagContext.String("org")
+	t.space = flagContext.String("space")
+	return nil
 }
 
 func (t *TargetCmd) Run() error {
-	vm, err := t.getVM()
+	v, err := t.getVM()
 	if err != nil {
 		return err
 	}
-	return vm.Target(t.AutoTarget)
+	return v.Target(&vm.TargetOpts{
+		AutoTarget: t.AutoTarget,
+		User:       t.user,
+		Org:        t.org,
+		Space:      t.space,
+	})
 }
 
 func (t *TargetCmd) getVM() (vm vm.VM, err error) {
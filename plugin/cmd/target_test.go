@@ -9,6 +9,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd"
 	"github.com/pivotal-cf/pcfdev-cli/plugin/cmd/mocks"
+	"github.com/pivotal-cf/pcfdev-cli/vm"
 	vmMocks "github.com/pivotal-cf/pcfdev-cli/vm/mocks"
 )
 
@@ -62,19 +63,32 @@ var _ = Describe("TargetCmd", func() {
 			gomock.InOrder(
 				mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 				mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
-				mockVM.EXPECT().Target(false),
+				mockVM.EXPECT().Target(&vm.TargetOpts{}),
 			)
 
 			Expect(targetCmd.Run()).To(Succeed())
 		})
 
+		Context("when a user, org and space are passed", func() {
+			It("should pass them to Target", func() {
+				Expect(targetCmd.Parse([]string{"--user", "some-user", "--org", "some-org", "--space", "some-space"})).To(Succeed())
+				gomock.InOrder(
+					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
+					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
+					mockVM.EXPECT().Target(&vm.TargetOpts{User: "some-user", Org: "some-org", Space: "some-space"}),
+				)
+
+				Expect(targetCmd.Run()).To(Succeed())
+			})
+		})
+
 		Context("when the VM is automatically targeted", func() {
 			It("should pass the autoTarget flag to Target", func() {
 				targetCmd.AutoTarget = true
 				gomock.InOrder(
 					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
-					mockVM.EXPECT().Target(true),
+					mockVM.EXPECT().Target(&vm.TargetOpts{AutoTarget: true}),
 				)
 
 				Expect(targetCmd.Run()).To(Succeed())
@@ -105,7 +119,7 @@ var _ = Describe("TargetCmd", func() {
 				gomock.InOrder(
 					mockVBox.EXPECT().GetVMName().Return("some-default-vm-name", nil),
 					mockVMBuilder.EXPECT().VM("some-default-vm-name").Return(mockVM, nil),
-					mockVM.EXPECT().Target(false).Return(errors.New("some-error")),
+					mockVM.EXPECT().Target(&vm.TargetOpts{}).Return(errors.New("some-error")),
 				)
 
 				Expect(targetCmd.Run()).To(MatchError("some-error"))
@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
+)
+
+func runTimed(tracer *timing.Tracer, history History, ui UI, conf *config.Config, command string, printTimings bool, run func() error) error {
+	err := tracer.Trace(command, run)
+
+	record := tracer.Record(command, err == nil)
+	record.PluginVersion = conf.Version.BuildVersion
+	record.OVAVersion = conf.Version.OVABuildVersion
+	if saveErr := history.Save(record); saveErr != nil {
+		ui.Say("Failed to save timings to %s: %s", conf.HistoryPath, saveErr)
+	}
+
+	if printTimings {
+		ui.Say("Timings:")
+		for _, line := range record.Summary() {
+			ui.Say("  %s", line)
+		}
+	}
+	return err
+}
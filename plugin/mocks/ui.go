@@ -38,6 +38,16 @@ func (_mr *_MockUIRecorder) Ask(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Ask", arg0)
 }
 
+func (_m *MockUI) AskForPassword(_param0 string) string {
+	ret := _m.ctrl.Call(_m, "AskForPassword", _param0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+func (_mr *_MockUIRecorder) AskForPassword(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AskForPassword", arg0)
+}
+
 func (_m *MockUI) Failed(_param0 string, _param1 ...interface{}) {
 	_s := []interface{}{_param0}
 	for _, _x := range _param1 {
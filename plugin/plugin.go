@@ -22,6 +22,7 @@ type UI interface {
 	Failed(message string, args ...interface{})
 	Say(message string, args ...interface{})
 	Ask(prompt string) (answer string)
+	AskForPassword(prompt string) (answer string)
 }
 
 //go:generate mockgen -package mocks -destination mocks/cmd_builder.go github.com/pivotal-cf/pcfdev-cli/plugin CmdBuilder
@@ -112,7 +113,8 @@ func (p *Plugin) GetMetadata() cfplugin.PluginMetadata {
 SUBCOMMANDS:
    start                             Start the PCF Dev VM. When creating a VM, http proxy env vars are respected.
       [-c number-of-cores]           Number of processor cores used by VM. Default: number of physical cores.
-      [-d domain]                    Specify the domain that the PCF Dev VM will occupy.
+      [-d domain]                    Specify the domain that the PCF Dev VM will occupy. Domains other than local.pcfdev.io
+                                        through local9.pcfdev.io must be listed in $PCFDEV_HOME/addresses.json.
       [-i ip-address]                Specify the IP Address that the PCF Dev VM will occupy.
       [-k]                           Import VM certificates into host's trusted certificate store.
       [-m memory-in-mb]              Memory to allocate for VM. Default: half of total memory, max 4 GB, max 8 GB with SCS.
@@ -122,14 +124,68 @@ SUBCOMMANDS:
                                         Default: redis, rabbitmq
                                         (MySQL is always available and cannot be disabled.)
       [-t]                           Perform a CF login to PCF Dev after starting, as the 'user' user.
+      [--seed /path/to/seed.yml]     Create the orgs, spaces, users and quotas described in a seed manifest after starting.
+      [--network bridged[=IFACE]]    Bridge the VM to a host interface so it can be reached from the LAN. The IP address comes
+                                        from DHCP and the domain defaults to its xip.io form. Default: hostonly.
+      [--provision-timeout 30m]      Give up on provisioning after this long. The full provision output is saved to
+                                        $PCFDEV_HOME/vms/VM-NAME/provision.log. Default: 15m.
+      [--timings]                    Print how long each phase took. Timings are always appended to $PCFDEV_HOME/history.jsonl.
    stop                              Shutdown the PCF Dev VM. All data is preserved.
+      [--timings]                    Print how long each phase took.
    suspend                           Save the current state of the PCF Dev VM to disk and then stop the VM.
    resume                            Resume PCF Dev VM from suspended state.
+      [--timings]                    Print how long each phase took.
    destroy                           Delete the PCF Dev VM. All data is destroyed.
    status                            Query for the status of the PCF Dev VM.
    import /path/to/ova               Import OVA from local filesystem.
    ssh                               Start an SSH session into a running PCF Dev VM.
+   logs                              Print the last lines of the provision and reset logs, prefixed with their source.
+      [--follow]                     Keep printing new lines until interrupted with Ctrl-C.
+      [--components cc,router,...]   Print the logs of these Cloud Foundry components instead.
+                                        Options: cc, diego, loggregator, router, uaa, all
+                                        --component is accepted as well, e.g. --component router.
+      [--scrub]                      Redact IPs, emails, URIs, secrets and certificates before printing.
+   share add HOSTPATH GUESTPATH      Mount a host directory at GUESTPATH in the PCF Dev VM every time it starts or resumes.
+      [--readonly]                   Mount the directory read-only.
+   share list                        List the directories shared with the PCF Dev VM.
+   share remove GUESTPATH            Stop sharing the directory mounted at GUESTPATH.
+   seed /path/to/seed.yml            Create the orgs, spaces, users, roles, quotas, feature flags and security groups
+                                        described in a seed manifest. Only differences are applied, so it is safe to rerun.
+   backup /path/to/backup.tgz        Export the databases and blobstore of a running PCF Dev VM.
+   restore /path/to/backup.tgz       Import a backup into a running PCF Dev VM with the same OVA version.
+   disk resize SIZE                  Grow the disk of a stopped PCF Dev VM to SIZE (e.g. 80G or 81920M). Disks cannot be shrunk.
+   password rotate                   Replace the master password of a running PCF Dev VM and restart its components.
+                                        The new password is read from PCFDEV_NEW_PASSWORD or prompted for.
+   gc                                Remove PCF Dev host-only interfaces no VM uses, detached PCF Dev disks, stale partial downloads
+                                        and old temp directories after asking for confirmation.
+      [--dry-run]                    List what would be removed without removing anything.
+   config show                       Print the settings stored for the PCF Dev VM as JSON.
+   doctor                            Show the CPUs and memory PCF Dev detects on this host and where each value came from.
+   debug                             Collect VM and host logs into a tarball to attach to bug reports. Logs that cannot be
+                                        collected are recorded in the tarball instead of aborting it.
+      [--output /path/to/debug.tgz]  Write the tarball somewhere else. Default: ./pcfdev-debug.tgz.
+      [--timeout seconds]            Give up on each log after this many seconds. Default: 20.
+      [--components cc,router,...]   Also collect the logs of these Cloud Foundry components from /var/vcap/sys/log.
+                                        Options: cc, diego, loggregator, router, uaa, all
+      [--since 1h]                   Only collect component logs written within this duration.
+      [--max-size 50M]               Keep at most this much of the newest logs per component. Default: 10M.
+      [--pseudonymize]               Replace each distinct IP, email and URI with a stable pseudonym such as <email-1>.
+                                        Secrets, private keys and certificates are still redacted outright.
+                                        The pseudonyms are mapped back in a file written next to the tarball, not inside it.
+                                        Extra patterns and an allowlist are read from $PCFDEV_HOME/scrub.json.
+   network check                     Explain which host-only subnets PCF Dev can use and why the others are rejected.
+   dns start                         Answer DNS queries for the PCF Dev domain with the VM IP, so PCF Dev works without xip.io.
+      [--address 127.0.0.1:5354]     Listen on a different address.
+      [--upstream 10.0.0.2:53]       Forward other queries to this nameserver. Default: the first non-loopback nameserver
+                                        in /etc/resolv.conf or /run/systemd/resolve/resolv.conf.
+   dns configure                     Send queries for the PCF Dev domain to 'cf dev dns start' using systemd-resolved
+      [--address 127.0.0.1:5354]        or NetworkManager (Linux only). systemd-resolved sends them through the host-only
+                                        interface, so both commands need an --address on it, e.g. 192.168.11.1:5354.
+   dns unconfigure                   Remove the split DNS configuration. This is also done by 'cf dev destroy'.
    target                            Perform a CF login to PCF Dev, as the 'user' user.
+      [--user user]                  Log in as a different user. The password is read from PCFDEV_PASSWORD or prompted for.
+      [--org org]                    Target a different org.
+      [--space space]                Target a different space.
    trust                             Import VM certificates into host's trusted certificate store.
       [-p]                           Print the PCF Dev Root CA Certificate to stdout.
    untrust                           Remove VM certificates from host's trusted certificate store.
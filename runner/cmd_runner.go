@@ -1,6 +1,8 @@
 package runner
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -16,3 +18,33 @@ func (c *CmdRunner) Run(command string, args ...string) ([]byte, error) {
 
 	return output, nil
 }
+
+// RunUntil is Run, except that the command is killed once stop is closed.
+func (c *CmdRunner) RunUntil(stop <-chan struct{}, command string, args ...string) ([]byte, error) {
+	var output bytes.Buffer
+	cmd := exec.Command(command, args...)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to execute '%s %s': %s", command, strings.Join(args, " "), err)
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	var err error
+	select {
+	case err = <-exited:
+	case <-stop:
+		cmd.Process.Kill()
+		<-exited
+		err = errors.New("interrupted")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute '%s %s': %s: %s", command, strings.Join(args, " "), err, output.Bytes())
+	}
+
+	return output.Bytes(), nil
+}
@@ -2,6 +2,7 @@ package runner_test
 
 import (
 	"runtime"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -34,4 +35,29 @@ var _ = Describe("CmdRunner", func() {
 			})
 		})
 	})
+
+	Describe("#RunUntil", func() {
+		It("should execute a command and return its output", func() {
+			Expect(runner.RunUntil(make(chan struct{}), "bash", "-c", "echo -n some-output && >&2 echo -n some-other-output")).To(Equal([]byte("some-outputsome-other-output")))
+		})
+
+		Context("when there is an error", func() {
+			It("should return the error with the output and the arguments", func() {
+				_, err := runner.RunUntil(make(chan struct{}), "bash", "-c", "echo -n some-error && exit 1")
+				Expect(err).To(MatchError("failed to execute 'bash -c echo -n some-error && exit 1': exit status 1: some-error"))
+			})
+		})
+
+		Context("when stop is closed", func() {
+			It("should kill the command", func() {
+				stop := make(chan struct{})
+				close(stop)
+
+				start := time.Now()
+				_, err := runner.RunUntil(stop, "sleep", "10")
+				Expect(err).To(MatchError("failed to execute 'sleep 10': interrupted: "))
+				Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second))
+			})
+		})
+	})
 })
@@ -0,0 +1,127 @@
+package seed
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+type Manifest struct {
+	Quotas         []Quota         `yaml:"quotas"`
+	FeatureFlags   map[string]bool `yaml:"feature_flags"`
+	SecurityGroups []SecurityGroup `yaml:"security_groups"`
+	Users          []User          `yaml:"users"`
+	Orgs           []Org           `yaml:"orgs"`
+}
+
+type Quota struct {
+	Name             string `yaml:"name"`
+	Memory           string `yaml:"memory"`
+	Routes           *int   `yaml:"routes"`
+	ServiceInstances *int   `yaml:"service_instances"`
+	PaidServicePlans *bool  `yaml:"paid_service_plans"`
+}
+
+type SecurityGroup struct {
+	Name    string `yaml:"name"`
+	Rules   []Rule `yaml:"rules"`
+	Running bool   `yaml:"running"`
+	Staging bool   `yaml:"staging"`
+}
+
+type Rule struct {
+	Protocol    string `yaml:"protocol" json:"protocol"`
+	Destination string `yaml:"destination" json:"destination"`
+	Ports       string `yaml:"ports" json:"ports,omitempty"`
+	Type        *int   `yaml:"type" json:"type,omitempty"`
+	Code        *int   `yaml:"code" json:"code,omitempty"`
+	Log         bool   `yaml:"log" json:"log,omitempty"`
+	Description string `yaml:"description" json:"description,omitempty"`
+}
+
+type User struct {
+	Name     string `yaml:"name"`
+	Password string `yaml:"password"`
+}
+
+type Org struct {
+	Name            string   `yaml:"name"`
+	Quota           string   `yaml:"quota"`
+	Managers        []string `yaml:"managers"`
+	BillingManagers []string `yaml:"billing_managers"`
+	Auditors        []string `yaml:"auditors"`
+	Spaces          []Space  `yaml:"spaces"`
+}
+
+type Space struct {
+	Name       string   `yaml:"name"`
+	Managers   []string `yaml:"managers"`
+	Developers []string `yaml:"developers"`
+	Auditors   []string `yaml:"auditors"`
+}
+
+func ParseManifest(contents []byte) (*Manifest, error) {
+	manifest := &Manifest{}
+	if err := yaml.Unmarshal(contents, manifest); err != nil {
+		return nil, err
+	}
+
+	for _, quota := range manifest.Quotas {
+		if quota.Name == "" {
+			return nil, errors.New("quota is missing a name")
+		}
+		if quota.Memory != "" {
+			if _, err := memoryInMB(quota.Memory); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, group := range manifest.SecurityGroups {
+		if group.Name == "" {
+			return nil, errors.New("security group is missing a name")
+		}
+	}
+	for _, user := range manifest.Users {
+		if user.Name == "" {
+			return nil, errors.New("user is missing a name")
+		}
+		if strings.TrimSpace(user.Password) == "" {
+			return nil, fmt.Errorf("user %s is missing a password", user.Name)
+		}
+	}
+	for _, org := range manifest.Orgs {
+		if org.Name == "" {
+			return nil, errors.New("org is missing a name")
+		}
+		for _, space := range org.Spaces {
+			if space.Name == "" {
+				return nil, fmt.Errorf("space in org %s is missing a name", org.Name)
+			}
+		}
+	}
+
+	return manifest, nil
+}
+
+var memoryRegex = regexp.MustCompile(`^(\d+)\s*(M|MB|G|GB)$`)
+
+func memoryInMB(memory string) (int, error) {
+	matches := memoryRegex.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(memory)))
+	if matches == nil {
+		return 0, fmt.Errorf("invalid memory %s: must be a number followed by M or G", memory)
+	}
+
+	size, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, err
+	}
+
+	if strings.HasPrefix(matches[2], "G") {
+		size *= 1024
+	}
+	return size, nil
+}
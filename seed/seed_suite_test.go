@@ -0,0 +1,13 @@
+package seed_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestSeed(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PCF Dev Seed Suite")
+}
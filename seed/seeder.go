@@ -0,0 +1,457 @@
+package seed
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+)
+
+//go:generate mockgen -package mocks -destination mocks/cmd_runner.go github.com/pivotal-cf/pcfdev-cli/seed CmdRunner
+type CmdRunner interface {
+	Run(command string, args ...string) (output []byte, err error)
+}
+
+//go:generate mockgen -package mocks -destination mocks/ui.go github.com/pivotal-cf/pcfdev-cli/seed UI
+type UI interface {
+	Say(message string, args ...interface{})
+}
+
+type Seeder struct {
+	CmdRunner CmdRunner
+	UI        UI
+}
+
+type resource struct {
+	Metadata struct {
+		GUID string `json:"guid"`
+	} `json:"metadata"`
+	Entity json.RawMessage `json:"entity"`
+}
+
+type role struct {
+	path  string
+	name  string
+	users []string
+}
+
+func (s *Seeder) Seed(contents []byte) error {
+	manifest, err := ParseManifest(contents)
+	if err != nil {
+		return err
+	}
+
+	for _, quota := range manifest.Quotas {
+		if err := s.seedQuota(quota); err != nil {
+			return err
+		}
+	}
+
+	if err := s.seedFeatureFlags(manifest.FeatureFlags); err != nil {
+		return err
+	}
+
+	for _, group := range manifest.SecurityGroups {
+		if err := s.seedSecurityGroup(group); err != nil {
+			return err
+		}
+	}
+
+	if err := s.seedUsers(manifest.Users); err != nil {
+		return err
+	}
+
+	for _, org := range manifest.Orgs {
+		if err := s.seedOrg(org); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Seeder) seedQuota(quota Quota) error {
+	existing, err := s.find("/v2/quota_definitions?q=name:" + url.QueryEscape(quota.Name))
+	if err != nil {
+		return err
+	}
+
+	var current struct {
+		MemoryLimit             int  `json:"memory_limit"`
+		TotalRoutes             int  `json:"total_routes"`
+		TotalServices           int  `json:"total_services"`
+		NonBasicServicesAllowed bool `json:"non_basic_services_allowed"`
+	}
+	if existing != nil {
+		if err := json.Unmarshal(existing.Entity, &current); err != nil {
+			return err
+		}
+	}
+
+	args := []string{}
+	if quota.Memory != "" {
+		memory, err := memoryInMB(quota.Memory)
+		if err != nil {
+			return err
+		}
+		if existing == nil || memory != current.MemoryLimit {
+			args = append(args, "-m", fmt.Sprintf("%dM", memory))
+		}
+	}
+	if quota.Routes != nil && (existing == nil || *quota.Routes != current.TotalRoutes) {
+		args = append(args, "-r", fmt.Sprintf("%d", *quota.Routes))
+	}
+	if quota.ServiceInstances != nil && (existing == nil || *quota.ServiceInstances != current.TotalServices) {
+		args = append(args, "-s", fmt.Sprintf("%d", *quota.ServiceInstances))
+	}
+	if quota.PaidServicePlans != nil && (existing == nil || *quota.PaidServicePlans != current.NonBasicServicesAllowed) {
+		if *quota.PaidServicePlans {
+			args = append(args, "--allow-paid-service-plans")
+		} else if existing != nil {
+			args = append(args, "--disallow-paid-service-plans")
+		}
+	}
+
+	if existing == nil {
+		return s.cf(fmt.Sprintf("Creating quota %s...", quota.Name), append([]string{"create-quota", quota.Name}, args...)...)
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return s.cf(fmt.Sprintf("Updating quota %s...", quota.Name), append([]string{"update-quota", quota.Name}, args...)...)
+}
+
+func (s *Seeder) seedFeatureFlags(featureFlags map[string]bool) error {
+	if len(featureFlags) == 0 {
+		return nil
+	}
+
+	var current []struct {
+		Name    string `json:"name"`
+		Enabled bool   `json:"enabled"`
+	}
+	if err := s.curl("/v2/config/feature_flags", &current); err != nil {
+		return err
+	}
+
+	enabled := map[string]bool{}
+	for _, flag := range current {
+		enabled[flag.Name] = flag.Enabled
+	}
+
+	names := []string{}
+	for name := range featureFlags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		currentlyEnabled, ok := enabled[name]
+		if !ok {
+			return fmt.Errorf("unknown feature flag %s", name)
+		}
+
+		if featureFlags[name] && !currentlyEnabled {
+			if err := s.cf(fmt.Sprintf("Enabling feature flag %s...", name), "enable-feature-flag", name); err != nil {
+				return err
+			}
+		} else if !featureFlags[name] && currentlyEnabled {
+			if err := s.cf(fmt.Sprintf("Disabling feature flag %s...", name), "disable-feature-flag", name); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Seeder) seedSecurityGroup(group SecurityGroup) error {
+	path := "/v2/security_groups?q=name:" + url.QueryEscape(group.Name)
+	existing, err := s.find(path)
+	if err != nil {
+		return err
+	}
+
+	rules := group.Rules
+	if rules == nil {
+		rules = []Rule{}
+	}
+
+	var current struct {
+		Rules          []Rule `json:"rules"`
+		RunningDefault bool   `json:"running_default"`
+		StagingDefault bool   `json:"staging_default"`
+	}
+
+	if existing == nil {
+		body, err := json.Marshal(map[string]interface{}{"name": group.Name, "rules": rules})
+		if err != nil {
+			return err
+		}
+		s.UI.Say(fmt.Sprintf("Creating security group %s...", group.Name))
+		if err := s.curl("/v2/security_groups", nil, "-X", "POST", "-d", string(body)); err != nil {
+			return err
+		}
+	} else {
+		if err := json.Unmarshal(existing.Entity, &current); err != nil {
+			return err
+		}
+		if current.Rules == nil {
+			current.Rules = []Rule{}
+		}
+
+		if !reflect.DeepEqual(current.Rules, rules) {
+			body, err := json.Marshal(map[string]interface{}{"rules": rules})
+			if err != nil {
+				return err
+			}
+			s.UI.Say(fmt.Sprintf("Updating security group %s...", group.Name))
+			if err := s.curl("/v2/security_groups/"+existing.Metadata.GUID, nil, "-X", "PUT", "-d", string(body)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if group.Running != current.RunningDefault {
+		command := "bind-running-security-group"
+		if !group.Running {
+			command = "unbind-running-security-group"
+		}
+		if err := s.cf(fmt.Sprintf("Setting running default of security group %s to %t...", group.Name, group.Running), command, group.Name); err != nil {
+			return err
+		}
+	}
+
+	if group.Staging != current.StagingDefault {
+		command := "bind-staging-security-group"
+		if !group.Staging {
+			command = "unbind-staging-security-group"
+		}
+		if err := s.cf(fmt.Sprintf("Setting staging default of security group %s to %t...", group.Name, group.Staging), command, group.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Seeder) seedUsers(users []User) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	existing, err := s.usernames("/v2/users")
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		if existing[user.Name] {
+			continue
+		}
+		s.UI.Say(fmt.Sprintf("Creating user %s...", user.Name))
+		if _, err := s.CmdRunner.Run("cf", "create-user", user.Name, user.Password); err != nil {
+			return fmt.Errorf("failed to create user %s", user.Name)
+		}
+	}
+
+	return nil
+}
+
+func (s *Seeder) seedOrg(org Org) error {
+	path := "/v2/organizations?q=name:" + url.QueryEscape(org.Name)
+	existing, err := s.find(path)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		args := []string{"create-org", org.Name}
+		if org.Quota != "" {
+			args = append(args, "-q", org.Quota)
+		}
+		if err := s.cf(fmt.Sprintf("Creating org %s...", org.Name), args...); err != nil {
+			return err
+		}
+		if existing, err = s.findCreated(path, "org", org.Name); err != nil {
+			return err
+		}
+	} else if org.Quota != "" {
+		if err := s.seedOrgQuota(org, existing); err != nil {
+			return err
+		}
+	}
+
+	if err := s.seedRoles(org.Name, "/v2/organizations/"+existing.Metadata.GUID, []role{
+		{path: "managers", name: "OrgManager", users: org.Managers},
+		{path: "billing_managers", name: "BillingManager", users: org.BillingManagers},
+		{path: "auditors", name: "OrgAuditor", users: org.Auditors},
+	}, func(user string, role string) []string {
+		return []string{"set-org-role", user, org.Name, role}
+	}); err != nil {
+		return err
+	}
+
+	for _, space := range org.Spaces {
+		if err := s.seedSpace(org, existing.Metadata.GUID, space); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Seeder) seedOrgQuota(org Org, existing *resource) error {
+	quota, err := s.find("/v2/quota_definitions?q=name:" + url.QueryEscape(org.Quota))
+	if err != nil {
+		return err
+	}
+	if quota == nil {
+		return fmt.Errorf("quota %s does not exist", org.Quota)
+	}
+
+	var current struct {
+		QuotaDefinitionGUID string `json:"quota_definition_guid"`
+	}
+	if err := json.Unmarshal(existing.Entity, &current); err != nil {
+		return err
+	}
+
+	if current.QuotaDefinitionGUID == quota.Metadata.GUID {
+		return nil
+	}
+	return s.cf(fmt.Sprintf("Setting quota of org %s to %s...", org.Name, org.Quota), "set-quota", org.Name, org.Quota)
+}
+
+func (s *Seeder) seedSpace(org Org, orgGUID string, space Space) error {
+	path := fmt.Sprintf("/v2/organizations/%s/spaces?q=name:%s", orgGUID, url.QueryEscape(space.Name))
+	existing, err := s.find(path)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		if err := s.cf(fmt.Sprintf("Creating space %s in org %s...", space.Name, org.Name), "create-space", space.Name, "-o", org.Name); err != nil {
+			return err
+		}
+		if existing, err = s.findCreated(path, "space", space.Name); err != nil {
+			return err
+		}
+	}
+
+	return s.seedRoles(fmt.Sprintf("%s/%s", org.Name, space.Name), "/v2/spaces/"+existing.Metadata.GUID, []role{
+		{path: "managers", name: "SpaceManager", users: space.Managers},
+		{path: "developers", name: "SpaceDeveloper", users: space.Developers},
+		{path: "auditors", name: "SpaceAuditor", users: space.Auditors},
+	}, func(user string, role string) []string {
+		return []string{"set-space-role", user, org.Name, space.Name, role}
+	})
+}
+
+func (s *Seeder) seedRoles(target string, path string, roles []role, args func(user string, role string) []string) error {
+	for _, role := range roles {
+		if len(role.users) == 0 {
+			continue
+		}
+
+		existing, err := s.usernames(path + "/" + role.path)
+		if err != nil {
+			return err
+		}
+
+		for _, user := range role.users {
+			if existing[user] {
+				continue
+			}
+			if err := s.cf(fmt.Sprintf("Assigning role %s in %s to %s...", role.name, target, user), args(user, role.name)...); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Seeder) usernames(path string) (map[string]bool, error) {
+	resources, err := s.list(path)
+	if err != nil {
+		return nil, err
+	}
+
+	usernames := map[string]bool{}
+	for _, resource := range resources {
+		var user struct {
+			Username string `json:"username"`
+		}
+		if err := json.Unmarshal(resource.Entity, &user); err != nil {
+			return nil, err
+		}
+		usernames[user.Username] = true
+	}
+	return usernames, nil
+}
+
+func (s *Seeder) findCreated(path string, kind string, name string) (*resource, error) {
+	created, err := s.find(path)
+	if err != nil {
+		return nil, err
+	}
+	if created == nil {
+		return nil, fmt.Errorf("%s %s was not found after creating it", kind, name)
+	}
+	return created, nil
+}
+
+func (s *Seeder) find(path string) (*resource, error) {
+	resources, err := s.list(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(resources) == 0 {
+		return nil, nil
+	}
+	return &resources[0], nil
+}
+
+func (s *Seeder) list(path string) ([]resource, error) {
+	resources := []resource{}
+	for path != "" {
+		var page struct {
+			NextURL   string     `json:"next_url"`
+			Resources []resource `json:"resources"`
+		}
+		if err := s.curl(path, &page); err != nil {
+			return nil, err
+		}
+		resources = append(resources, page.Resources...)
+		path = page.NextURL
+	}
+	return resources, nil
+}
+
+func (s *Seeder) curl(path string, response interface{}, args ...string) error {
+	output, err := s.CmdRunner.Run("cf", append([]string{"curl", path}, args...)...)
+	if err != nil {
+		return err
+	}
+
+	var ccError struct {
+		ErrorCode   string `json:"error_code"`
+		Description string `json:"description"`
+	}
+	if json.Unmarshal(output, &ccError) == nil && ccError.ErrorCode != "" {
+		return fmt.Errorf("request to %s failed: %s: %s", path, ccError.ErrorCode, ccError.Description)
+	}
+
+	if response == nil {
+		return nil
+	}
+	return json.Unmarshal(output, response)
+}
+
+func (s *Seeder) cf(message string, args ...string) error {
+	s.UI.Say(message)
+	_, err := s.CmdRunner.Run("cf", args...)
+	return err
+}
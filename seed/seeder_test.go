@@ -0,0 +1,290 @@
+package seed_test
+
+import (
+	"errors"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/seed"
+	"github.com/pivotal-cf/pcfdev-cli/seed/mocks"
+)
+
+var _ = Describe("Seeder", func() {
+	var (
+		mockCtrl      *gomock.Controller
+		mockCmdRunner *mocks.MockCmdRunner
+		mockUI        *mocks.MockUI
+		seeder        *seed.Seeder
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCmdRunner = mocks.NewMockCmdRunner(mockCtrl)
+		mockUI = mocks.NewMockUI(mockCtrl)
+		seeder = &seed.Seeder{
+			CmdRunner: mockCmdRunner,
+			UI:        mockUI,
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#Seed", func() {
+		Context("when the manifest describes quotas", func() {
+			manifest := []byte(`
+quotas:
+- name: some-quota
+  memory: 10G
+  routes: 100
+  service_instances: 10
+  paid_service_plans: true
+`)
+
+			It("should create a missing quota", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/quota_definitions?q=name:some-quota").Return([]byte(`{"next_url":null,"resources":[]}`), nil),
+					mockUI.EXPECT().Say("Creating quota some-quota..."),
+					mockCmdRunner.EXPECT().Run("cf", "create-quota", "some-quota", "-m", "10240M", "-r", "100", "-s", "10", "--allow-paid-service-plans"),
+				)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+
+			It("should only update the settings of an existing quota that differ", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/quota_definitions?q=name:some-quota").Return([]byte(`{
+						"next_url": null,
+						"resources": [{
+							"metadata": {"guid": "some-quota-guid"},
+							"entity": {"memory_limit": 10240, "total_routes": 50, "total_services": 10, "non_basic_services_allowed": true}
+						}]
+					}`), nil),
+					mockUI.EXPECT().Say("Updating quota some-quota..."),
+					mockCmdRunner.EXPECT().Run("cf", "update-quota", "some-quota", "-r", "100"),
+				)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+
+			It("should not change a quota that matches the manifest", func() {
+				mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/quota_definitions?q=name:some-quota").Return([]byte(`{
+					"next_url": null,
+					"resources": [{
+						"metadata": {"guid": "some-quota-guid"},
+						"entity": {"memory_limit": 10240, "total_routes": 100, "total_services": 10, "non_basic_services_allowed": true}
+					}]
+				}`), nil)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+		})
+
+		Context("when the manifest describes feature flags", func() {
+			manifest := []byte(`
+feature_flags:
+  diego_docker: true
+  user_org_creation: false
+  task_creation: true
+`)
+
+			It("should only toggle the feature flags that differ", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/config/feature_flags").Return([]byte(`[
+						{"name": "diego_docker", "enabled": false},
+						{"name": "task_creation", "enabled": true},
+						{"name": "user_org_creation", "enabled": true}
+					]`), nil),
+					mockUI.EXPECT().Say("Enabling feature flag diego_docker..."),
+					mockCmdRunner.EXPECT().Run("cf", "enable-feature-flag", "diego_docker"),
+					mockUI.EXPECT().Say("Disabling feature flag user_org_creation..."),
+					mockCmdRunner.EXPECT().Run("cf", "disable-feature-flag", "user_org_creation"),
+				)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+
+			Context("when a feature flag does not exist", func() {
+				It("should return an error", func() {
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/config/feature_flags").Return([]byte(`[
+						{"name": "diego_docker", "enabled": true},
+						{"name": "user_org_creation", "enabled": false}
+					]`), nil)
+
+					Expect(seeder.Seed(manifest)).To(MatchError("unknown feature flag task_creation"))
+				})
+			})
+		})
+
+		Context("when the manifest describes security groups", func() {
+			manifest := []byte(`
+security_groups:
+- name: some-group
+  running: true
+  rules:
+  - protocol: tcp
+    destination: 10.0.0.0/8
+    ports: "443"
+`)
+
+			It("should create a missing security group and bind it", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/security_groups?q=name:some-group").Return([]byte(`{"next_url":null,"resources":[]}`), nil),
+					mockUI.EXPECT().Say("Creating security group some-group..."),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/security_groups", "-X", "POST", "-d", `{"name":"some-group","rules":[{"protocol":"tcp","destination":"10.0.0.0/8","ports":"443"}]}`).Return([]byte(`{}`), nil),
+					mockUI.EXPECT().Say("Setting running default of security group some-group to true..."),
+					mockCmdRunner.EXPECT().Run("cf", "bind-running-security-group", "some-group"),
+				)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+
+			It("should update the rules and defaults of an existing security group that differ", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/security_groups?q=name:some-group").Return([]byte(`{
+						"next_url": null,
+						"resources": [{
+							"metadata": {"guid": "some-group-guid"},
+							"entity": {"rules": [{"protocol": "all", "destination": "0.0.0.0/0"}], "running_default": true, "staging_default": true}
+						}]
+					}`), nil),
+					mockUI.EXPECT().Say("Updating security group some-group..."),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/security_groups/some-group-guid", "-X", "PUT", "-d", `{"rules":[{"protocol":"tcp","destination":"10.0.0.0/8","ports":"443"}]}`).Return([]byte(`{}`), nil),
+					mockUI.EXPECT().Say("Setting staging default of security group some-group to false..."),
+					mockCmdRunner.EXPECT().Run("cf", "unbind-staging-security-group", "some-group"),
+				)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+		})
+
+		Context("when the manifest describes users", func() {
+			manifest := []byte(`
+users:
+- name: some-existing-user
+  password: some-password
+- name: some-new-user
+  password: some-other-password
+`)
+
+			It("should create the missing users", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/users").Return([]byte(`{
+						"next_url": "/v2/users?page=2",
+						"resources": [{"metadata": {"guid": "some-guid"}, "entity": {"username": "admin"}}]
+					}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/users?page=2").Return([]byte(`{
+						"next_url": null,
+						"resources": [{"metadata": {"guid": "some-other-guid"}, "entity": {"username": "some-existing-user"}}]
+					}`), nil),
+					mockUI.EXPECT().Say("Creating user some-new-user..."),
+					mockCmdRunner.EXPECT().Run("cf", "create-user", "some-new-user", "some-other-password"),
+				)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+
+			Context("when creating a user fails", func() {
+				It("should return an error without the password", func() {
+					gomock.InOrder(
+						mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/users").Return([]byte(`{"next_url":null,"resources":[]}`), nil),
+						mockUI.EXPECT().Say("Creating user some-existing-user..."),
+						mockCmdRunner.EXPECT().Run("cf", "create-user", "some-existing-user", "some-password").Return(nil, errors.New("some-error: some-password")),
+					)
+
+					Expect(seeder.Seed(manifest)).To(MatchError("failed to create user some-existing-user"))
+				})
+			})
+		})
+
+		Context("when the manifest describes orgs and spaces", func() {
+			manifest := []byte(`
+orgs:
+- name: some-org
+  quota: some-quota
+  managers: [some-user]
+  spaces:
+  - name: some-space
+    developers: [some-user, some-other-user]
+`)
+
+			It("should create the missing org, space and roles", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations?q=name:some-org").Return([]byte(`{"next_url":null,"resources":[]}`), nil),
+					mockUI.EXPECT().Say("Creating org some-org..."),
+					mockCmdRunner.EXPECT().Run("cf", "create-org", "some-org", "-q", "some-quota"),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations?q=name:some-org").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-org-guid"},"entity":{}}]}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations/some-org-guid/managers").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-guid"},"entity":{"username":"admin"}}]}`), nil),
+					mockUI.EXPECT().Say("Assigning role OrgManager in some-org to some-user..."),
+					mockCmdRunner.EXPECT().Run("cf", "set-org-role", "some-user", "some-org", "OrgManager"),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations/some-org-guid/spaces?q=name:some-space").Return([]byte(`{"next_url":null,"resources":[]}`), nil),
+					mockUI.EXPECT().Say("Creating space some-space in org some-org..."),
+					mockCmdRunner.EXPECT().Run("cf", "create-space", "some-space", "-o", "some-org"),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations/some-org-guid/spaces?q=name:some-space").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-space-guid"},"entity":{}}]}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/spaces/some-space-guid/developers").Return([]byte(`{"next_url":null,"resources":[]}`), nil),
+					mockUI.EXPECT().Say("Assigning role SpaceDeveloper in some-org/some-space to some-user..."),
+					mockCmdRunner.EXPECT().Run("cf", "set-space-role", "some-user", "some-org", "some-space", "SpaceDeveloper"),
+					mockUI.EXPECT().Say("Assigning role SpaceDeveloper in some-org/some-space to some-other-user..."),
+					mockCmdRunner.EXPECT().Run("cf", "set-space-role", "some-other-user", "some-org", "some-space", "SpaceDeveloper"),
+				)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+
+			It("should not change an org and space that match the manifest", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations?q=name:some-org").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-org-guid"},"entity":{"quota_definition_guid":"some-quota-guid"}}]}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/quota_definitions?q=name:some-quota").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-quota-guid"},"entity":{}}]}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations/some-org-guid/managers").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-guid"},"entity":{"username":"some-user"}}]}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations/some-org-guid/spaces?q=name:some-space").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-space-guid"},"entity":{}}]}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/spaces/some-space-guid/developers").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-guid"},"entity":{"username":"some-user"}},{"metadata":{"guid":"some-other-guid"},"entity":{"username":"some-other-user"}}]}`), nil),
+				)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+
+			It("should set the quota of an existing org when it differs", func() {
+				gomock.InOrder(
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations?q=name:some-org").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-org-guid"},"entity":{"quota_definition_guid":"some-default-quota-guid"}}]}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/quota_definitions?q=name:some-quota").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-quota-guid"},"entity":{}}]}`), nil),
+					mockUI.EXPECT().Say("Setting quota of org some-org to some-quota..."),
+					mockCmdRunner.EXPECT().Run("cf", "set-quota", "some-org", "some-quota"),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations/some-org-guid/managers").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-guid"},"entity":{"username":"some-user"}}]}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations/some-org-guid/spaces?q=name:some-space").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-space-guid"},"entity":{}}]}`), nil),
+					mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/spaces/some-space-guid/developers").Return([]byte(`{"next_url":null,"resources":[{"metadata":{"guid":"some-guid"},"entity":{"username":"some-user"}},{"metadata":{"guid":"some-other-guid"},"entity":{"username":"some-other-user"}}]}`), nil),
+				)
+
+				Expect(seeder.Seed(manifest)).To(Succeed())
+			})
+		})
+
+		Context("when the cloud controller returns an error", func() {
+			It("should return the error", func() {
+				mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations?q=name:some-org").Return([]byte(`{"code":10002,"description":"Authentication error","error_code":"CF-NotAuthenticated"}`), nil)
+
+				Expect(seeder.Seed([]byte("orgs: [{name: some-org}]"))).To(MatchError("request to /v2/organizations?q=name:some-org failed: CF-NotAuthenticated: Authentication error"))
+			})
+		})
+
+		Context("when running cf fails", func() {
+			It("should return the error", func() {
+				mockCmdRunner.EXPECT().Run("cf", "curl", "/v2/organizations?q=name:some-org").Return(nil, errors.New("some-error"))
+
+				Expect(seeder.Seed([]byte("orgs: [{name: some-org}]"))).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the manifest is invalid", func() {
+			It("should return an error", func() {
+				Expect(seeder.Seed([]byte("orgs: [{}]"))).To(MatchError("org is missing a name"))
+				Expect(seeder.Seed([]byte("users: [{password: some-password}]"))).To(MatchError("user is missing a name"))
+				Expect(seeder.Seed([]byte("users: [{name: some-user}]"))).To(MatchError("user some-user is missing a password"))
+				Expect(seeder.Seed([]byte(`users: [{name: some-user, password: "  "}]`))).To(MatchError("user some-user is missing a password"))
+				Expect(seeder.Seed([]byte("quotas: [{name: some-quota, memory: lots}]"))).To(MatchError("invalid memory lots: must be a number followed by M or G"))
+				Expect(seeder.Seed([]byte("orgs: some-org"))).NotTo(Succeed())
+			})
+		})
+	})
+})
@@ -6,6 +6,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -14,12 +15,17 @@ import (
 
 	"github.com/docker/docker/pkg/term"
 	. "github.com/pivotal-cf/pcfdev-cli/helpers"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	"golang.org/x/crypto/ssh"
 )
 
+// ErrInterrupted is returned by RunSSHCommandUntil when the command was stopped before it exited.
+var ErrInterrupted = errors.New("interrupted")
+
 type SSH struct {
 	Terminal      Terminal
 	WindowResizer WindowResizer
+	Tracer        *timing.Tracer
 }
 
 //go:generate mockgen -package mocks -destination mocks/terminal.go github.com/pivotal-cf/pcfdev-cli/ssh Terminal
@@ -59,6 +65,10 @@ func (s *SSH) GetSSHOutput(command string, addresses []SSHAddress, privateKey []
 }
 
 func (s *SSH) RunSSHCommand(command string, addresses []SSHAddress, privateKey []byte, timeout time.Duration, stdout io.Writer, stderr io.Writer) (err error) {
+	return s.RunSSHCommandWithStdin(command, addresses, privateKey, timeout, nil, stdout, stderr)
+}
+
+func (s *SSH) RunSSHCommandWithStdin(command string, addresses []SSHAddress, privateKey []byte, timeout time.Duration, stdin io.Reader, stdout io.Writer, stderr io.Writer) (err error) {
 	client, session, err := s.newSession(addresses, privateKey, timeout)
 	if err != nil {
 		return err
@@ -66,12 +76,48 @@ func (s *SSH) RunSSHCommand(command string, addresses []SSHAddress, privateKey [
 	defer client.Close()
 	defer session.Close()
 
+	session.Stdin = stdin
 	session.Stdout = stdout
 	session.Stderr = stderr
 
 	return session.Run(command)
 }
 
+func (s *SSH) RunSSHCommandUntil(command string, addresses []SSHAddress, privateKey []byte, timeout time.Duration, stdout io.Writer, stderr io.Writer, stop <-chan struct{}) error {
+	client, session, err := s.newSession(addresses, privateKey, timeout)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	defer session.Close()
+
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	if err := session.Start(command); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-stop:
+		IgnoreErrorFrom(session.Signal(ssh.SIGINT))
+		IgnoreErrorFrom(client.Close())
+		// A command that exited on its own just as it was stopped still reports its own result.
+		err := <-done
+		if exitErr, exited := err.(*ssh.ExitError); err == nil || exited && exitErr.Signal() == "" {
+			return err
+		}
+		return ErrInterrupted
+	}
+}
+
 func (s *SSH) StartSSHSession(addresses []SSHAddress, privateKey []byte, timeout time.Duration, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
 	client, session, err := s.newSession(addresses, privateKey, timeout)
 	if err != nil {
@@ -207,7 +253,9 @@ func (s *SSH) newSession(addresses []SSHAddress, privateKey []byte, timeout time
 	return client, session, nil
 }
 
-func (*SSH) waitForSSH(addresses []SSHAddress, privateKey []byte, timeout time.Duration) (*ssh.Client, error) {
+func (s *SSH) waitForSSH(addresses []SSHAddress, privateKey []byte, timeout time.Duration) (*ssh.Client, error) {
+	defer s.Tracer.Start("wait for SSH").End()
+
 	signer, err := ssh.ParsePrivateKey(privateKey)
 	if err != nil {
 		return nil, fmt.Errorf("could not parse private key: %s", err)
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/pkg/term"
@@ -125,6 +126,35 @@ var _ = Describe("ssh", func() {
 		})
 	})
 
+	Describe("#RunSSHCommandWithStdin", func() {
+		It("should stream stdin to the command", func() {
+			stdout := gbytes.NewBuffer()
+			Expect(s.RunSSHCommandWithStdin("cat", []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, strings.NewReader("some-input"), stdout, ioutil.Discard)).To(Succeed())
+			Eventually(string(stdout.Contents()), 20*time.Second).Should(Equal("some-input"))
+		})
+	})
+
+	Describe("#RunSSHCommandUntil", func() {
+		It("should stream the output of the command until it is stopped", func() {
+			stdout := gbytes.NewBuffer()
+			stop := make(chan struct{})
+			errChan := make(chan error, 1)
+			go func() {
+				errChan <- s.RunSSHCommandUntil("echo some-output; sleep 60", []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, stdout, ioutil.Discard, stop)
+			}()
+
+			Eventually(stdout, 20*time.Second).Should(gbytes.Say("some-output"))
+			close(stop)
+			Eventually(errChan, 5*time.Second).Should(Receive(Equal(ssh.ErrInterrupted)))
+		})
+
+		Context("when the command exits on its own", func() {
+			It("should return its result", func() {
+				Expect(s.RunSSHCommandUntil("false", []ssh.SSHAddress{{IP: ip, Port: port}}, privateKeyBytes, timeToConnect, ioutil.Discard, ioutil.Discard, make(chan struct{}))).To(MatchError(ContainSubstring("Process exited with: 1")))
+			})
+		})
+	})
+
 	Describe("#WaitForSSH", func() {
 		Context("when SSH is available", func() {
 			It("should succeed with one port", func() {
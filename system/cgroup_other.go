@@ -0,0 +1,11 @@
+// +build !linux
+
+package system
+
+func (s *System) cgroupCPULimit() (int, string) {
+	return 0, ""
+}
+
+func (s *System) cgroupMemory() (limit uint64, usage uint64, source string) {
+	return 0, 0, ""
+}
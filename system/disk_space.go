@@ -0,0 +1,57 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/cloudfoundry/gosigar"
+)
+
+type InsufficientDiskSpaceError struct {
+	Path      string
+	Required  uint64
+	Available uint64
+}
+
+func (e *InsufficientDiskSpaceError) Error() string {
+	return fmt.Sprintf("not enough free disk space in %s: %d MB required, %d MB available, %d MB short. Free up space, for example by running 'cf dev gc', and try again",
+		e.Path, toMegabytes(e.Required), toMegabytes(e.Available), toMegabytes(e.Required-e.Available))
+}
+
+func (s *System) FreeDiskSpace(path string) (uint64, error) {
+	usage := &sigar.FileSystemUsage{}
+	if err := usage.Get(fileSystemPath(path)); err != nil {
+		return 0, fmt.Errorf("failed to determine free disk space in %s: %s", path, err)
+	}
+	return usage.Avail * 1024, nil
+}
+
+func (s *System) CheckDiskSpace(path string, required uint64) error {
+	available, err := s.FreeDiskSpace(path)
+	if err != nil {
+		return err
+	}
+	if available < required {
+		return &InsufficientDiskSpaceError{Path: path, Required: required, Available: available}
+	}
+	return nil
+}
+
+func fileSystemPath(path string) string {
+	if runtime.GOOS == "windows" {
+		return filepath.VolumeName(path) + `\`
+	}
+
+	for {
+		if _, err := os.Stat(path); err == nil || filepath.Dir(path) == path {
+			return path
+		}
+		path = filepath.Dir(path)
+	}
+}
+
+func toMegabytes(bytes uint64) uint64 {
+	return (bytes + BYTES_IN_MEGABYTE - 1) / BYTES_IN_MEGABYTE
+}
@@ -0,0 +1,72 @@
+package system_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/system"
+)
+
+var _ = Describe("disk space", func() {
+	var (
+		sys    *system.System
+		tmpDir string
+	)
+
+	BeforeEach(func() {
+		sys = &system.System{}
+
+		var err error
+		tmpDir, err = ioutil.TempDir("", "pcfdev-system")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(tmpDir)
+	})
+
+	Describe("#FreeDiskSpace", func() {
+		It("should return the free space of the file system containing the path", func() {
+			Expect(sys.FreeDiskSpace(tmpDir)).To(BeNumerically(">", 0))
+		})
+
+		Context("when the path does not exist yet", func() {
+			It("should return the free space of its closest existing parent", func() {
+				Expect(sys.FreeDiskSpace(filepath.Join(tmpDir, "some-dir", "some-file"))).To(BeNumerically(">", 0))
+			})
+		})
+	})
+
+	Describe("#CheckDiskSpace", func() {
+		Context("when there is enough free space", func() {
+			It("should succeed", func() {
+				Expect(sys.CheckDiskSpace(tmpDir, 1)).To(Succeed())
+			})
+		})
+
+		Context("when there is not enough free space", func() {
+			It("should return the shortfall", func() {
+				available, err := sys.FreeDiskSpace(tmpDir)
+				Expect(err).NotTo(HaveOccurred())
+
+				err = sys.CheckDiskSpace(tmpDir, available+10*system.BYTES_IN_MEGABYTE)
+				Expect(err).To(BeAssignableToTypeOf(&system.InsufficientDiskSpaceError{}))
+				Expect(err.(*system.InsufficientDiskSpaceError).Required - err.(*system.InsufficientDiskSpaceError).Available).To(BeNumerically(">=", 10*system.BYTES_IN_MEGABYTE))
+			})
+		})
+	})
+
+	Describe("InsufficientDiskSpaceError", func() {
+		It("should describe the shortfall and suggest freeing up space", func() {
+			err := &system.InsufficientDiskSpaceError{
+				Path:      "some-path",
+				Required:  3 * system.BYTES_IN_MEGABYTE,
+				Available: system.BYTES_IN_MEGABYTE,
+			}
+			Expect(err).To(MatchError("not enough free disk space in some-path: 3 MB required, 1 MB available, 2 MB short. Free up space, for example by running 'cf dev gc', and try again"))
+		})
+	})
+})
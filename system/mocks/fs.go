@@ -28,6 +28,17 @@ func (_m *MockFS) EXPECT() *_MockFSRecorder {
 	return _m.recorder
 }
 
+func (_m *MockFS) Glob(_param0 string) ([]string, error) {
+	ret := _m.ctrl.Call(_m, "Glob", _param0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFSRecorder) Glob(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Glob", arg0)
+}
+
 func (_m *MockFS) Read(_param0 string) ([]byte, error) {
 	ret := _m.ctrl.Call(_m, "Read", _param0)
 	ret0, _ := ret[0].([]byte)
@@ -1,30 +1,123 @@
 package system
 
-import "github.com/cloudfoundry/gosigar"
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/cloudfoundry/gosigar"
+)
 
 const BYTES_IN_MEGABYTE = 1048576
 
 //go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/system FS
 type FS interface {
 	Read(path string) ([]byte, error)
+	Glob(pattern string) (paths []string, err error)
 }
 
 type System struct {
 	FS FS
 }
 
+type Resources struct {
+	CPUs              int
+	CPUsSource        string
+	TotalMemory       uint64
+	TotalMemorySource string
+	FreeMemory        uint64
+	FreeMemorySource  string
+}
+
+func (r *Resources) String() string {
+	return fmt.Sprintf("CPUs: %d (source: %s)\nTotal memory: %d MB (source: %s)\nFree memory: %d MB (source: %s)\n",
+		r.CPUs, r.CPUsSource,
+		r.TotalMemory, r.TotalMemorySource,
+		r.FreeMemory, r.FreeMemorySource,
+	)
+}
+
+func (s *System) Resources() (*Resources, error) {
+	cpus, cpusSource, err := s.cpus()
+	if err != nil {
+		return nil, err
+	}
+	totalMemory, totalMemorySource, err := s.totalMemory()
+	if err != nil {
+		return nil, err
+	}
+	freeMemory, freeMemorySource, err := s.freeMemory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resources{
+		CPUs:              cpus,
+		CPUsSource:        cpusSource,
+		TotalMemory:       totalMemory,
+		TotalMemorySource: totalMemorySource,
+		FreeMemory:        freeMemory,
+		FreeMemorySource:  freeMemorySource,
+	}, nil
+}
+
+func (s *System) PhysicalCores() (int, error) {
+	cpus, _, err := s.cpus()
+	return cpus, err
+}
+
 func (s *System) FreeMemory() (uint64, error) {
+	freeMemory, _, err := s.freeMemory()
+	return freeMemory, err
+}
+
+func (s *System) TotalMemory() (uint64, error) {
+	totalMemory, _, err := s.totalMemory()
+	return totalMemory, err
+}
+
+func (s *System) cpus() (int, string, error) {
+	cores, source, err := s.physicalCores()
+	if err != nil {
+		return 0, "", err
+	}
+	if cores < 1 {
+		cores, source = runtime.NumCPU(), "runtime.NumCPU"
+	}
+
+	if limit, limitSource := s.cgroupCPULimit(); limit > 0 && limit < cores {
+		return limit, limitSource, nil
+	}
+	return cores, source, nil
+}
+
+func (s *System) totalMemory() (uint64, string, error) {
 	mem := &sigar.Mem{}
 	if err := mem.Get(); err != nil {
-		return 0, err
+		return 0, "", err
+	}
+	totalMemory := mem.Total / BYTES_IN_MEGABYTE
+
+	if limit, _, limitSource := s.cgroupMemory(); limit > 0 && limit < totalMemory {
+		return limit, limitSource, nil
 	}
-	return mem.ActualFree / BYTES_IN_MEGABYTE, nil
+	return totalMemory, "host", nil
 }
 
-func (s *System) TotalMemory() (uint64, error) {
+func (s *System) freeMemory() (uint64, string, error) {
 	mem := &sigar.Mem{}
 	if err := mem.Get(); err != nil {
-		return 0, err
+		return 0, "", err
+	}
+	freeMemory := mem.ActualFree / BYTES_IN_MEGABYTE
+
+	if limit, usage, limitSource := s.cgroupMemory(); limit > 0 {
+		available := uint64(0)
+		if usage < limit {
+			available = limit - usage
+		}
+		if available < freeMemory {
+			return available, limitSource, nil
+		}
 	}
-	return mem.Total / BYTES_IN_MEGABYTE, nil
+	return freeMemory, "host", nil
 }
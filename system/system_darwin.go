@@ -6,14 +6,15 @@ import (
 	"strings"
 )
 
-func (s *System) PhysicalCores() (int, error) {
+func (s *System) physicalCores() (int, string, error) {
 	sysctlPath, err := exec.LookPath("sysctl")
 	if err != nil {
 		sysctlPath = "/usr/sbin/sysctl"
 	}
 	output, err := exec.Command(sysctlPath, "-n", "hw.physicalcpu").Output()
 	if err != nil {
-		return 0, nil
+		return 0, "", nil
 	}
-	return strconv.Atoi(strings.TrimSpace(string(output)))
+	cores, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	return cores, "sysctl hw.physicalcpu", err
 }
@@ -1,14 +1,97 @@
 package system
 
-import "regexp"
+import (
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
 
-func (s *System) PhysicalCores() (int, error) {
+func (s *System) physicalCores() (int, string, error) {
 	cpuinfo, err := s.FS.Read("/proc/cpuinfo")
 	if err != nil {
-		return 0, err
+		return 0, "", err
+	}
+	if cores := s.uniq(cpuinfo, `physical id.*`) * s.uniq(cpuinfo, `core id.*`); cores > 0 {
+		return cores, "/proc/cpuinfo", nil
+	}
+
+	return s.topologyCores(), "/sys/devices/system/cpu topology", nil
+}
+
+func (s *System) topologyCores() int {
+	paths, err := s.FS.Glob("/sys/devices/system/cpu/cpu[0-9]*/topology/core_id")
+	if err != nil {
+		return 0
+	}
+
+	cores := map[string]bool{}
+	for _, path := range paths {
+		coreID, err := s.FS.Read(path)
+		if err != nil {
+			continue
+		}
+		packageID, err := s.FS.Read(filepath.Join(filepath.Dir(path), "physical_package_id"))
+		if err != nil {
+			continue
+		}
+		cores[strings.TrimSpace(string(packageID))+":"+strings.TrimSpace(string(coreID))] = true
+	}
+	return len(cores)
+}
+
+func (s *System) cgroupCPULimit() (int, string) {
+	if cpuMax, err := s.FS.Read("/sys/fs/cgroup/cpu.max"); err == nil {
+		fields := strings.Fields(string(cpuMax))
+		if len(fields) != 2 || fields[0] == "max" {
+			return 0, ""
+		}
+		return cpuLimit(fields[0], fields[1]), "cgroup v2 cpu.max"
 	}
 
-	return s.uniq(cpuinfo, `physical id.*`) * s.uniq(cpuinfo, `core id.*`), nil
+	quota, err := s.FS.Read("/sys/fs/cgroup/cpu/cpu.cfs_quota_us")
+	if err != nil {
+		return 0, ""
+	}
+	period, err := s.FS.Read("/sys/fs/cgroup/cpu/cpu.cfs_period_us")
+	if err != nil {
+		return 0, ""
+	}
+	return cpuLimit(string(quota), string(period)), "cgroup v1 cpu.cfs_quota_us"
+}
+
+func cpuLimit(quota string, period string) int {
+	q, err := strconv.ParseInt(strings.TrimSpace(quota), 10, 64)
+	if err != nil || q <= 0 {
+		return 0
+	}
+	p, err := strconv.ParseInt(strings.TrimSpace(period), 10, 64)
+	if err != nil || p <= 0 {
+		return 0
+	}
+	return int((q + p - 1) / p)
+}
+
+func (s *System) cgroupMemory() (limit uint64, usage uint64, source string) {
+	for _, files := range []struct{ limit, usage, source string }{
+		{"/sys/fs/cgroup/memory.max", "/sys/fs/cgroup/memory.current", "cgroup v2 memory.max"},
+		{"/sys/fs/cgroup/memory/memory.limit_in_bytes", "/sys/fs/cgroup/memory/memory.usage_in_bytes", "cgroup v1 memory.limit_in_bytes"},
+	} {
+		limitBytes, err := s.FS.Read(files.limit)
+		if err != nil {
+			continue
+		}
+		limit, err := strconv.ParseUint(strings.TrimSpace(string(limitBytes)), 10, 64)
+		if err != nil {
+			return 0, 0, ""
+		}
+		usage := uint64(0)
+		if usageBytes, err := s.FS.Read(files.usage); err == nil {
+			usage, _ = strconv.ParseUint(strings.TrimSpace(string(usageBytes)), 10, 64)
+		}
+		return limit / BYTES_IN_MEGABYTE, usage / BYTES_IN_MEGABYTE, files.source
+	}
+	return 0, 0, ""
 }
 
 func (s *System) uniq(data []byte, regex string) int {
@@ -4,6 +4,7 @@ package system_test
 
 import (
 	"errors"
+	"runtime"
 
 	"github.com/golang/mock/gomock"
 	"github.com/pivotal-cf/pcfdev-cli/system"
@@ -237,23 +238,205 @@ power management:
 		mockCtrl.Finish()
 	})
 
-	It("should return the number of physical cores", func() {
-		sys := system.System{
-			FS: mockFS,
+	withFiles := func(contents map[string]string) {
+		for _, path := range []string{
+			"/proc/cpuinfo",
+			"/sys/fs/cgroup/cpu.max",
+			"/sys/fs/cgroup/cpu/cpu.cfs_quota_us",
+			"/sys/fs/cgroup/cpu/cpu.cfs_period_us",
+			"/sys/fs/cgroup/memory.max",
+			"/sys/fs/cgroup/memory.current",
+			"/sys/fs/cgroup/memory/memory.limit_in_bytes",
+			"/sys/fs/cgroup/memory/memory.usage_in_bytes",
+		} {
+			if content, ok := contents[path]; ok {
+				mockFS.EXPECT().Read(path).Return([]byte(content), nil).AnyTimes()
+			} else {
+				mockFS.EXPECT().Read(path).Return(nil, errors.New("no such file or directory")).AnyTimes()
+			}
 		}
-		mockFS.EXPECT().Read("/proc/cpuinfo").Return([]byte(cpuinfo), nil)
-		Expect(sys.PhysicalCores()).To(Equal(4))
+	}
+
+	Describe("#PhysicalCores", func() {
+		var sys *system.System
+
+		BeforeEach(func() {
+			sys = &system.System{
+				FS: mockFS,
+			}
+		})
+
+		It("should return the number of physical cores", func() {
+			withFiles(map[string]string{"/proc/cpuinfo": cpuinfo})
+			Expect(sys.PhysicalCores()).To(Equal(4))
+		})
+
+		Context("when it cannot read cpuinfo", func() {
+			It("should return an error", func() {
+				mockFS.EXPECT().Read("/proc/cpuinfo").Return(nil, errors.New("some-error"))
+				_, err := sys.PhysicalCores()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when cpuinfo does not list physical and core ids", func() {
+			BeforeEach(func() {
+				withFiles(map[string]string{"/proc/cpuinfo": "processor : 0\nBogoMIPS : 48.00\n"})
+			})
+
+			It("should count the cores in the cpu topology", func() {
+				mockFS.EXPECT().Glob("/sys/devices/system/cpu/cpu[0-9]*/topology/core_id").Return([]string{
+					"/sys/devices/system/cpu/cpu0/topology/core_id",
+					"/sys/devices/system/cpu/cpu1/topology/core_id",
+					"/sys/devices/system/cpu/cpu2/topology/core_id",
+				}, nil)
+				mockFS.EXPECT().Read("/sys/devices/system/cpu/cpu0/topology/core_id").Return([]byte("0\n"), nil)
+				mockFS.EXPECT().Read("/sys/devices/system/cpu/cpu0/topology/physical_package_id").Return([]byte("0\n"), nil)
+				mockFS.EXPECT().Read("/sys/devices/system/cpu/cpu1/topology/core_id").Return([]byte("1\n"), nil)
+				mockFS.EXPECT().Read("/sys/devices/system/cpu/cpu1/topology/physical_package_id").Return([]byte("0\n"), nil)
+				mockFS.EXPECT().Read("/sys/devices/system/cpu/cpu2/topology/core_id").Return([]byte("1\n"), nil)
+				mockFS.EXPECT().Read("/sys/devices/system/cpu/cpu2/topology/physical_package_id").Return([]byte("0\n"), nil)
+
+				resources, err := sys.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources.CPUs).To(Equal(2))
+				Expect(resources.CPUsSource).To(Equal("/sys/devices/system/cpu topology"))
+			})
+
+			Context("when there is no cpu topology", func() {
+				It("should fall back to the number of logical CPUs", func() {
+					mockFS.EXPECT().Glob("/sys/devices/system/cpu/cpu[0-9]*/topology/core_id").Return([]string{}, nil)
+
+					resources, err := sys.Resources()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(resources.CPUs).To(Equal(runtime.NumCPU()))
+					Expect(resources.CPUsSource).To(Equal("runtime.NumCPU"))
+				})
+			})
+		})
+
+		Context("when a cgroup v2 CPU limit is lower than the number of cores", func() {
+			It("should return the limit rounded up", func() {
+				withFiles(map[string]string{
+					"/proc/cpuinfo":          cpuinfo,
+					"/sys/fs/cgroup/cpu.max": "150000 100000\n",
+				})
+
+				resources, err := sys.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources.CPUs).To(Equal(2))
+				Expect(resources.CPUsSource).To(Equal("cgroup v2 cpu.max"))
+			})
+		})
+
+		Context("when the cgroup v2 CPU limit is max", func() {
+			It("should return the number of physical cores", func() {
+				withFiles(map[string]string{
+					"/proc/cpuinfo":          cpuinfo,
+					"/sys/fs/cgroup/cpu.max": "max 100000\n",
+				})
+
+				resources, err := sys.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources.CPUs).To(Equal(4))
+				Expect(resources.CPUsSource).To(Equal("/proc/cpuinfo"))
+			})
+		})
+
+		Context("when a cgroup v1 CPU quota is set", func() {
+			It("should return the quota divided by the period", func() {
+				withFiles(map[string]string{
+					"/proc/cpuinfo":                        cpuinfo,
+					"/sys/fs/cgroup/cpu/cpu.cfs_quota_us":  "100000\n",
+					"/sys/fs/cgroup/cpu/cpu.cfs_period_us": "100000\n",
+				})
+
+				resources, err := sys.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources.CPUs).To(Equal(1))
+				Expect(resources.CPUsSource).To(Equal("cgroup v1 cpu.cfs_quota_us"))
+			})
+		})
+
+		Context("when the cgroup v1 CPU quota is unlimited", func() {
+			It("should return the number of physical cores", func() {
+				withFiles(map[string]string{
+					"/proc/cpuinfo":                        cpuinfo,
+					"/sys/fs/cgroup/cpu/cpu.cfs_quota_us":  "-1\n",
+					"/sys/fs/cgroup/cpu/cpu.cfs_period_us": "100000\n",
+				})
+
+				Expect(sys.PhysicalCores()).To(Equal(4))
+			})
+		})
 	})
 
-	Context("when it cannot read cpuinfo", func() {
-		It("should return an error", func() {
-			sys := system.System{
+	Describe("#Resources", func() {
+		var sys *system.System
+
+		BeforeEach(func() {
+			sys = &system.System{
 				FS: mockFS,
 			}
-			mockFS.EXPECT().Read("/proc/cpuinfo").Return(nil, errors.New("some-error"))
-			_, err := sys.PhysicalCores()
-			Expect(err).To(MatchError("some-error"))
 		})
 
+		It("should report the host memory", func() {
+			withFiles(map[string]string{"/proc/cpuinfo": cpuinfo})
+
+			resources, err := sys.Resources()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources.TotalMemory).To(BeNumerically(">", 0))
+			Expect(resources.TotalMemorySource).To(Equal("host"))
+			Expect(resources.FreeMemorySource).To(Equal("host"))
+			Expect(resources.String()).To(ContainSubstring("CPUs: 4 (source: /proc/cpuinfo)\n"))
+		})
+
+		Context("when a cgroup v2 memory limit is set", func() {
+			It("should report the memory left within the limit", func() {
+				withFiles(map[string]string{
+					"/proc/cpuinfo":                 cpuinfo,
+					"/sys/fs/cgroup/memory.max":     "3145728\n",
+					"/sys/fs/cgroup/memory.current": "1048576\n",
+				})
+
+				resources, err := sys.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources.TotalMemory).To(Equal(uint64(3)))
+				Expect(resources.TotalMemorySource).To(Equal("cgroup v2 memory.max"))
+				Expect(resources.FreeMemory).To(Equal(uint64(2)))
+				Expect(resources.FreeMemorySource).To(Equal("cgroup v2 memory.max"))
+			})
+		})
+
+		Context("when the cgroup v2 memory limit is max", func() {
+			It("should report the host memory", func() {
+				withFiles(map[string]string{
+					"/proc/cpuinfo":                 cpuinfo,
+					"/sys/fs/cgroup/memory.max":     "max\n",
+					"/sys/fs/cgroup/memory.current": "1048576\n",
+				})
+
+				resources, err := sys.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources.TotalMemorySource).To(Equal("host"))
+				Expect(resources.FreeMemorySource).To(Equal("host"))
+			})
+		})
+
+		Context("when a cgroup v1 memory limit is set", func() {
+			It("should report the memory left within the limit", func() {
+				withFiles(map[string]string{
+					"/proc/cpuinfo": cpuinfo,
+					"/sys/fs/cgroup/memory/memory.limit_in_bytes": "2097152\n",
+					"/sys/fs/cgroup/memory/memory.usage_in_bytes": "4194304\n",
+				})
+
+				resources, err := sys.Resources()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resources.TotalMemory).To(Equal(uint64(2)))
+				Expect(resources.TotalMemorySource).To(Equal("cgroup v1 memory.limit_in_bytes"))
+				Expect(resources.FreeMemory).To(Equal(uint64(0)))
+			})
+		})
 	})
 })
@@ -0,0 +1,25 @@
+package timing
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+)
+
+//go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/timing FS
+type FS interface {
+	Write(path string, contents io.Reader, append bool) error
+}
+
+type History struct {
+	FS   FS
+	Path string
+}
+
+func (h *History) Save(record *Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return h.FS.Write(h.Path, bytes.NewReader(append(data, '\n')), true)
+}
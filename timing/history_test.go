@@ -0,0 +1,69 @@
+package timing_test
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
+	"github.com/pivotal-cf/pcfdev-cli/timing/mocks"
+)
+
+var _ = Describe("History", func() {
+	var (
+		mockCtrl *gomock.Controller
+		mockFS   *mocks.MockFS
+		history  *timing.History
+		record   *timing.Record
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockFS = mocks.NewMockFS(mockCtrl)
+		history = &timing.History{
+			FS:   mockFS,
+			Path: "some-history-path",
+		}
+		record = &timing.Record{
+			Command:       "start",
+			PluginVersion: "some-plugin-version",
+			OVAVersion:    "some-ova-version",
+			StartedAt:     time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC),
+			Succeeded:     true,
+			Phases:        []timing.Phase{{Name: "start", Seconds: 1.5, Count: 1}},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#Save", func() {
+		It("should append the record to the history as a line of JSON", func() {
+			mockFS.EXPECT().Write("some-history-path", gomock.Any(), true).Do(func(_ string, contents io.Reader, _ bool) {
+				Expect(ioutil.ReadAll(contents)).To(MatchJSON(`{
+					"command": "start",
+					"plugin_version": "some-plugin-version",
+					"ova_version": "some-ova-version",
+					"started_at": "2017-06-01T12:00:00Z",
+					"succeeded": true,
+					"phases": [{"name": "start", "seconds": 1.5, "count": 1}]
+				}`))
+			})
+
+			Expect(history.Save(record)).To(Succeed())
+		})
+
+		Context("when writing the history fails", func() {
+			It("should return the error", func() {
+				mockFS.EXPECT().Write("some-history-path", gomock.Any(), true).Return(errors.New("some-error"))
+
+				Expect(history.Save(record)).To(MatchError("some-error"))
+			})
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package timing_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTiming(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "PCF Dev Timing Suite")
+}
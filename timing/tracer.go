@@ -0,0 +1,162 @@
+package timing
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const pathSeparator = "/"
+
+type Tracer struct {
+	Now func() time.Time
+
+	mutex sync.Mutex
+	spans []*Span
+	open  []*Span
+}
+
+type Span struct {
+	tracer   *Tracer
+	path     string
+	start    time.Time
+	duration time.Duration
+	ended    bool
+}
+
+type Phase struct {
+	Name    string  `json:"name"`
+	Seconds float64 `json:"seconds"`
+	Count   int     `json:"count"`
+}
+
+type Record struct {
+	Command       string    `json:"command"`
+	PluginVersion string    `json:"plugin_version"`
+	OVAVersion    string    `json:"ova_version"`
+	StartedAt     time.Time `json:"started_at"`
+	Succeeded     bool      `json:"succeeded"`
+	Phases        []Phase   `json:"phases"`
+}
+
+func (t *Tracer) Start(name string) *Span {
+	if t == nil {
+		return nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	path := name
+	if len(t.open) > 0 {
+		path = t.open[len(t.open)-1].path + pathSeparator + name
+	}
+
+	span := &Span{tracer: t, path: path, start: t.now()}
+	t.spans = append(t.spans, span)
+	t.open = append(t.open, span)
+	return span
+}
+
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+
+	t := s.tracer
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if s.ended {
+		return
+	}
+	s.ended = true
+	s.duration = t.now().Sub(s.start)
+
+	for i := len(t.open) - 1; i >= 0; i-- {
+		if t.open[i] == s {
+			t.open = append(t.open[:i], t.open[i+1:]...)
+			break
+		}
+	}
+}
+
+func (t *Tracer) Trace(name string, block func() error) error {
+	span := t.Start(name)
+	defer span.End()
+	return block()
+}
+
+func (t *Tracer) Record(command string, succeeded bool) *Record {
+	record := &Record{
+		Command:   command,
+		Succeeded: succeeded,
+		Phases:    []Phase{},
+	}
+	if t == nil {
+		return record
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	indexes := map[string]int{}
+	for _, span := range t.spans {
+		duration := span.duration
+		if !span.ended {
+			duration = t.now().Sub(span.start)
+		}
+
+		index, ok := indexes[span.path]
+		if !ok {
+			index = len(record.Phases)
+			indexes[span.path] = index
+			record.Phases = append(record.Phases, Phase{Name: span.path})
+		}
+		record.Phases[index].Seconds += duration.Seconds()
+		record.Phases[index].Count++
+	}
+
+	if len(t.spans) > 0 {
+		record.StartedAt = t.spans[0].start
+	}
+	return record
+}
+
+func (r *Record) Summary() []string {
+	labels := []string{}
+	width := 0
+	for _, phase := range r.Phases {
+		names := strings.Split(phase.Name, pathSeparator)
+		label := strings.Repeat("  ", len(names)-1) + names[len(names)-1]
+		if phase.Count > 1 {
+			label += fmt.Sprintf(" (x%d)", phase.Count)
+		}
+		if len(label) > width {
+			width = len(label)
+		}
+		labels = append(labels, label)
+	}
+
+	lines := []string{}
+	for i, phase := range r.Phases {
+		lines = append(lines, fmt.Sprintf("%-*s  %s", width, labels[i], formatDuration(phase.Seconds)))
+	}
+	return lines
+}
+
+func formatDuration(seconds float64) string {
+	duration := time.Duration(seconds * float64(time.Second))
+	if duration < time.Second {
+		return duration.Round(time.Millisecond).String()
+	}
+	return duration.Round(100 * time.Millisecond).String()
+}
+
+func (t *Tracer) now() time.Time {
+	if t.Now != nil {
+		return t.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,120 @@
+package timing_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
+)
+
+var _ = Describe("Tracer", func() {
+	var (
+		tracer *timing.Tracer
+		now    time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)
+		tracer = &timing.Tracer{
+			Now: func() time.Time { return now },
+		}
+	})
+
+	advance := func(duration time.Duration) {
+		now = now.Add(duration)
+	}
+
+	Describe("#Trace", func() {
+		It("should record a phase around the block and return its error", func() {
+			err := tracer.Trace("import", func() error {
+				advance(time.Second)
+				return errors.New("some-error")
+			})
+			Expect(err).To(MatchError("some-error"))
+
+			Expect(tracer.Record("start", false).Phases).To(Equal([]timing.Phase{
+				{Name: "import", Seconds: 1, Count: 1},
+			}))
+		})
+	})
+
+	Describe("#Record", func() {
+		It("should return the duration of each nested phase in the order they started", func() {
+			start := tracer.Start("start")
+			advance(time.Second)
+			boot := tracer.Start("boot")
+			advance(2 * time.Second)
+			boot.End()
+			provision := tracer.Start("provision")
+			advance(3 * time.Second)
+			provision.End()
+			start.End()
+
+			record := tracer.Record("start", true)
+			Expect(record.Command).To(Equal("start"))
+			Expect(record.Succeeded).To(BeTrue())
+			Expect(record.StartedAt).To(Equal(time.Date(2017, time.June, 1, 12, 0, 0, 0, time.UTC)))
+			Expect(record.Phases).To(Equal([]timing.Phase{
+				{Name: "start", Seconds: 6, Count: 1},
+				{Name: "start/boot", Seconds: 2, Count: 1},
+				{Name: "start/provision", Seconds: 3, Count: 1},
+			}))
+		})
+
+		It("should add up repeated phases with the same parent", func() {
+			start := tracer.Start("start")
+			for i := 0; i < 3; i++ {
+				wait := tracer.Start("wait for SSH")
+				advance(time.Second)
+				wait.End()
+			}
+			start.End()
+
+			Expect(tracer.Record("start", true).Phases).To(Equal([]timing.Phase{
+				{Name: "start", Seconds: 3, Count: 1},
+				{Name: "start/wait for SSH", Seconds: 3, Count: 3},
+			}))
+		})
+
+		It("should measure phases that have not ended until now", func() {
+			tracer.Start("start")
+			advance(time.Second)
+
+			Expect(tracer.Record("start", false).Phases).To(Equal([]timing.Phase{
+				{Name: "start", Seconds: 1, Count: 1},
+			}))
+		})
+
+		Context("when the tracer is nil", func() {
+			It("should record nothing", func() {
+				tracer = nil
+				span := tracer.Start("start")
+				span.End()
+
+				Expect(tracer.Record("start", true)).To(Equal(&timing.Record{Command: "start", Succeeded: true, Phases: []timing.Phase{}}))
+			})
+		})
+	})
+})
+
+var _ = Describe("Record", func() {
+	Describe("#Summary", func() {
+		It("should return an indented line with the duration of each phase", func() {
+			record := &timing.Record{
+				Phases: []timing.Phase{
+					{Name: "start", Seconds: 252.34, Count: 1},
+					{Name: "start/import", Seconds: 35, Count: 1},
+					{Name: "start/import/wait for SSH", Seconds: 0.0123, Count: 4},
+				},
+			}
+
+			Expect(record.Summary()).To(Equal([]string{
+				"start                  4m12.3s",
+				"  import               35s",
+				"    wait for SSH (x4)  12ms",
+			}))
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package ui
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
 
 //go:generate mockgen -package mocks -destination mocks/plugin_ui.go github.com/pivotal-cf/pcfdev-cli/ui PluginUI
 type PluginUI interface {
@@ -11,7 +15,7 @@ type HelpText struct {
 	UI PluginUI
 }
 
-func (h *HelpText) Print(domain string, autoTarget bool) {
+func (h *HelpText) Print(domain string, credentials *config.Credentials, autoTarget bool) {
 	h.UI.Say(` _______  _______  _______    ______   _______  __   __
 |       ||       ||       |  |      | |       ||  | |  |
 |    _  ||       ||    ___|  |  _    ||    ___||  |_|  |
@@ -29,6 +33,5 @@ is now running.`)
 
 	h.UI.Say(fmt.Sprintf(`   cf login -a https://api.%s --skip-ssl-validation
 Apps Manager URL: https://%s
-Admin user => Email: admin / Password: admin
-Regular user => Email: user / Password: pass`, domain, domain))
+%s`, domain, domain, credentials))
 }
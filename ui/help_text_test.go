@@ -3,6 +3,7 @@ package ui_test
 import (
 	"github.com/golang/mock/gomock"
 	. "github.com/onsi/ginkgo"
+	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/ui"
 	"github.com/pivotal-cf/pcfdev-cli/ui/mocks"
 )
@@ -45,7 +46,7 @@ Admin user => Email: admin / Password: admin
 Regular user => Email: user / Password: pass`),
 				)
 
-				helpText.Print("some-domain", false)
+				helpText.Print("some-domain", config.DefaultCredentials(), false)
 			})
 		})
 
@@ -67,7 +68,26 @@ Admin user => Email: admin / Password: admin
 Regular user => Email: user / Password: pass`),
 				)
 
-				helpText.Print("some-domain", true)
+				helpText.Print("some-domain", config.DefaultCredentials(), true)
+			})
+		})
+
+		Context("when PCF Dev is using a master password", func() {
+			It("should print where the passwords come from", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say(gomock.Any()),
+					mockUI.EXPECT().Say(`To begin using PCF Dev, please run:`),
+					mockUI.EXPECT().Say(`   cf login -a https://api.some-domain --skip-ssl-validation
+Apps Manager URL: https://some-domain
+Admin user => Email: admin / Password: <master password>
+Regular user => Email: user / Password: <master password>`),
+				)
+
+				helpText.Print("some-domain", &config.Credentials{
+					AdminUsername:  "admin",
+					UserUsername:   "user",
+					MasterPassword: true,
+				}, false)
 			})
 		})
 	})
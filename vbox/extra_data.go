@@ -0,0 +1,119 @@
+package vbox
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+const (
+	extraDataOwner          = "pcfdev/owner"
+	extraDataPluginVersion  = "pcfdev/plugin-version"
+	extraDataOVAVersion     = "pcfdev/ova-version"
+	extraDataDomain         = "pcfdev/domain"
+	extraDataIP             = "pcfdev/ip"
+	extraDataCPUs           = "pcfdev/cpus"
+	extraDataMemory         = "pcfdev/memory"
+	extraDataOVAPath        = "pcfdev/ova-path"
+	extraDataNetwork        = "pcfdev/network"
+	extraDataBridgeAdapter  = "pcfdev/bridge-adapter"
+	extraDataMasterPassword = "pcfdev/master-password"
+
+	owner = "pcfdev-cli"
+)
+
+func (v *VBox) tagVM(vmConfig *config.VMConfig) error {
+	var pluginVersion string
+	if v.Config.Version != nil {
+		pluginVersion = v.Config.Version.BuildVersion
+	}
+
+	for _, entry := range [][]string{
+		{extraDataPluginVersion, pluginVersion},
+		{extraDataOVAVersion, vmConfig.OVAVersion},
+		{extraDataDomain, vmConfig.Domain},
+		{extraDataIP, vmConfig.IP},
+		{extraDataCPUs, strconv.Itoa(vmConfig.CPUs)},
+		{extraDataMemory, strconv.FormatUint(vmConfig.Memory, 10)},
+		{extraDataOVAPath, vmConfig.OVAPath},
+		{extraDataNetwork, vmConfig.Network},
+		{extraDataBridgeAdapter, vmConfig.BridgeAdapter},
+	} {
+		if err := v.Driver.SetExtraData(vmConfig.Name, entry[0], entry[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ovaVersion is only known for the VM imported from the OVA the plugin downloads. Custom OVAs have no version.
+func (v *VBox) ovaVersion(vmName string) string {
+	if v.Config.Version == nil || vmName != v.Config.DefaultVMName {
+		return ""
+	}
+	return v.Config.Version.OVABuildVersion
+}
+
+func (v *VBox) isManaged(vmName string) (bool, error) {
+	extraData, err := v.Driver.ExtraData(vmName)
+	if err != nil {
+		return false, err
+	}
+
+	switch extraData[extraDataOwner] {
+	case owner:
+		return true, nil
+	case "":
+		if !strings.HasPrefix(vmName, "pcfdev-") {
+			return false, nil
+		}
+		// VMs imported before they were tagged are ours when their disk is in the VM directory, and are tagged
+		// as soon as that is known. Until then, only the names the plugin gives its VMs are recognized.
+		if disk, err := v.Driver.GetVMDisk(vmName); err == nil && isInDir(disk, v.Config.VMDir) {
+			return true, v.Driver.SetExtraData(vmName, extraDataOwner, owner)
+		}
+		return vmName == v.Config.DefaultVMName || vmName == "pcfdev-custom", nil
+	default:
+		return false, nil
+	}
+}
+
+func (v *VBox) managedVMs() (vms []string, err error) {
+	allVMs, err := v.Driver.VMs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vm := range allVMs {
+		managed, err := v.isManaged(vm)
+		if err != nil {
+			return nil, err
+		}
+		if managed {
+			vms = append(vms, vm)
+		}
+	}
+
+	return vms, nil
+}
+
+func vmConfigFromExtraData(vmConfig *config.VMConfig, extraData map[string]string) bool {
+	if extraData[extraDataIP] == "" || extraData[extraDataDomain] == "" {
+		return false
+	}
+
+	vmConfig.IP = extraData[extraDataIP]
+	vmConfig.Domain = extraData[extraDataDomain]
+	vmConfig.OVAPath = extraData[extraDataOVAPath]
+	vmConfig.Network = extraData[extraDataNetwork]
+	vmConfig.BridgeAdapter = extraData[extraDataBridgeAdapter]
+	vmConfig.OVAVersion = extraData[extraDataOVAVersion]
+	vmConfig.MasterPassword = extraData[extraDataMasterPassword] == "true"
+	if cpus, err := strconv.Atoi(extraData[extraDataCPUs]); err == nil {
+		vmConfig.CPUs = cpus
+	}
+
+	return true
+}
@@ -0,0 +1,113 @@
+package vbox
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/fs"
+)
+
+const staleTempDirAge = time.Hour
+
+type Garbage struct {
+	HostOnlyInterfaces []string
+	Disks              []string
+	Files              []string
+}
+
+func (g *Garbage) Empty() bool {
+	return len(g.HostOnlyInterfaces) == 0 && len(g.Disks) == 0 && len(g.Files) == 0
+}
+
+func (v *VBox) FindGarbage() (*Garbage, error) {
+	garbage := &Garbage{}
+
+	interfaces, err := v.Driver.GetHostOnlyInterfaces()
+	if err != nil {
+		return nil, err
+	}
+	for _, iface := range interfaces {
+		// Other tools such as Vagrant and minikube keep their own host-only interfaces.
+		if !v.Picker.IsPCFDevSubnet(iface.IP) {
+			continue
+		}
+		inUse, err := v.Driver.IsInterfaceInUse(iface.Name)
+		if err != nil {
+			return nil, err
+		}
+		if !inUse {
+			garbage.HostOnlyInterfaces = append(garbage.HostOnlyInterfaces, iface.Name)
+		}
+	}
+
+	disks, err := v.Driver.UnattachedDisks()
+	if err != nil {
+		return nil, err
+	}
+	for _, disk := range disks {
+		// Disks elsewhere may belong to another tool's VM that happens to use a pcfdev- name.
+		if isInDir(disk, v.Config.VMDir) {
+			garbage.Disks = append(garbage.Disks, disk)
+		}
+	}
+
+	partialFiles, err := v.FS.Glob(filepath.Join(v.Config.OVADir, "*.partial"))
+	if err != nil {
+		return nil, err
+	}
+	for _, partialFile := range partialFiles {
+		if partialFile != v.Config.PartialOVAPath {
+			garbage.Files = append(garbage.Files, partialFile)
+		}
+	}
+
+	tempDirs, err := v.FS.Glob(filepath.Join(os.TempDir(), fs.TempDirPrefix+"*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, tempDir := range tempDirs {
+		modTime, err := v.FS.ModTime(tempDir)
+		if err != nil {
+			return nil, err
+		}
+		if time.Since(modTime) > staleTempDirAge {
+			garbage.Files = append(garbage.Files, tempDir)
+		}
+	}
+
+	return garbage, nil
+}
+
+func (v *VBox) CollectGarbage(garbage *Garbage) error {
+	var errs []string
+
+	for _, iface := range garbage.HostOnlyInterfaces {
+		if err := v.Driver.RemoveHostOnlyInterface(iface); err != nil {
+			errs = append(errs, fmt.Sprintf("error removing host-only interface %s: %s", iface, err))
+		}
+	}
+	for _, disk := range garbage.Disks {
+		if err := v.Driver.DeleteDisk(disk); err != nil {
+			errs = append(errs, fmt.Sprintf("error removing disk %s: %s", disk, err))
+		}
+	}
+	for _, file := range garbage.Files {
+		if err := v.FS.Remove(file); err != nil {
+			errs = append(errs, fmt.Sprintf("error removing %s: %s", file, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
+func isInDir(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != "." && !strings.HasPrefix(rel, "..")
+}
@@ -0,0 +1,181 @@
+package vbox_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/network"
+	"github.com/pivotal-cf/pcfdev-cli/vbox"
+	"github.com/pivotal-cf/pcfdev-cli/vbox/mocks"
+)
+
+var _ = Describe("Garbage", func() {
+	var (
+		mockCtrl   *gomock.Controller
+		mockDriver *mocks.MockDriver
+		mockFS     *mocks.MockFS
+		mockPicker *mocks.MockNetworkPicker
+		vbx        *vbox.VBox
+		tempGlob   string
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockDriver = mocks.NewMockDriver(mockCtrl)
+		mockFS = mocks.NewMockFS(mockCtrl)
+		mockPicker = mocks.NewMockNetworkPicker(mockCtrl)
+		tempGlob = filepath.Join(os.TempDir(), "pcfdev-*")
+
+		vbx = &vbox.VBox{
+			Driver: mockDriver,
+			FS:     mockFS,
+			Picker: mockPicker,
+			Config: &config.Config{
+				OVADir:         "some-ova-dir",
+				VMDir:          "some-vm-dir",
+				PartialOVAPath: filepath.Join("some-ova-dir", "pcfdev-v2.ova.partial"),
+			},
+		}
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	Describe("#FindGarbage", func() {
+		It("should find unused interfaces, detached disks in the VM directory, stale partial files and stale temp dirs", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().GetHostOnlyInterfaces().Return([]*network.Interface{
+					{Name: "vboxnet0", IP: "192.168.11.1"},
+					{Name: "vboxnet1", IP: "192.168.22.1"},
+				}, nil),
+				mockPicker.EXPECT().IsPCFDevSubnet("192.168.11.1").Return(true),
+				mockDriver.EXPECT().IsInterfaceInUse("vboxnet0").Return(true, nil),
+				mockPicker.EXPECT().IsPCFDevSubnet("192.168.22.1").Return(true),
+				mockDriver.EXPECT().IsInterfaceInUse("vboxnet1").Return(false, nil),
+				mockDriver.EXPECT().UnattachedDisks().Return([]string{
+					filepath.Join("some-other-dir", "pcfdev-disk1.vmdk"),
+					filepath.Join("some-vm-dir", "some-vm", "some-disk.vmdk"),
+					filepath.Join("some-other-dir", "some-other-disk.vmdk"),
+				}, nil),
+				mockFS.EXPECT().Glob(filepath.Join("some-ova-dir", "*.partial")).Return([]string{
+					filepath.Join("some-ova-dir", "pcfdev-v1.ova.partial"),
+					filepath.Join("some-ova-dir", "pcfdev-v2.ova.partial"),
+				}, nil),
+				mockFS.EXPECT().Glob(tempGlob).Return([]string{"some-old-temp-dir", "some-new-temp-dir"}, nil),
+				mockFS.EXPECT().ModTime("some-old-temp-dir").Return(time.Now().Add(-2*time.Hour), nil),
+				mockFS.EXPECT().ModTime("some-new-temp-dir").Return(time.Now(), nil),
+			)
+
+			garbage, err := vbx.FindGarbage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(garbage).To(Equal(&vbox.Garbage{
+				HostOnlyInterfaces: []string{"vboxnet1"},
+				Disks: []string{
+					filepath.Join("some-vm-dir", "some-vm", "some-disk.vmdk"),
+				},
+				Files: []string{
+					filepath.Join("some-ova-dir", "pcfdev-v1.ova.partial"),
+					"some-old-temp-dir",
+				},
+			}))
+			Expect(garbage.Empty()).To(BeFalse())
+		})
+
+		Context("when an unused host-only interface belongs to another tool", func() {
+			It("should keep the interface", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetHostOnlyInterfaces().Return([]*network.Interface{
+						{Name: "vboxnet0", IP: "192.168.56.1"},
+					}, nil),
+					mockPicker.EXPECT().IsPCFDevSubnet("192.168.56.1").Return(false),
+					mockDriver.EXPECT().UnattachedDisks().Return([]string{}, nil),
+					mockFS.EXPECT().Glob(filepath.Join("some-ova-dir", "*.partial")).Return(nil, nil),
+					mockFS.EXPECT().Glob(tempGlob).Return(nil, nil),
+				)
+
+				garbage, err := vbx.FindGarbage()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(garbage.HostOnlyInterfaces).To(BeEmpty())
+			})
+		})
+
+		Context("when there is nothing to collect", func() {
+			It("should return empty garbage", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetHostOnlyInterfaces().Return([]*network.Interface{}, nil),
+					mockDriver.EXPECT().UnattachedDisks().Return([]string{}, nil),
+					mockFS.EXPECT().Glob(filepath.Join("some-ova-dir", "*.partial")).Return(nil, nil),
+					mockFS.EXPECT().Glob(tempGlob).Return(nil, nil),
+				)
+
+				garbage, err := vbx.FindGarbage()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(garbage.Empty()).To(BeTrue())
+			})
+		})
+
+		Context("when checking whether an interface is in use fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetHostOnlyInterfaces().Return([]*network.Interface{{Name: "vboxnet0", IP: "192.168.11.1"}}, nil),
+					mockPicker.EXPECT().IsPCFDevSubnet("192.168.11.1").Return(true),
+					mockDriver.EXPECT().IsInterfaceInUse("vboxnet0").Return(false, errors.New("some-error")),
+				)
+
+				_, err := vbx.FindGarbage()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when listing disks fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetHostOnlyInterfaces().Return([]*network.Interface{}, nil),
+					mockDriver.EXPECT().UnattachedDisks().Return(nil, errors.New("some-error")),
+				)
+
+				_, err := vbx.FindGarbage()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#CollectGarbage", func() {
+		It("should remove everything that was found", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().RemoveHostOnlyInterface("vboxnet1"),
+				mockDriver.EXPECT().DeleteDisk("some-disk"),
+				mockFS.EXPECT().Remove("some-file"),
+			)
+
+			Expect(vbx.CollectGarbage(&vbox.Garbage{
+				HostOnlyInterfaces: []string{"vboxnet1"},
+				Disks:              []string{"some-disk"},
+				Files:              []string{"some-file"},
+			})).To(Succeed())
+		})
+
+		Context("when removing fails", func() {
+			It("should keep going and return all errors", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().RemoveHostOnlyInterface("vboxnet1").Return(errors.New("some-error")),
+					mockDriver.EXPECT().DeleteDisk("some-disk"),
+					mockFS.EXPECT().Remove("some-file").Return(errors.New("some-other-error")),
+				)
+
+				Expect(vbx.CollectGarbage(&vbox.Garbage{
+					HostOnlyInterfaces: []string{"vboxnet1"},
+					Disks:              []string{"some-disk"},
+					Files:              []string{"some-file"},
+				})).To(MatchError("error removing host-only interface vboxnet1: some-error\nerror removing some-file: some-other-error"))
+			})
+		})
+	})
+})
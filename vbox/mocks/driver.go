@@ -30,6 +30,26 @@ func (_m *MockDriver) EXPECT() *_MockDriverRecorder {
 	return _m.recorder
 }
 
+func (_m *MockDriver) AddSharedFolder(_param0 string, _param1 string, _param2 string, _param3 bool) error {
+	ret := _m.ctrl.Call(_m, "AddSharedFolder", _param0, _param1, _param2, _param3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) AddSharedFolder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddSharedFolder", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockDriver) AttachBridgedInterface(_param0 string, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "AttachBridgedInterface", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) AttachBridgedInterface(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AttachBridgedInterface", arg0, arg1)
+}
+
 func (_m *MockDriver) AttachDisk(_param0 string, _param1 string) error {
 	ret := _m.ctrl.Call(_m, "AttachDisk", _param0, _param1)
 	ret0, _ := ret[0].(error)
@@ -50,6 +70,17 @@ func (_mr *_MockDriverRecorder) AttachNetworkInterface(arg0, arg1 interface{}) *
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "AttachNetworkInterface", arg0, arg1)
 }
 
+func (_m *MockDriver) BridgedInterfaces() ([]string, error) {
+	ret := _m.ctrl.Call(_m, "BridgedInterfaces")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDriverRecorder) BridgedInterfaces() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "BridgedInterfaces")
+}
+
 func (_m *MockDriver) CloneDisk(_param0 string, _param1 string) error {
 	ret := _m.ctrl.Call(_m, "CloneDisk", _param0, _param1)
 	ret0, _ := ret[0].(error)
@@ -70,6 +101,16 @@ func (_mr *_MockDriverRecorder) ConfigureHostOnlyInterface(arg0, arg1 interface{
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ConfigureHostOnlyInterface", arg0, arg1)
 }
 
+func (_m *MockDriver) ConvertDisk(_param0 string, _param1 string, _param2 string) error {
+	ret := _m.ctrl.Call(_m, "ConvertDisk", _param0, _param1, _param2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) ConvertDisk(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ConvertDisk", arg0, arg1, arg2)
+}
+
 func (_m *MockDriver) CreateHostOnlyInterface(_param0 string) (string, error) {
 	ret := _m.ctrl.Call(_m, "CreateHostOnlyInterface", _param0)
 	ret0, _ := ret[0].(string)
@@ -122,6 +163,27 @@ func (_mr *_MockDriverRecorder) Disks() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Disks")
 }
 
+func (_m *MockDriver) EnableConsoleLog(_param0 string, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "EnableConsoleLog", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) EnableConsoleLog(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "EnableConsoleLog", arg0, arg1)
+}
+
+func (_m *MockDriver) ExtraData(_param0 string) (map[string]string, error) {
+	ret := _m.ctrl.Call(_m, "ExtraData", _param0)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDriverRecorder) ExtraData(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ExtraData", arg0)
+}
+
 func (_m *MockDriver) ForwardPort(_param0 string, _param1 string, _param2 string, _param3 string) error {
 	ret := _m.ctrl.Call(_m, "ForwardPort", _param0, _param1, _param2, _param3)
 	ret0, _ := ret[0].(error)
@@ -132,6 +194,28 @@ func (_mr *_MockDriverRecorder) ForwardPort(arg0, arg1, arg2, arg3 interface{})
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ForwardPort", arg0, arg1, arg2, arg3)
 }
 
+func (_m *MockDriver) GetDiskFormat(_param0 string) (string, error) {
+	ret := _m.ctrl.Call(_m, "GetDiskFormat", _param0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDriverRecorder) GetDiskFormat(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDiskFormat", arg0)
+}
+
+func (_m *MockDriver) GetDiskSize(_param0 string) (uint64, error) {
+	ret := _m.ctrl.Call(_m, "GetDiskSize", _param0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDriverRecorder) GetDiskSize(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDiskSize", arg0)
+}
+
 func (_m *MockDriver) GetHostForwardPort(_param0 string, _param1 string) (string, error) {
 	ret := _m.ctrl.Call(_m, "GetHostForwardPort", _param0, _param1)
 	ret0, _ := ret[0].(string)
@@ -165,6 +249,17 @@ func (_mr *_MockDriverRecorder) GetMemory(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetMemory", arg0)
 }
 
+func (_m *MockDriver) GetVMDisk(_param0 string) (string, error) {
+	ret := _m.ctrl.Call(_m, "GetVMDisk", _param0)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDriverRecorder) GetVMDisk(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetVMDisk", arg0)
+}
+
 func (_m *MockDriver) IsInterfaceInUse(_param0 string) (bool, error) {
 	ret := _m.ctrl.Call(_m, "IsInterfaceInUse", _param0)
 	ret0, _ := ret[0].(bool)
@@ -186,6 +281,46 @@ func (_mr *_MockDriverRecorder) PowerOffVM(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "PowerOffVM", arg0)
 }
 
+func (_m *MockDriver) ReattachDisk(_param0 string, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "ReattachDisk", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) ReattachDisk(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ReattachDisk", arg0, arg1)
+}
+
+func (_m *MockDriver) RemoveHostOnlyInterface(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "RemoveHostOnlyInterface", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) RemoveHostOnlyInterface(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveHostOnlyInterface", arg0)
+}
+
+func (_m *MockDriver) RemoveSharedFolder(_param0 string, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "RemoveSharedFolder", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) RemoveSharedFolder(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveSharedFolder", arg0, arg1)
+}
+
+func (_m *MockDriver) ResizeDisk(_param0 string, _param1 uint64) error {
+	ret := _m.ctrl.Call(_m, "ResizeDisk", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) ResizeDisk(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResizeDisk", arg0, arg1)
+}
+
 func (_m *MockDriver) ResumeVM(_param0 string) error {
 	ret := _m.ctrl.Call(_m, "ResumeVM", _param0)
 	ret0, _ := ret[0].(error)
@@ -217,6 +352,16 @@ func (_mr *_MockDriverRecorder) SetCPUs(arg0, arg1 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetCPUs", arg0, arg1)
 }
 
+func (_m *MockDriver) SetExtraData(_param0 string, _param1 string, _param2 string) error {
+	ret := _m.ctrl.Call(_m, "SetExtraData", _param0, _param1, _param2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockDriverRecorder) SetExtraData(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SetExtraData", arg0, arg1, arg2)
+}
+
 func (_m *MockDriver) SetMemory(_param0 string, _param1 uint64) error {
 	ret := _m.ctrl.Call(_m, "SetMemory", _param0, _param1)
 	ret0, _ := ret[0].(error)
@@ -257,6 +402,17 @@ func (_mr *_MockDriverRecorder) SuspendVM(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SuspendVM", arg0)
 }
 
+func (_m *MockDriver) UnattachedDisks() ([]string, error) {
+	ret := _m.ctrl.Call(_m, "UnattachedDisks")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockDriverRecorder) UnattachedDisks() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "UnattachedDisks")
+}
+
 func (_m *MockDriver) UseDNSProxy(_param0 string) error {
 	ret := _m.ctrl.Call(_m, "UseDNSProxy", _param0)
 	ret0, _ := ret[0].(error)
@@ -7,6 +7,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	io "io"
 	os "os"
+	time "time"
 )
 
 // Mock of FS interface
@@ -61,6 +62,39 @@ func (_mr *_MockFSRecorder) Extract(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Extract", arg0, arg1, arg2)
 }
 
+func (_m *MockFS) Glob(_param0 string) ([]string, error) {
+	ret := _m.ctrl.Call(_m, "Glob", _param0)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFSRecorder) Glob(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Glob", arg0)
+}
+
+func (_m *MockFS) Length(_param0 string) (int64, error) {
+	ret := _m.ctrl.Call(_m, "Length", _param0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFSRecorder) Length(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Length", arg0)
+}
+
+func (_m *MockFS) ModTime(_param0 string) (time.Time, error) {
+	ret := _m.ctrl.Call(_m, "ModTime", _param0)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockFSRecorder) ModTime(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ModTime", arg0)
+}
+
 func (_m *MockFS) Read(_param0 string) ([]byte, error) {
 	ret := _m.ctrl.Call(_m, "Read", _param0)
 	ret0, _ := ret[0].([]byte)
@@ -91,3 +125,13 @@ func (_m *MockFS) Write(_param0 string, _param1 io.Reader, _param2 bool) error {
 func (_mr *_MockFSRecorder) Write(arg0, arg1, arg2 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Write", arg0, arg1, arg2)
 }
+
+func (_m *MockFS) WriteAtomic(_param0 string, _param1 io.Reader) error {
+	ret := _m.ctrl.Call(_m, "WriteAtomic", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockFSRecorder) WriteAtomic(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "WriteAtomic", arg0, arg1)
+}
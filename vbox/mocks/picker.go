@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	address "github.com/pivotal-cf/pcfdev-cli/address"
 	config "github.com/pivotal-cf/pcfdev-cli/config"
 	network "github.com/pivotal-cf/pcfdev-cli/network"
 )
@@ -30,6 +31,37 @@ func (_m *MockNetworkPicker) EXPECT() *_MockNetworkPickerRecorder {
 	return _m.recorder
 }
 
+func (_m *MockNetworkPicker) Check(_param0 []*network.Interface) ([]*address.Candidate, error) {
+	ret := _m.ctrl.Call(_m, "Check", _param0)
+	ret0, _ := ret[0].([]*address.Candidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockNetworkPickerRecorder) Check(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Check", arg0)
+}
+
+func (_m *MockNetworkPicker) DomainForIP(_param0 string) string {
+	ret := _m.ctrl.Call(_m, "DomainForIP", _param0)
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+func (_mr *_MockNetworkPickerRecorder) DomainForIP(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DomainForIP", arg0)
+}
+
+func (_m *MockNetworkPicker) IsPCFDevSubnet(_param0 string) bool {
+	ret := _m.ctrl.Call(_m, "IsPCFDevSubnet", _param0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+func (_mr *_MockNetworkPickerRecorder) IsPCFDevSubnet(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "IsPCFDevSubnet", arg0)
+}
+
 func (_m *MockNetworkPicker) SelectAvailableInterface(_param0 []*network.Interface, _param1 *config.VMConfig) (*config.NetworkConfig, error) {
 	ret := _m.ctrl.Call(_m, "SelectAvailableInterface", _param0, _param1)
 	ret0, _ := ret[0].(*config.NetworkConfig)
@@ -2,12 +2,15 @@ package vbox
 
 import (
 	"bytes"
+	"crypto/sha1"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"text/template"
 	"time"
@@ -17,8 +20,8 @@ import (
 	. "github.com/pivotal-cf/pcfdev-cli/helpers"
 	"github.com/pivotal-cf/pcfdev-cli/network"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
-	"os"
 )
 
 //go:generate mockgen -package mocks -destination mocks/driver.go github.com/pivotal-cf/pcfdev-cli/vbox Driver
@@ -32,20 +35,35 @@ type Driver interface {
 	ResumeVM(vmName string) error
 	DestroyVM(vmName string) error
 	VMs() (vms []string, err error)
+	SetExtraData(vmName string, key string, value string) error
+	ExtraData(vmName string) (extraData map[string]string, err error)
 	Disks() (disks []string, err error)
 	RunningVMs() (vms []string, err error)
 	CreateHostOnlyInterface(ip string) (interfaceName string, err error)
 	ConfigureHostOnlyInterface(interfaceName string, ip string) error
 	AttachNetworkInterface(interfaceName string, vmName string) error
+	AttachBridgedInterface(interfaceName string, vmName string) error
+	BridgedInterfaces() (interfaces []string, err error)
 	ForwardPort(vmName string, ruleName string, hostPort string, guestPort string) error
 	IsInterfaceInUse(interfaceName string) (bool, error)
 	GetHostForwardPort(vmName string, ruleName string) (port string, err error)
 	GetHostOnlyInterfaces() (interfaces []*network.Interface, err error)
+	RemoveHostOnlyInterface(interfaceName string) error
+	UnattachedDisks() (disks []string, err error)
 	SetCPUs(vmName string, cpuNumber int) error
 	SetMemory(vmName string, memory uint64) error
+	EnableConsoleLog(vmName string, logPath string) error
 	CreateVM(vmName string, baseDirectory string) error
 	AttachDisk(vmName string, diskPath string) error
 	CloneDisk(src string, dest string) error
+	ConvertDisk(src string, dest string, format string) error
+	ReattachDisk(vmName string, diskPath string) error
+	ResizeDisk(diskPath string, size uint64) error
+	AddSharedFolder(vmName string, name string, hostPath string, readOnly bool) error
+	RemoveSharedFolder(vmName string, name string) error
+	GetVMDisk(vmName string) (diskPath string, err error)
+	GetDiskSize(diskPath string) (size uint64, err error)
+	GetDiskFormat(diskPath string) (format string, err error)
 	DeleteDisk(diskPath string) error
 	UseDNSProxy(vmName string) error
 	GetMemory(vmName string) (uint64, error)
@@ -59,8 +77,17 @@ type FS interface {
 	Extract(archivePath string, destinationPath string, filename string) error
 	Remove(path string) error
 	Write(path string, contents io.Reader, append bool) error
+	WriteAtomic(path string, contents io.Reader) error
 	Read(path string) (contents []byte, err error)
+	Length(path string) (bytes int64, err error)
 	Chmod(path string, mode os.FileMode) error
+	Glob(pattern string) (paths []string, err error)
+	ModTime(path string) (modTime time.Time, err error)
+}
+
+//go:generate mockgen -package mocks -destination mocks/system.go github.com/pivotal-cf/pcfdev-cli/vbox System
+type System interface {
+	CheckDiskSpace(path string, required uint64) error
 }
 
 //go:generate mockgen -package mocks -destination mocks/ssh.go github.com/pivotal-cf/pcfdev-cli/vbox SSH
@@ -73,6 +100,9 @@ type SSH interface {
 //go:generate mockgen -package mocks -destination mocks/picker.go github.com/pivotal-cf/pcfdev-cli/vbox NetworkPicker
 type NetworkPicker interface {
 	SelectAvailableInterface(vboxnets []*network.Interface, vmConfig *config.VMConfig) (networkConfig *config.NetworkConfig, err error)
+	Check(vboxnets []*network.Interface) (candidates []*address.Candidate, err error)
+	DomainForIP(ip string) (domain string)
+	IsPCFDevSubnet(subnet string) bool
 }
 
 type VBox struct {
@@ -81,10 +111,13 @@ type VBox struct {
 	FS     FS
 	Picker NetworkPicker
 	SSH    SSH
+	System System
+	Tracer *timing.Tracer
 }
 
 type VMProperties struct {
 	IPAddress string
+	DHCP      bool
 }
 
 type ProxyTypes struct {
@@ -102,6 +135,12 @@ const (
 	StatusUnknown    = "Unknown"
 )
 
+// A bridged VM reaches the host through the gateway of its NAT adapter.
+const natGatewayIP = "10.0.2.2"
+
+// The disk cloned from the stream-optimized VMDK in the OVA is rarely more than three times its size.
+const clonedDiskExpansion = 3
+
 var (
 	networkTemplate = `
 auto lo
@@ -111,9 +150,9 @@ auto eth0
 iface eth0 inet dhcp
 
 auto eth1
-iface eth1 inet static
+{{if .DHCP}}iface eth1 inet dhcp{{else}}iface eth1 inet static
 address {{.IPAddress}}
-netmask 255.255.255.0`
+netmask 255.255.255.0{{end}}`
 
 	proxyTemplate = `
 PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
@@ -123,13 +162,69 @@ NO_PROXY={{.NOProxy}}
 {{if .HTTPProxy}}http_proxy={{.HTTPProxy}}{{end}}
 {{if .HTTPSProxy}}https_proxy={{.HTTPSProxy}}{{end}}
 no_proxy={{.NOProxy}}`
+
+	growPartitionCommand = `set -e
+start=$(sudo sfdisk -d /dev/sda | sed -n 's|^/dev/sda1 : start= *\([0-9]*\),.*|\1|p')
+swap_size=$(sudo sfdisk -d /dev/sda | sed -n 's|^/dev/sda2 : start= *[0-9]*, size= *\([0-9]*\),.*|\1|p')
+root_size=$(($(sudo blockdev --getsz /dev/sda) - start - swap_size))
+sudo blkid -s UUID -o value /dev/sda2 | sudo tee /var/lib/pcfdev-swap-uuid
+sudo swapoff -a
+printf '%s\n' "/dev/sda1 : start=$start, size=$root_size, Id=83, bootable" "/dev/sda2 : start=$((start + root_size)), size=$swap_size, Id=82" | sudo sfdisk --force --no-reread -uS /dev/sda`
+
+	growFilesystemCommand = `set -e
+sudo mkswap -U $(cat /var/lib/pcfdev-swap-uuid) /dev/sda2
+sudo swapon -a
+sudo rm -f /var/lib/pcfdev-swap-uuid
+sudo resize2fs /dev/sda1`
+
+	bridgedIPCommand = `sudo ifdown --force eth1 >/dev/null 2>&1
+sudo ifup eth1 >/dev/null 2>&1
+for i in $(seq 1 60); do
+  ip -4 -o addr show dev eth1 | sed -n 's|.* inet \([0-9.]*\)/.*|\1|p' | grep . && exit 0
+  sleep 1
+done
+exit 1`
+
+	staticIPCommand = `sudo ifdown --force eth1 >/dev/null 2>&1
+sudo ifup eth1 >/dev/null 2>&1
+for i in $(seq 1 60); do
+  ip -4 -o addr show dev eth1 | grep -qF ' inet %s/' && exit 0
+  sleep 1
+done
+exit 1`
+
+	// Only login sessions read /etc/environment, through pam_env, and set-env only reaches upstart jobs
+	// started afterwards. monit and the jobs it started at boot are restarted to pick up changed settings.
+	environmentCommand = `settings="$(echo -e '%s')"
+[ "$settings" = "$(cat /etc/environment 2>/dev/null)" ] && exit 0
+echo "$settings" | sudo tee /etc/environment >/dev/null
+if command -v initctl >/dev/null; then grep = /etc/environment | while IFS= read -r line; do sudo initctl set-env --global "$line"; done; fi
+pgrep -x monit >/dev/null || exit 0
+if [ -e /etc/init/monit.conf ]; then sudo initctl restart monit; else sudo sh -c 'set -a; . /etc/environment; /etc/init.d/monit restart'; fi
+for i in $(seq 1 30); do
+  sudo monit restart all && exit 0
+  sleep 1
+done
+exit 1`
 )
 
 func (v *VBox) StartVM(vmConfig *config.VMConfig) error {
-	if err := v.Driver.StartVM(vmConfig.Name); err != nil {
+	if err := v.Tracer.Trace("boot", func() error {
+		return v.Driver.StartVM(vmConfig.Name)
+	}); err != nil {
 		return err
 	}
 
+	if err := v.Tracer.Trace("configure guest", func() error {
+		return v.configureGuest(vmConfig)
+	}); err != nil {
+		return err
+	}
+
+	return v.growDisk(vmConfig)
+}
+
+func (v *VBox) configureGuest(vmConfig *config.VMConfig) error {
 	if err := v.insertSecureKeypair(vmConfig); err != nil {
 		return err
 	}
@@ -137,7 +232,35 @@ func (v *VBox) StartVM(vmConfig *config.VMConfig) error {
 	if err := v.configureNetwork(vmConfig); err != nil {
 		return err
 	}
-	if err := v.configureEnvironment(vmConfig); err != nil {
+	if vmConfig.Network == config.NetworkBridged {
+		if err := v.configureBridgedAddress(vmConfig); err != nil {
+			return err
+		}
+	} else if err := v.configureStaticAddress(vmConfig); err != nil {
+		return err
+	}
+	return v.configureEnvironment(vmConfig)
+}
+
+func (v *VBox) growDisk(vmConfig *config.VMConfig) error {
+	exists, err := v.FS.Exists(v.diskResizePendingPath())
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return nil
+	}
+	defer v.Tracer.Start("grow disk").End()
+
+	privateKeyBytes, err := v.FS.Read(v.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	addresses := sshAddresses(vmConfig)
+
+	if err := v.SSH.RunSSHCommand(growPartitionCommand, addresses, privateKeyBytes, 5*time.Minute, ioutil.Discard, ioutil.Discard); err != nil {
 		return err
 	}
 
@@ -145,7 +268,19 @@ func (v *VBox) StartVM(vmConfig *config.VMConfig) error {
 		return err
 	}
 
-	return v.Driver.StartVM(vmConfig.Name)
+	if err := v.Driver.StartVM(vmConfig.Name); err != nil {
+		return err
+	}
+
+	if err := v.SSH.RunSSHCommand(growFilesystemCommand, addresses, privateKeyBytes, 5*time.Minute, ioutil.Discard, ioutil.Discard); err != nil {
+		return err
+	}
+
+	return v.FS.Remove(v.diskResizePendingPath())
+}
+
+func (v *VBox) diskResizePendingPath() string {
+	return filepath.Join(v.Config.VMDir, "disk_resize_pending")
 }
 
 func (v *VBox) insertSecureKeypair(vmConfig *config.VMConfig) error {
@@ -165,16 +300,7 @@ func (v *VBox) insertSecureKeypair(vmConfig *config.VMConfig) error {
 
 	if err = v.SSH.RunSSHCommand(
 		fmt.Sprintf(`echo -n "%s" > /home/vcap/.ssh/authorized_keys`, publicKey),
-		[]ssh.SSHAddress{
-			{
-				IP:   "127.0.0.1",
-				Port: vmConfig.SSHPort,
-			},
-			{
-				IP:   vmConfig.IP,
-				Port: "22",
-			},
-		},
+		sshAddresses(vmConfig),
 		v.Config.InsecurePrivateKey,
 		5*time.Minute,
 		ioutil.Discard,
@@ -186,6 +312,33 @@ func (v *VBox) insertSecureKeypair(vmConfig *config.VMConfig) error {
 	return v.writePrivateKey(privateKey)
 }
 
+// The VM IP is only known once a bridged VM has received its address from DHCP.
+func sshAddresses(vmConfig *config.VMConfig) []ssh.SSHAddress {
+	addresses := []ssh.SSHAddress{
+		{
+			IP:   "127.0.0.1",
+			Port: vmConfig.SSHPort,
+		},
+	}
+	if vmConfig.IP != "" {
+		addresses = append(addresses, ssh.SSHAddress{
+			IP:   vmConfig.IP,
+			Port: "22",
+		})
+	}
+	return addresses
+}
+
+// Restarting eth1 drops SSH sessions to the VM IP, so it is done through the NAT port forward.
+func natSSHAddresses(vmConfig *config.VMConfig) []ssh.SSHAddress {
+	return []ssh.SSHAddress{
+		{
+			IP:   "127.0.0.1",
+			Port: vmConfig.SSHPort,
+		},
+	}
+}
+
 func (v *VBox) writePrivateKey(privateKey []byte) error {
 	if err := v.FS.Write(v.Config.PrivateKeyPath, bytes.NewReader(privateKey), false); err != nil {
 		return err
@@ -205,22 +358,13 @@ func (v *VBox) configureNetwork(vmConfig *config.VMConfig) error {
 	}
 
 	var sshCommand bytes.Buffer
-	if err = t.Execute(&sshCommand, VMProperties{IPAddress: vmConfig.IP}); err != nil {
+	if err = t.Execute(&sshCommand, VMProperties{IPAddress: vmConfig.IP, DHCP: vmConfig.Network == config.NetworkBridged}); err != nil {
 		return err
 	}
 
 	return v.SSH.RunSSHCommand(
 		fmt.Sprintf("echo -e '%s' | sudo tee /etc/network/interfaces", sshCommand.String()),
-		[]ssh.SSHAddress{
-			{
-				IP:   "127.0.0.1",
-				Port: vmConfig.SSHPort,
-			},
-			{
-				IP:   vmConfig.IP,
-				Port: "22",
-			},
-		},
+		sshAddresses(vmConfig),
 		privateKeyBytes,
 		5*time.Minute,
 		ioutil.Discard,
@@ -228,6 +372,48 @@ func (v *VBox) configureNetwork(vmConfig *config.VMConfig) error {
 	)
 }
 
+func (v *VBox) configureBridgedAddress(vmConfig *config.VMConfig) error {
+	privateKeyBytes, err := v.FS.Read(v.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	var stdout bytes.Buffer
+	if err := v.SSH.RunSSHCommand(bridgedIPCommand, natSSHAddresses(vmConfig), privateKeyBytes, 5*time.Minute, &stdout, ioutil.Discard); err != nil {
+		return fmt.Errorf("failed to get an IP address for the bridged network from DHCP: %s", err)
+	}
+
+	ip := strings.TrimSpace(stdout.String())
+	if !network.IsIPV4(ip) {
+		return fmt.Errorf("failed to get an IP address for the bridged network from DHCP: '%s' is not an IPv4 address", ip)
+	}
+
+	if vmConfig.Domain == "" || vmConfig.Domain == v.Picker.DomainForIP(vmConfig.IP) {
+		vmConfig.Domain = v.Picker.DomainForIP(ip)
+	}
+	vmConfig.IP = ip
+
+	if err := v.writeVMConfigFile(config.NewVMConfigFile(vmConfig)); err != nil {
+		return err
+	}
+	if err := v.Driver.SetExtraData(vmConfig.Name, extraDataDomain, vmConfig.Domain); err != nil {
+		return err
+	}
+	return v.Driver.SetExtraData(vmConfig.Name, extraDataIP, vmConfig.IP)
+}
+
+func (v *VBox) configureStaticAddress(vmConfig *config.VMConfig) error {
+	privateKeyBytes, err := v.FS.Read(v.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	if err := v.SSH.RunSSHCommand(fmt.Sprintf(staticIPCommand, vmConfig.IP), natSSHAddresses(vmConfig), privateKeyBytes, 5*time.Minute, ioutil.Discard, ioutil.Discard); err != nil {
+		return fmt.Errorf("failed to bring up the IP address %s: %s", vmConfig.IP, err)
+	}
+	return nil
+}
+
 func (v *VBox) configureEnvironment(vmConfig *config.VMConfig) error {
 	proxySettings, err := v.proxySettings(vmConfig)
 	if err != nil {
@@ -240,17 +426,8 @@ func (v *VBox) configureEnvironment(vmConfig *config.VMConfig) error {
 	}
 
 	return v.SSH.RunSSHCommand(
-		fmt.Sprintf("echo -e '%s' | sudo tee /etc/environment", proxySettings),
-		[]ssh.SSHAddress{
-			{
-				IP:   "127.0.0.1",
-				Port: vmConfig.SSHPort,
-			},
-			{
-				IP:   vmConfig.IP,
-				Port: "22",
-			},
-		},
+		fmt.Sprintf(environmentCommand, proxySettings),
+		sshAddresses(vmConfig),
 		privateKeyBytes,
 		5*time.Minute,
 		ioutil.Discard,
@@ -259,9 +436,11 @@ func (v *VBox) configureEnvironment(vmConfig *config.VMConfig) error {
 }
 
 func (v *VBox) proxySettings(vmConfig *config.VMConfig) (settings string, err error) {
-	subnet, err := address.SubnetForIP(vmConfig.IP)
-	if err != nil {
-		return "", err
+	subnet := natGatewayIP
+	if vmConfig.Network != config.NetworkBridged {
+		if subnet, err = address.SubnetForIP(vmConfig.IP); err != nil {
+			return "", err
+		}
 	}
 
 	httpProxy := strings.Replace(v.Config.HTTPProxy, "127.0.0.1", subnet, -1)
@@ -292,17 +471,34 @@ func (v *VBox) proxySettings(vmConfig *config.VMConfig) (settings string, err er
 }
 
 func (v *VBox) ImportVM(vmConfig *config.VMConfig) error {
+	ovaSize, err := v.FS.Length(vmConfig.OVAPath)
+	if err != nil {
+		return err
+	}
+
+	if err := v.System.CheckDiskSpace(v.Config.VMDir, uint64(ovaSize)*(1+clonedDiskExpansion)); err != nil {
+		return err
+	}
+
 	if err := v.Driver.CreateVM(vmConfig.Name, v.Config.VMDir); err != nil {
 		return err
 	}
 
+	if err := v.Driver.SetExtraData(vmConfig.Name, extraDataOwner, owner); err != nil {
+		return err
+	}
+
 	compressedDisk := filepath.Join(v.Config.VMDir, vmConfig.Name+"-disk1.vmdk") + ".compressed"
 	uncompressedDisk := filepath.Join(v.Config.VMDir, vmConfig.Name, vmConfig.Name+"-disk1.vmdk")
-	if err := v.FS.Extract(vmConfig.OVAPath, compressedDisk, `\w+\.vmdk`); err != nil {
+	if err := v.Tracer.Trace("extract disk", func() error {
+		return v.FS.Extract(vmConfig.OVAPath, compressedDisk, `\w+\.vmdk`)
+	}); err != nil {
 		return err
 	}
 
-	if err := v.Driver.CloneDisk(compressedDisk, uncompressedDisk); err != nil {
+	if err := v.Tracer.Trace("clone disk", func() error {
+		return v.Driver.CloneDisk(compressedDisk, uncompressedDisk)
+	}); err != nil {
 		return err
 	}
 
@@ -314,64 +510,290 @@ func (v *VBox) ImportVM(vmConfig *config.VMConfig) error {
 		return err
 	}
 
-	vboxInterfaces, err := v.Driver.GetHostOnlyInterfaces()
+	importedVMConfig := &config.VMConfig{
+		Name:       vmConfig.Name,
+		CPUs:       vmConfig.CPUs,
+		Memory:     vmConfig.Memory,
+		OVAPath:    vmConfig.OVAPath,
+		OVAVersion: v.ovaVersion(vmConfig.Name),
+		Network:    config.NetworkHostOnly,
+	}
+
+	if vmConfig.Network == config.NetworkBridged {
+		bridgeAdapter, err := v.attachBridgedInterface(vmConfig)
+		if err != nil {
+			return err
+		}
+		importedVMConfig.Network = config.NetworkBridged
+		importedVMConfig.BridgeAdapter = bridgeAdapter
+		importedVMConfig.Domain = vmConfig.Domain
+	} else {
+		networkConfig, err := v.attachHostOnlyInterface(vmConfig)
+		if err != nil {
+			return err
+		}
+		importedVMConfig.IP = networkConfig.VMIP
+		importedVMConfig.Domain = networkConfig.VMDomain
+	}
+
+	if err := v.writeVMConfigFile(config.NewVMConfigFile(importedVMConfig)); err != nil {
+		return err
+	}
+
+	if err := v.Driver.UseDNSProxy(vmConfig.Name); err != nil {
+		return err
+	}
+
+	_, sshPort, err := v.SSH.GenerateAddress()
 	if err != nil {
 		return err
 	}
 
+	if err := v.Driver.ForwardPort(vmConfig.Name, "ssh", sshPort, "22"); err != nil {
+		return err
+	}
+
+	if err := v.Driver.SetCPUs(vmConfig.Name, vmConfig.CPUs); err != nil {
+		return err
+	}
+
+	if err := v.Driver.SetMemory(vmConfig.Name, vmConfig.Memory); err != nil {
+		return err
+	}
+
+	if err := v.Driver.EnableConsoleLog(vmConfig.Name, v.Config.ConsoleLogPath(vmConfig.Name)); err != nil {
+		return err
+	}
+
+	return v.tagVM(importedVMConfig)
+}
+
+func (v *VBox) attachHostOnlyInterface(vmConfig *config.VMConfig) (*config.NetworkConfig, error) {
+	vboxInterfaces, err := v.Driver.GetHostOnlyInterfaces()
+	if err != nil {
+		return nil, err
+	}
+
 	networkConfig, err := v.Picker.SelectAvailableInterface(vboxInterfaces, vmConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if networkConfig.Interface.Exists {
 		if err := v.Driver.ConfigureHostOnlyInterface(networkConfig.Interface.Name, networkConfig.Interface.IP); err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		interfaceName, err := v.Driver.CreateHostOnlyInterface(networkConfig.Interface.IP)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		networkConfig.Interface.Name = interfaceName
 	}
 
 	if err := v.Driver.AttachNetworkInterface(networkConfig.Interface.Name, vmConfig.Name); err != nil {
+		return nil, err
+	}
+
+	return networkConfig, nil
+}
+
+func (v *VBox) attachBridgedInterface(vmConfig *config.VMConfig) (bridgeAdapter string, err error) {
+	bridgeAdapter = vmConfig.BridgeAdapter
+	if bridgeAdapter == "" {
+		bridgedInterfaces, err := v.Driver.BridgedInterfaces()
+		if err != nil {
+			return "", err
+		}
+		if len(bridgedInterfaces) == 0 {
+			return "", errors.New("no host network interface is up to bridge to, specify one with --network bridged=IFACE")
+		}
+		bridgeAdapter = bridgedInterfaces[0]
+	}
+
+	if err := v.Driver.AttachBridgedInterface(bridgeAdapter, vmConfig.Name); err != nil {
+		return "", err
+	}
+
+	return bridgeAdapter, nil
+}
+
+func (v *VBox) DiskSize(vmConfig *config.VMConfig) (size uint64, err error) {
+	diskPath, err := v.Driver.GetVMDisk(vmConfig.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	return v.Driver.GetDiskSize(diskPath)
+}
+
+func (v *VBox) ResizeDisk(vmConfig *config.VMConfig, size uint64) error {
+	diskPath, err := v.Driver.GetVMDisk(vmConfig.Name)
+	if err != nil {
 		return err
 	}
 
-	if err := v.FS.Write(
-		filepath.Join(v.Config.VMDir, "vm_config"),
-		strings.NewReader(fmt.Sprintf(`{"ip":"%s","domain":"%s"}`, networkConfig.VMIP, networkConfig.VMDomain)),
-		false,
-	); err != nil {
+	format, err := v.Driver.GetDiskFormat(diskPath)
+	if err != nil {
 		return err
 	}
 
-	if err := v.Driver.UseDNSProxy(vmConfig.Name); err != nil {
+	if format != "VDI" {
+		resizableDisk := strings.TrimSuffix(diskPath, filepath.Ext(diskPath)) + ".vdi"
+		if err := v.Driver.ConvertDisk(diskPath, resizableDisk, "VDI"); err != nil {
+			return err
+		}
+
+		if err := v.Driver.ReattachDisk(vmConfig.Name, resizableDisk); err != nil {
+			return err
+		}
+
+		if err := v.Driver.DeleteDisk(diskPath); err != nil {
+			return err
+		}
+
+		diskPath = resizableDisk
+	}
+
+	if err := v.Driver.ResizeDisk(diskPath, size); err != nil {
 		return err
 	}
 
-	_, sshPort, err := v.SSH.GenerateAddress()
+	return v.FS.Write(v.diskResizePendingPath(), strings.NewReader(""), false)
+}
+
+func (v *VBox) SharedFolders(vmConfig *config.VMConfig) (sharedFolders []*config.SharedFolder, err error) {
+	exists, err := v.FS.Exists(v.sharedFoldersPath())
+	if err != nil {
+		return nil, err
+	}
+
+	sharedFolders = []*config.SharedFolder{}
+	if !exists {
+		return sharedFolders, nil
+	}
+
+	data, err := v.FS.Read(v.sharedFoldersPath())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &sharedFolders); err != nil {
+		return nil, err
+	}
+
+	return sharedFolders, nil
+}
+
+func (v *VBox) AddSharedFolder(vmConfig *config.VMConfig, sharedFolder *config.SharedFolder) error {
+	sharedFolders, err := v.SharedFolders(vmConfig)
 	if err != nil {
 		return err
 	}
 
-	if err := v.Driver.ForwardPort(vmConfig.Name, "ssh", sshPort, "22"); err != nil {
+	for _, existingSharedFolder := range sharedFolders {
+		if existingSharedFolder.GuestPath == sharedFolder.GuestPath {
+			return fmt.Errorf("a shared folder is already mounted at %s", sharedFolder.GuestPath)
+		}
+	}
+
+	exists, err := v.FS.Exists(sharedFolder.HostPath)
+	if err != nil {
 		return err
 	}
+	if !exists {
+		return fmt.Errorf("%s does not exist", sharedFolder.HostPath)
+	}
 
-	if err := v.Driver.SetCPUs(vmConfig.Name, vmConfig.CPUs); err != nil {
+	sharedFolder.Name = sharedFolderName(sharedFolder.GuestPath)
+	for _, existingSharedFolder := range sharedFolders {
+		if existingSharedFolder.Name == sharedFolder.Name {
+			return fmt.Errorf("a shared folder named %s already exists", sharedFolder.Name)
+		}
+	}
+
+	if err := v.Driver.AddSharedFolder(vmConfig.Name, sharedFolder.Name, sharedFolder.HostPath, sharedFolder.ReadOnly); err != nil {
 		return err
 	}
 
-	if err := v.Driver.SetMemory(vmConfig.Name, vmConfig.Memory); err != nil {
+	return v.writeSharedFolders(append(sharedFolders, sharedFolder))
+}
+
+func (v *VBox) RemoveSharedFolder(vmConfig *config.VMConfig, guestPath string) error {
+	sharedFolders, err := v.SharedFolders(vmConfig)
+	if err != nil {
+		return err
+	}
+
+	for i, sharedFolder := range sharedFolders {
+		if sharedFolder.GuestPath == guestPath {
+			if err := v.Driver.RemoveSharedFolder(vmConfig.Name, sharedFolder.Name); err != nil {
+				return err
+			}
+
+			return v.writeSharedFolders(append(sharedFolders[:i], sharedFolders[i+1:]...))
+		}
+	}
+
+	return fmt.Errorf("no shared folder is mounted at %s", guestPath)
+}
+
+func (v *VBox) MountSharedFolders(vmConfig *config.VMConfig) error {
+	sharedFolders, err := v.SharedFolders(vmConfig)
+	if err != nil {
 		return err
 	}
 
+	if len(sharedFolders) == 0 {
+		return nil
+	}
+
+	privateKeyBytes, err := v.FS.Read(v.Config.PrivateKeyPath)
+	if err != nil {
+		return err
+	}
+
+	for _, sharedFolder := range sharedFolders {
+		options := "uid=vcap,gid=vcap"
+		if sharedFolder.ReadOnly {
+			options += ",ro"
+		}
+
+		if err := v.SSH.RunSSHCommand(
+			fmt.Sprintf("sudo mkdir -p %[1]s && (mountpoint -q %[1]s || sudo mount -t vboxsf -o %[2]s %[3]s %[1]s)", ShellQuote(sharedFolder.GuestPath), options, sharedFolder.Name),
+			sshAddresses(vmConfig),
+			privateKeyBytes,
+			5*time.Minute,
+			ioutil.Discard,
+			ioutil.Discard,
+		); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+func (v *VBox) writeSharedFolders(sharedFolders []*config.SharedFolder) error {
+	data, err := json.Marshal(sharedFolders)
+	if err != nil {
+		return err
+	}
+
+	return v.FS.Write(v.sharedFoldersPath(), bytes.NewReader(data), false)
+}
+
+func (v *VBox) sharedFoldersPath() string {
+	return filepath.Join(v.Config.VMDir, "shared_folders")
+}
+
+// sharedFolderName ends in a hash of the guest path, since guest paths like /a_b and /a-b read the same once sanitized.
+func sharedFolderName(guestPath string) string {
+	readable := regexp.MustCompile(`[^A-Za-z0-9]+`).ReplaceAllString(strings.TrimRight(guestPath, "/"), "-")
+	sum := sha1.Sum([]byte(guestPath))
+	return fmt.Sprintf("pcfdev%s-%x", readable, sum[:4])
+}
+
 func (v *VBox) DestroyVM(vmConfig *config.VMConfig) error {
 	return v.Driver.DestroyVM(vmConfig.Name)
 }
@@ -381,18 +803,15 @@ func (v *VBox) PowerOffVM(vmConfig *config.VMConfig) error {
 }
 
 func (v *VBox) GetVMName() (name string, err error) {
-	vms, err := v.Driver.VMs()
+	vms, err := v.managedVMs()
 	if err != nil {
 		return "", err
 	}
-	for _, vm := range vms {
-		if strings.HasPrefix(vm, "pcfdev-") {
-			if name == "" {
-				name = vm
-			} else {
-				return "", errors.New("multiple PCF Dev VMs found")
-			}
-		}
+	if len(vms) > 1 {
+		return "", errors.New("multiple PCF Dev VMs found")
+	}
+	if len(vms) == 1 {
+		name = vms[0]
 	}
 	return name, nil
 }
@@ -414,27 +833,23 @@ func (v *VBox) ResumeSavedVM(vmConfig *config.VMConfig) error {
 }
 
 func (v *VBox) DestroyPCFDevVMs() error {
-	vms, err := v.Driver.VMs()
+	vms, err := v.managedVMs()
 	if err != nil {
 		return err
 	}
 
 	for _, vm := range vms {
-		if strings.HasPrefix(vm, "pcfdev-") {
-			IgnoreErrorFrom(v.Driver.PowerOffVM(vm))
-			IgnoreErrorFrom(v.Driver.DestroyVM(vm))
-		}
+		IgnoreErrorFrom(v.Driver.PowerOffVM(vm))
+		IgnoreErrorFrom(v.Driver.DestroyVM(vm))
 	}
 
-	vms, err = v.Driver.VMs()
+	vms, err = v.managedVMs()
 	if err != nil {
 		return err
 	}
 
-	for _, vm := range vms {
-		if strings.HasPrefix(vm, "pcfdev-") {
-			return errors.New("failed to destroy all pcfdev vms")
-		}
+	if len(vms) > 0 {
+		return errors.New("failed to destroy all pcfdev vms")
 	}
 
 	disks, err := v.Driver.Disks()
@@ -443,8 +858,7 @@ func (v *VBox) DestroyPCFDevVMs() error {
 	}
 
 	for _, disk := range disks {
-		filename := filepath.Base(disk)
-		if strings.HasPrefix(filename, "pcfdev-") {
+		if isInDir(disk, v.Config.VMDir) {
 			IgnoreErrorFrom(v.Driver.DeleteDisk(disk))
 		}
 	}
@@ -455,8 +869,7 @@ func (v *VBox) DestroyPCFDevVMs() error {
 	}
 
 	for _, disk := range disks {
-		filename := filepath.Base(disk)
-		if strings.HasPrefix(filename, "pcfdev-") {
+		if isInDir(disk, v.Config.VMDir) {
 			return errors.New("failed to destroy all pcfdev disks")
 		}
 	}
@@ -472,7 +885,7 @@ func (v *VBox) VMConfig(vmName string) (*config.VMConfig, error) {
 	if err != nil {
 		return nil, err
 	}
-	vmConfigBytes, err := v.FS.Read(filepath.Join(v.Config.VMDir, "vm_config"))
+	extraData, err := v.Driver.ExtraData(vmName)
 	if err != nil {
 		return nil, err
 	}
@@ -483,13 +896,93 @@ func (v *VBox) VMConfig(vmName string) (*config.VMConfig, error) {
 		SSHPort:  port,
 		Provider: "virtualbox",
 	}
-	if err := json.Unmarshal(vmConfigBytes, &vmConfig); err != nil {
+	if vmConfigFromExtraData(vmConfig, extraData) {
+		// The extra data takes precedence, but an outdated vm_config file is still migrated.
+		// A vm_config file that cannot be read does not keep a tagged VM from working.
+		if exists, err := v.FS.Exists(v.vmConfigFilePath()); err == nil && exists {
+			if vmConfigFile, err := v.readVMConfigFile(vmName, memory); err == nil && vmConfig.OVAVersion == "" {
+				vmConfig.OVAVersion = vmConfigFile.OVAVersion
+			}
+		}
+		if vmConfig.OVAVersion == "" {
+			vmConfig.OVAVersion = v.ovaVersion(vmName)
+		}
+		return vmConfig, nil
+	}
+
+	vmConfigFile, err := v.readVMConfigFile(vmName, memory)
+	if err != nil {
 		return nil, err
 	}
 
+	vmConfig.IP = vmConfigFile.IP
+	vmConfig.Domain = vmConfigFile.Domain
+	vmConfig.CPUs = vmConfigFile.CPUs
+	vmConfig.OVAPath = vmConfigFile.OVAPath
+	vmConfig.Network = vmConfigFile.Network
+	vmConfig.BridgeAdapter = vmConfigFile.BridgeAdapter
+	vmConfig.MasterPassword = vmConfigFile.MasterPassword
+	vmConfig.OVAVersion = vmConfigFile.OVAVersion
+	if vmConfig.OVAVersion == "" {
+		vmConfig.OVAVersion = v.ovaVersion(vmName)
+	}
 	return vmConfig, nil
 }
 
+func (v *VBox) VMConfigFile(vmName string) (*config.VMConfigFile, error) {
+	memory, err := v.Driver.GetMemory(vmName)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.readVMConfigFile(vmName, memory)
+}
+
+func (v *VBox) RecordMasterPassword(vmConfig *config.VMConfig) error {
+	vmConfig.MasterPassword = true
+
+	if err := v.Driver.SetExtraData(vmConfig.Name, extraDataMasterPassword, "true"); err != nil {
+		return err
+	}
+	return v.writeVMConfigFile(config.NewVMConfigFile(vmConfig))
+}
+
+func (v *VBox) readVMConfigFile(vmName string, memory uint64) (*config.VMConfigFile, error) {
+	contents, err := v.FS.Read(v.vmConfigFilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	vmConfigFile, migrated, err := config.ParseVMConfigFile(contents, &config.VMConfig{
+		Memory:  memory,
+		OVAPath: filepath.Join(v.Config.OVADir, vmName+".ova"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if migrated {
+		if err := v.writeVMConfigFile(vmConfigFile); err != nil {
+			return nil, err
+		}
+	}
+
+	return vmConfigFile, nil
+}
+
+func (v *VBox) writeVMConfigFile(vmConfigFile *config.VMConfigFile) error {
+	contents, err := vmConfigFile.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return v.FS.WriteAtomic(v.vmConfigFilePath(), bytes.NewReader(contents))
+}
+
+func (v *VBox) vmConfigFilePath() string {
+	return filepath.Join(v.Config.VMDir, "vm_config")
+}
+
 func (v *VBox) VMStatus(vmName string) (status string, err error) {
 	exists, err := v.Driver.VMExists(vmName)
 	if err != nil {
@@ -519,6 +1012,15 @@ func (v *VBox) VMStatus(vmName string) (status string, err error) {
 	}
 }
 
+func (v *VBox) CheckNetwork() (candidates []*address.Candidate, err error) {
+	vboxInterfaces, err := v.Driver.GetHostOnlyInterfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Picker.Check(vboxInterfaces)
+}
+
 func (v *VBox) Version() (version *vboxdriver.VBoxDriverVersion, err error) {
 	return v.Driver.Version()
 }
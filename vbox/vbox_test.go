@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/pivotal-cf/pcfdev-cli/address"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/network"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
@@ -29,6 +31,7 @@ var _ = Describe("vbox", func() {
 		mockSSH    *mocks.MockSSH
 		mockPicker *mocks.MockNetworkPicker
 		mockFS     *mocks.MockFS
+		mockSystem *mocks.MockSystem
 		vbx        *vbox.VBox
 		conf       *config.Config
 	)
@@ -39,6 +42,7 @@ var _ = Describe("vbox", func() {
 		mockSSH = mocks.NewMockSSH(mockCtrl)
 		mockFS = mocks.NewMockFS(mockCtrl)
 		mockPicker = mocks.NewMockNetworkPicker(mockCtrl)
+		mockSystem = mocks.NewMockSystem(mockCtrl)
 
 		conf = &config.Config{
 			PCFDevHome:         "some-pcfdev-home",
@@ -49,6 +53,11 @@ var _ = Describe("vbox", func() {
 			NoProxy:            "some-no-proxy",
 			InsecurePrivateKey: []byte("some-insecure-private-key"),
 			PrivateKeyPath:     "some-private-key-path",
+			DefaultVMName:      "pcfdev-default",
+			Version: &config.Version{
+				BuildVersion:    "some-plugin-version",
+				OVABuildVersion: "some-ova-version",
+			},
 
 			MinMemory: uint64(1000),
 			MaxMemory: uint64(2000),
@@ -59,6 +68,7 @@ var _ = Describe("vbox", func() {
 			SSH:    mockSSH,
 			FS:     mockFS,
 			Picker: mockPicker,
+			System: mockSystem,
 			Config: conf,
 		}
 	})
@@ -97,7 +107,10 @@ var _ = Describe("vbox", func() {
 					OVAPath: "some-ova-path",
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -106,12 +119,87 @@ var _ = Describe("vbox", func() {
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(newInterface, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
 					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "some-vm"),
-					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-vm-ip","domain":"some-vm-domain"}`), false),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
 					mockDriver.EXPECT().UseDNSProxy("some-vm"),
 					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
 					mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
 					mockDriver.EXPECT().SetCPUs("some-vm", 7),
 					mockDriver.EXPECT().SetMemory("some-vm", uint64(2000)),
+					mockDriver.EXPECT().EnableConsoleLog("some-vm", filepath.Join("some-vm-dir", "some-vm", "console.log")),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/plugin-version", "some-plugin-version"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ova-version", ""),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/domain", "some-vm-domain"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ip", "some-vm-ip"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/cpus", "7"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/memory", "2000"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ova-path", "some-ova-path"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/network", "hostonly"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/bridge-adapter", ""),
+				)
+				Expect(vbx.ImportVM(vmConfig)).To(Succeed())
+			})
+
+			It("should record the OVA version when importing the OVA the plugin downloads", func() {
+				vboxnets := []*network.Interface{
+					&network.Interface{
+						Name:   "some-used-vbox-interface",
+						IP:     "some-used-ip",
+						Exists: true,
+					},
+					&network.Interface{
+						Name:   "some-other-used-vbox-interface",
+						IP:     "some-other-used-ip",
+						Exists: true,
+					},
+				}
+				newInterface := &config.NetworkConfig{
+					VMIP:     "some-vm-ip",
+					VMDomain: "some-vm-domain",
+					Interface: &network.Interface{
+						IP:     "some-unused-ip",
+						Exists: false,
+					},
+				}
+				vmConfig := &config.VMConfig{
+					Name:    "pcfdev-default",
+					Memory:  uint64(2000),
+					CPUs:    7,
+					OVAPath: "some-ova-path",
+				}
+				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
+					mockDriver.EXPECT().CreateVM("pcfdev-default", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/owner", "pcfdev-cli"),
+					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "pcfdev-default-disk1.vmdk.compressed"), `\w+\.vmdk`),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "pcfdev-default-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "pcfdev-default", "pcfdev-default-disk1.vmdk")),
+					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "pcfdev-default-disk1.vmdk.compressed")),
+					mockDriver.EXPECT().AttachDisk("pcfdev-default", filepath.Join("some-vm-dir", "pcfdev-default", "pcfdev-default-disk1.vmdk")),
+					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
+					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(newInterface, nil),
+					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
+					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "pcfdev-default"),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", &config.VMConfig{
+						CPUs:       7,
+						Memory:     uint64(2000),
+						OVAPath:    "some-ova-path",
+						OVAVersion: "some-ova-version",
+					})),
+					mockDriver.EXPECT().UseDNSProxy("pcfdev-default"),
+					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
+					mockDriver.EXPECT().ForwardPort("pcfdev-default", "ssh", "some-port", "22"),
+					mockDriver.EXPECT().SetCPUs("pcfdev-default", 7),
+					mockDriver.EXPECT().SetMemory("pcfdev-default", uint64(2000)),
+					mockDriver.EXPECT().EnableConsoleLog("pcfdev-default", filepath.Join("some-vm-dir", "pcfdev-default", "console.log")),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/plugin-version", "some-plugin-version"),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/ova-version", "some-ova-version"),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/domain", "some-vm-domain"),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/ip", "some-vm-ip"),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/cpus", "7"),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/memory", "2000"),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/ova-path", "some-ova-path"),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/network", "hostonly"),
+					mockDriver.EXPECT().SetExtraData("pcfdev-default", "pcfdev/bridge-adapter", ""),
 				)
 				Expect(vbx.ImportVM(vmConfig)).To(Succeed())
 			})
@@ -143,7 +231,10 @@ var _ = Describe("vbox", func() {
 					Memory:  uint64(2000),
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -152,12 +243,22 @@ var _ = Describe("vbox", func() {
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(unusedVBoxInterface, nil),
 					mockDriver.EXPECT().ConfigureHostOnlyInterface("some-unused-vbox-interface", "some-unused-ip"),
 					mockDriver.EXPECT().AttachNetworkInterface("some-unused-vbox-interface", "some-vm"),
-					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-vm-ip","domain":"some-vm-domain"}`), false),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
 					mockDriver.EXPECT().UseDNSProxy("some-vm"),
 					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
 					mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
 					mockDriver.EXPECT().SetCPUs("some-vm", 7),
 					mockDriver.EXPECT().SetMemory("some-vm", uint64(2000)),
+					mockDriver.EXPECT().EnableConsoleLog("some-vm", filepath.Join("some-vm-dir", "some-vm", "console.log")),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/plugin-version", "some-plugin-version"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ova-version", ""),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/domain", "some-vm-domain"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ip", "some-vm-ip"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/cpus", "7"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/memory", "2000"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ova-path", "some-ova-path"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/network", "hostonly"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/bridge-adapter", ""),
 				)
 				Expect(vbx.ImportVM(vmConfig)).To(Succeed())
 			})
@@ -189,7 +290,10 @@ var _ = Describe("vbox", func() {
 					Memory:  uint64(2000),
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -198,21 +302,180 @@ var _ = Describe("vbox", func() {
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(unusedVBoxInterface, nil),
 					mockDriver.EXPECT().ConfigureHostOnlyInterface("some-unused-vbox-interface", "some-unused-ip"),
 					mockDriver.EXPECT().AttachNetworkInterface("some-unused-vbox-interface", "some-vm"),
-					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-vm-ip","domain":"some-vm-domain"}`), false),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
+					mockDriver.EXPECT().UseDNSProxy("some-vm"),
+					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
+					mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
+					mockDriver.EXPECT().SetCPUs("some-vm", 7),
+					mockDriver.EXPECT().SetMemory("some-vm", uint64(2000)),
+					mockDriver.EXPECT().EnableConsoleLog("some-vm", filepath.Join("some-vm-dir", "some-vm", "console.log")),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/plugin-version", "some-plugin-version"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ova-version", ""),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/domain", "some-vm-domain"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ip", "some-vm-ip"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/cpus", "7"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/memory", "2000"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ova-path", "some-ova-path"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/network", "hostonly"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/bridge-adapter", ""),
+				)
+				Expect(vbx.ImportVM(vmConfig)).To(Succeed())
+			})
+		})
+
+		Context("when bridged networking is requested", func() {
+			var vmConfig *config.VMConfig
+
+			BeforeEach(func() {
+				vmConfig = &config.VMConfig{
+					Name:    "some-vm",
+					Memory:  uint64(2000),
+					CPUs:    7,
+					OVAPath: "some-ova-path",
+					Network: "bridged",
+				}
+			})
+
+			It("should bridge the VM to the first host interface that is up", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
+					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
+					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().BridgedInterfaces().Return([]string{"some-adapter", "some-other-adapter"}, nil),
+					mockDriver.EXPECT().AttachBridgedInterface("some-adapter", "some-vm"),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("", "", &config.VMConfig{
+						CPUs:          7,
+						Memory:        uint64(2000),
+						OVAPath:       "some-ova-path",
+						Network:       "bridged",
+						BridgeAdapter: "some-adapter",
+					})),
 					mockDriver.EXPECT().UseDNSProxy("some-vm"),
 					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
 					mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
 					mockDriver.EXPECT().SetCPUs("some-vm", 7),
 					mockDriver.EXPECT().SetMemory("some-vm", uint64(2000)),
+					mockDriver.EXPECT().EnableConsoleLog("some-vm", filepath.Join("some-vm-dir", "some-vm", "console.log")),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/plugin-version", "some-plugin-version"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ova-version", ""),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/domain", ""),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ip", ""),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/cpus", "7"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/memory", "2000"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ova-path", "some-ova-path"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/network", "bridged"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/bridge-adapter", "some-adapter"),
 				)
 				Expect(vbx.ImportVM(vmConfig)).To(Succeed())
 			})
+
+			Context("when a host interface is given", func() {
+				It("should bridge the VM to that interface and keep the requested domain", func() {
+					vmConfig.BridgeAdapter = "some-given-adapter"
+					vmConfig.Domain = "some-domain"
+
+					gomock.InOrder(
+						mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+						mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
+						mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+						mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
+						mockFS.EXPECT().Extract(gomock.Any(), gomock.Any(), gomock.Any()),
+						mockDriver.EXPECT().CloneDisk(gomock.Any(), gomock.Any()),
+						mockDriver.EXPECT().DeleteDisk(gomock.Any()),
+						mockDriver.EXPECT().AttachDisk(gomock.Any(), gomock.Any()),
+						mockDriver.EXPECT().AttachBridgedInterface("some-given-adapter", "some-vm"),
+						mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("", "some-domain", &config.VMConfig{
+							CPUs:          7,
+							Memory:        uint64(2000),
+							OVAPath:       "some-ova-path",
+							Network:       "bridged",
+							BridgeAdapter: "some-given-adapter",
+						})),
+						mockDriver.EXPECT().UseDNSProxy("some-vm"),
+						mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
+						mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
+						mockDriver.EXPECT().SetCPUs("some-vm", 7),
+						mockDriver.EXPECT().SetMemory("some-vm", uint64(2000)),
+						mockDriver.EXPECT().EnableConsoleLog("some-vm", filepath.Join("some-vm-dir", "some-vm", "console.log")),
+						mockDriver.EXPECT().SetExtraData("some-vm", gomock.Any(), gomock.Any()).Times(9),
+					)
+					Expect(vbx.ImportVM(vmConfig)).To(Succeed())
+				})
+			})
+
+			Context("when no host interface is up", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+						mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
+						mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+						mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
+						mockFS.EXPECT().Extract(gomock.Any(), gomock.Any(), gomock.Any()),
+						mockDriver.EXPECT().CloneDisk(gomock.Any(), gomock.Any()),
+						mockDriver.EXPECT().DeleteDisk(gomock.Any()),
+						mockDriver.EXPECT().AttachDisk(gomock.Any(), gomock.Any()),
+						mockDriver.EXPECT().BridgedInterfaces().Return([]string{}, nil),
+					)
+					Expect(vbx.ImportVM(vmConfig)).To(MatchError("no host network interface is up to bridge to, specify one with --network bridged=IFACE"))
+				})
+			})
+
+			Context("when attaching the bridged interface fails", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+						mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
+						mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+						mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
+						mockFS.EXPECT().Extract(gomock.Any(), gomock.Any(), gomock.Any()),
+						mockDriver.EXPECT().CloneDisk(gomock.Any(), gomock.Any()),
+						mockDriver.EXPECT().DeleteDisk(gomock.Any()),
+						mockDriver.EXPECT().AttachDisk(gomock.Any(), gomock.Any()),
+						mockDriver.EXPECT().BridgedInterfaces().Return([]string{"some-adapter"}, nil),
+						mockDriver.EXPECT().AttachBridgedInterface("some-adapter", "some-vm").Return(errors.New("some-error")),
+					)
+					Expect(vbx.ImportVM(vmConfig)).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("when there is not enough free disk space to import the OVA", func() {
+			It("should return an error before creating the VM", func() {
+				vmConfig := &config.VMConfig{
+					Name:    "some-vm",
+					OVAPath: "some-ova-path",
+				}
+				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)).Return(errors.New("some-error")),
+				)
+				Expect(vbx.ImportVM(vmConfig)).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when the size of the OVA cannot be determined", func() {
+			It("should return an error", func() {
+				vmConfig := &config.VMConfig{
+					Name:    "some-vm",
+					OVAPath: "some-ova-path",
+				}
+				mockFS.EXPECT().Length("some-ova-path").Return(int64(0), errors.New("some-error"))
+				Expect(vbx.ImportVM(vmConfig)).To(MatchError("some-error"))
+			})
 		})
 
 		Context("when extracting the file returns an error", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`).Return(errors.New("some-error")),
 				)
 				Expect(vbx.ImportVM(
@@ -226,7 +489,10 @@ var _ = Describe("vbox", func() {
 		Context("when cloning the disk fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")).Return(errors.New("some-error")),
 				)
@@ -241,7 +507,10 @@ var _ = Describe("vbox", func() {
 		Context("when removing the compressed disk fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")).Return(errors.New("some-error")),
@@ -257,7 +526,10 @@ var _ = Describe("vbox", func() {
 		Context("when attaching the disk fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -274,7 +546,10 @@ var _ = Describe("vbox", func() {
 		Context("when geting vbox host-only interfaces fails", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -306,7 +581,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -334,7 +612,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -368,7 +649,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -397,7 +681,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -427,7 +714,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -436,7 +726,7 @@ var _ = Describe("vbox", func() {
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
 					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "some-vm"),
-					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-vm-ip","domain":"some-vm-domain"}`), false).Return(errors.New("some-error")),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)).Return(errors.New("some-error")),
 				)
 
 				Expect(vbx.ImportVM(vmConfig)).To(MatchError("some-error"))
@@ -459,7 +749,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -468,7 +761,7 @@ var _ = Describe("vbox", func() {
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
 					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "some-vm"),
-					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-vm-ip","domain":"some-vm-domain"}`), false),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
 					mockDriver.EXPECT().UseDNSProxy("some-vm").Return(errors.New("some-error")),
 				)
 
@@ -492,7 +785,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -501,7 +797,7 @@ var _ = Describe("vbox", func() {
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
 					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "some-vm"),
-					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-vm-ip","domain":"some-vm-domain"}`), false),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
 					mockDriver.EXPECT().UseDNSProxy("some-vm"),
 					mockSSH.EXPECT().GenerateAddress().Return("", "", errors.New("some-error")),
 				)
@@ -526,7 +822,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -535,7 +834,7 @@ var _ = Describe("vbox", func() {
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
 					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "some-vm"),
-					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-vm-ip","domain":"some-vm-domain"}`), false),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
 					mockDriver.EXPECT().UseDNSProxy("some-vm"),
 					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
 					mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22").Return(errors.New("some-error")),
@@ -560,7 +859,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -569,7 +871,7 @@ var _ = Describe("vbox", func() {
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
 					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "some-vm"),
-					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-vm-ip","domain":"some-vm-domain"}`), false),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
 					mockDriver.EXPECT().UseDNSProxy("some-vm"),
 					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
 					mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
@@ -595,7 +897,10 @@ var _ = Describe("vbox", func() {
 					CPUs:    7,
 				}
 				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
 					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
 					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
 					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
 					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
@@ -604,7 +909,7 @@ var _ = Describe("vbox", func() {
 					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
 					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
 					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "some-vm"),
-					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "vm_config"), strings.NewReader(`{"ip":"some-vm-ip","domain":"some-vm-domain"}`), false),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
 					mockDriver.EXPECT().UseDNSProxy("some-vm"),
 					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
 					mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
@@ -614,6 +919,103 @@ var _ = Describe("vbox", func() {
 				Expect(vbx.ImportVM(vmConfig)).To(MatchError("some-error"))
 			})
 		})
+
+		Context("when enabling the console log returns an error", func() {
+			It("should return an error", func() {
+				vboxnets := []*network.Interface{
+					&network.Interface{
+						Name:   "some-used-vbox-interface",
+						IP:     "some-used-ip",
+						Exists: true,
+					},
+				}
+				vmConfig := &config.VMConfig{
+					Name:    "some-vm",
+					OVAPath: "some-ova-path",
+					Memory:  uint64(2000),
+					CPUs:    7,
+				}
+				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
+					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
+					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
+					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
+					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
+					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "some-vm"),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
+					mockDriver.EXPECT().UseDNSProxy("some-vm"),
+					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
+					mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
+					mockDriver.EXPECT().SetCPUs("some-vm", 7),
+					mockDriver.EXPECT().SetMemory("some-vm", uint64(2000)),
+					mockDriver.EXPECT().EnableConsoleLog("some-vm", filepath.Join("some-vm-dir", "some-vm", "console.log")).Return(errors.New("some-error")),
+				)
+				Expect(vbx.ImportVM(vmConfig)).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when tagging the VM as managed by PCF Dev fails", func() {
+			It("should return an error", func() {
+				vmConfig := &config.VMConfig{
+					Name:    "some-vm",
+					OVAPath: "some-ova-path",
+				}
+				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
+					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli").Return(errors.New("some-error")),
+				)
+				Expect(vbx.ImportVM(vmConfig)).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when storing the VM settings as extra data fails", func() {
+			It("should return an error", func() {
+				vboxnets := []*network.Interface{
+					&network.Interface{
+						Name:   "some-used-vbox-interface",
+						IP:     "some-used-ip",
+						Exists: true,
+					},
+				}
+				vmConfig := &config.VMConfig{
+					Name:    "some-vm",
+					OVAPath: "some-ova-path",
+					Memory:  uint64(2000),
+					CPUs:    7,
+				}
+				gomock.InOrder(
+					mockFS.EXPECT().Length("some-ova-path").Return(int64(1000), nil),
+					mockSystem.EXPECT().CheckDiskSpace("some-vm-dir", uint64(4000)),
+					mockDriver.EXPECT().CreateVM("some-vm", "some-vm-dir"),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/owner", "pcfdev-cli"),
+					mockFS.EXPECT().Extract("some-ova-path", filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), `\w+\.vmdk`),
+					mockDriver.EXPECT().CloneDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed"), filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "some-vm-disk1.vmdk.compressed")),
+					mockDriver.EXPECT().AttachDisk("some-vm", filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")),
+					mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
+					mockPicker.EXPECT().SelectAvailableInterface(vboxnets, vmConfig).Return(&config.NetworkConfig{VMIP: "some-vm-ip", VMDomain: "some-vm-domain", Interface: &network.Interface{IP: "some-unused-ip", Exists: false}}, nil),
+					mockDriver.EXPECT().CreateHostOnlyInterface("some-unused-ip").Return("some-interface", nil),
+					mockDriver.EXPECT().AttachNetworkInterface("some-interface", "some-vm"),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("some-vm-ip", "some-vm-domain", vmConfig)),
+					mockDriver.EXPECT().UseDNSProxy("some-vm"),
+					mockSSH.EXPECT().GenerateAddress().Return("some-host", "some-port", nil),
+					mockDriver.EXPECT().ForwardPort("some-vm", "ssh", "some-port", "22"),
+					mockDriver.EXPECT().SetCPUs("some-vm", 7),
+					mockDriver.EXPECT().SetMemory("some-vm", uint64(2000)),
+					mockDriver.EXPECT().EnableConsoleLog("some-vm", filepath.Join("some-vm-dir", "some-vm", "console.log")),
+					mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/plugin-version", "some-plugin-version").Return(errors.New("some-error")),
+				)
+				Expect(vbx.ImportVM(vmConfig)).To(MatchError("some-error"))
+			})
+		})
 	})
 
 	Describe("#StartVM", func() {
@@ -650,21 +1052,22 @@ iface eth1 inet static
 address 192.168.22.11
 netmask 255.255.255.0' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-					mockSSH.EXPECT().RunSSHCommand(`echo -e '
+					mockSSH.EXPECT().RunSSHCommand(contains("grep -qF ' inet 192.168.22.11/'"), addresses[:1], []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand(setsEnvironment(`
 PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
 HTTP_PROXY=some-http-proxy
 HTTPS_PROXY=some-https-proxy
 NO_PROXY=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy
 http_proxy=some-http-proxy
 https_proxy=some-https-proxy
-no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy' | sudo tee /etc/environment`,
+no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy`),
 						addresses,
 						[]byte("some-private-key"),
 						5*time.Minute,
 						ioutil.Discard,
 						ioutil.Discard),
-					mockDriver.EXPECT().StopVM("some-vm"),
-					mockDriver.EXPECT().StartVM("some-vm"),
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(false, nil),
 				)
 
 				Expect(vbx.StartVM(&config.VMConfig{
@@ -704,21 +1107,49 @@ iface eth1 inet static
 address 192.168.22.11
 netmask 255.255.255.0' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-						mockSSH.EXPECT().RunSSHCommand(`echo -e '
+						mockSSH.EXPECT().RunSSHCommand(contains("grep -qF ' inet 192.168.22.11/'"), addresses[:1], []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(setsEnvironment(`
 PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
 HTTP_PROXY=some-http-proxy
 HTTPS_PROXY=some-https-proxy
 NO_PROXY=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy
 http_proxy=some-http-proxy
 https_proxy=some-https-proxy
-no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy' | sudo tee /etc/environment`,
+no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy`),
 							addresses,
 							[]byte("some-private-key"),
 							5*time.Minute,
 							ioutil.Discard,
 							ioutil.Discard),
-						mockDriver.EXPECT().StopVM("some-vm"),
+						mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(false, nil),
+					)
+
+					Expect(vbx.StartVM(&config.VMConfig{
+						Name:    "some-vm",
+						IP:      "192.168.22.11",
+						SSHPort: "some-port",
+						Domain:  "local2.pcfdev.io",
+					})).To(Succeed())
+				})
+
+				It("should bring up the static IP and reload the environment without power cycling the VM", func() {
+					gomock.InOrder(
 						mockDriver.EXPECT().StartVM("some-vm"),
+						mockFS.EXPECT().Exists("some-private-key-path").Return(true, nil),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(contains("sudo tee /etc/network/interfaces"), gomock.Any(), []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(`sudo ifdown --force eth1 >/dev/null 2>&1
+sudo ifup eth1 >/dev/null 2>&1
+for i in $(seq 1 60); do
+  ip -4 -o addr show dev eth1 | grep -qF ' inet 192.168.22.11/' && exit 0
+  sleep 1
+done
+exit 1`, []ssh.SSHAddress{{IP: "127.0.0.1", Port: "some-port"}}, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(reloadsEnvironment(), gomock.Any(), []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(false, nil),
 					)
 
 					Expect(vbx.StartVM(&config.VMConfig{
@@ -764,21 +1195,22 @@ iface eth1 inet static
 address 192.168.22.11
 netmask 255.255.255.0' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-					mockSSH.EXPECT().RunSSHCommand(`echo -e '
+					mockSSH.EXPECT().RunSSHCommand(contains("grep -qF ' inet 192.168.22.11/'"), addresses[:1], []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand(setsEnvironment(`
 PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
 HTTP_PROXY=192.168.22.1
 HTTPS_PROXY=192.168.22.1:8080
 NO_PROXY=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy
 http_proxy=192.168.22.1
 https_proxy=192.168.22.1:8080
-no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy' | sudo tee /etc/environment`,
+no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy`),
 						addresses,
 						[]byte("some-private-key"),
 						5*time.Minute,
 						ioutil.Discard,
 						ioutil.Discard),
-					mockDriver.EXPECT().StopVM("some-vm"),
-					mockDriver.EXPECT().StartVM("some-vm"),
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(false, nil),
 				)
 
 				Expect(vbx.StartVM(&config.VMConfig{
@@ -789,69 +1221,99 @@ no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2
 				})).To(Succeed())
 			})
 
-			Context("when a bad ip is passed to StartVM command", func() {
-				It("should return an error", func() {
+			Context("when a disk resize is pending", func() {
+				It("should grow the guest partition, power cycle the VM and grow the filesystem", func() {
 					addresses := []ssh.SSHAddress{
 						{
 							IP:   "127.0.0.1",
 							Port: "some-port",
 						},
 						{
-							IP:   "some-bad-ip",
+							IP:   "192.168.22.11",
 							Port: "22",
 						},
 					}
+
 					gomock.InOrder(
 						mockDriver.EXPECT().StartVM("some-vm"),
-						mockFS.EXPECT().Exists("some-private-key-path").Return(false, nil),
-						mockSSH.EXPECT().GenerateKeypair().Return([]byte("some-private-key"), []byte("some-public-key"), nil),
-						mockSSH.EXPECT().RunSSHCommand(`echo -n "some-public-key" > /home/vcap/.ssh/authorized_keys`, addresses, []byte("some-insecure-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
-						mockFS.EXPECT().Write("some-private-key-path", bytes.NewReader([]byte("some-private-key")), false),
-						mockFS.EXPECT().Chmod("some-private-key-path", os.FileMode(0600)),
+						mockFS.EXPECT().Exists("some-private-key-path").Return(true, nil),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-						mockSSH.EXPECT().RunSSHCommand(`echo -e '
-auto lo
-iface lo inet loopback
-
-auto eth0
-iface eth0 inet dhcp
-
-auto eth1
-iface eth1 inet static
-address some-bad-ip
-netmask 255.255.255.0' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockSSH.EXPECT().RunSSHCommand(contains("sudo tee /etc/network/interfaces"), addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(contains("sudo ifup eth1"), addresses[:1], []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(reloadsEnvironment(), addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(true, nil),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(contains("sfdisk --force --no-reread -uS /dev/sda"), addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockDriver.EXPECT().StopVM("some-vm"),
+						mockDriver.EXPECT().StartVM("some-vm"),
+						mockSSH.EXPECT().RunSSHCommand(contains("resize2fs /dev/sda1"), addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Remove(filepath.Join("some-vm-dir", "disk_resize_pending")),
 					)
 
 					Expect(vbx.StartVM(&config.VMConfig{
 						Name:    "some-vm",
-						IP:      "some-bad-ip",
+						IP:      "192.168.22.11",
 						SSHPort: "some-port",
-						Domain:  "some-domain",
-					})).To(MatchError("some-bad-ip is not a supported IP address"))
+						Domain:  "local2.pcfdev.io",
+					})).To(Succeed())
+				})
+
+				Context("when growing the guest partition fails", func() {
+					It("should return the error and leave the resize pending", func() {
+						gomock.InOrder(
+							mockDriver.EXPECT().StartVM("some-vm"),
+							mockFS.EXPECT().Exists("some-private-key-path").Return(true, nil),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+							mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(true, nil),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some-error")),
+						)
+
+						Expect(vbx.StartVM(&config.VMConfig{
+							Name:    "some-vm",
+							IP:      "192.168.22.11",
+							SSHPort: "some-port",
+							Domain:  "local2.pcfdev.io",
+						})).To(MatchError("some-error"))
+					})
 				})
 			})
 
-			Context("when the http proxy field is empty", func() {
-				It("should not appear in the environment file", func() {
-					addresses := []ssh.SSHAddress{
+			Context("when the VM uses bridged networking", func() {
+				var (
+					vmConfig  *config.VMConfig
+					addresses []ssh.SSHAddress
+				)
+
+				BeforeEach(func() {
+					vmConfig = &config.VMConfig{
+						Name:          "some-vm",
+						SSHPort:       "some-port",
+						CPUs:          2,
+						Memory:        uint64(4096),
+						OVAPath:       "some-ova-path",
+						Network:       "bridged",
+						BridgeAdapter: "some-adapter",
+					}
+					addresses = []ssh.SSHAddress{
 						{
 							IP:   "127.0.0.1",
 							Port: "some-port",
 						},
-						{
-							IP:   "192.168.22.11",
-							Port: "22",
-						},
 					}
-					conf.HTTPProxy = ""
-					conf.HTTPSProxy = "127.0.0.1"
+				})
+
+				It("should configure DHCP and record the leased IP and its wildcard domain", func() {
 					gomock.InOrder(
 						mockDriver.EXPECT().StartVM("some-vm"),
-						mockFS.EXPECT().Exists("some-private-key-path").Return(false, nil),
-						mockSSH.EXPECT().GenerateKeypair().Return([]byte("some-private-key"), []byte("some-public-key"), nil),
-						mockSSH.EXPECT().RunSSHCommand(`echo -n "some-public-key" > /home/vcap/.ssh/authorized_keys`, addresses, []byte("some-insecure-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
-						mockFS.EXPECT().Write("some-private-key-path", bytes.NewReader([]byte("some-private-key")), false),
-						mockFS.EXPECT().Chmod("some-private-key-path", os.FileMode(0600)),
+						mockFS.EXPECT().Exists("some-private-key-path").Return(true, nil),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 						mockSSH.EXPECT().RunSSHCommand(`echo -e '
 auto lo
@@ -861,25 +1323,221 @@ auto eth0
 iface eth0 inet dhcp
 
 auto eth1
-iface eth1 inet static
-address 192.168.22.11
+iface eth1 inet dhcp' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(contains("sudo ifup eth1"), addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), ioutil.Discard).Do(
+							func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, stdout io.Writer, _ io.Writer) {
+								stdout.Write([]byte("10.0.0.5\n"))
+							},
+						),
+						mockPicker.EXPECT().DomainForIP("10.0.0.5").Return("10.0.0.5.xip.io"),
+						mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("10.0.0.5", "10.0.0.5.xip.io", &config.VMConfig{
+							CPUs:          2,
+							Memory:        uint64(4096),
+							OVAPath:       "some-ova-path",
+							Network:       "bridged",
+							BridgeAdapter: "some-adapter",
+						})),
+						mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/domain", "10.0.0.5.xip.io"),
+						mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ip", "10.0.0.5"),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(setsEnvironment(`
+PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
+HTTP_PROXY=http://10.0.2.2:3128
+HTTPS_PROXY=some-https-proxy
+NO_PROXY=localhost,127.0.0.1,10.0.2.2,10.0.0.5,10.0.0.5.xip.io,.10.0.0.5.xip.io,some-no-proxy
+http_proxy=http://10.0.2.2:3128
+https_proxy=some-https-proxy
+no_proxy=localhost,127.0.0.1,10.0.2.2,10.0.0.5,10.0.0.5.xip.io,.10.0.0.5.xip.io,some-no-proxy`),
+							[]ssh.SSHAddress{
+								{
+									IP:   "127.0.0.1",
+									Port: "some-port",
+								},
+								{
+									IP:   "10.0.0.5",
+									Port: "22",
+								},
+							},
+							[]byte("some-private-key"),
+							5*time.Minute,
+							ioutil.Discard,
+							ioutil.Discard),
+						mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(false, nil),
+					)
+
+					conf.HTTPProxy = "http://127.0.0.1:3128"
+					Expect(vbx.StartVM(vmConfig)).To(Succeed())
+					Expect(vmConfig.IP).To(Equal("10.0.0.5"))
+					Expect(vmConfig.Domain).To(Equal("10.0.0.5.xip.io"))
+				})
+
+				Context("when a domain was chosen for the VM", func() {
+					It("should keep the domain when the leased IP changes", func() {
+						vmConfig.IP = "10.0.0.4"
+						vmConfig.Domain = "some-domain"
+						addresses = append(addresses, ssh.SSHAddress{IP: "10.0.0.4", Port: "22"})
+
+						gomock.InOrder(
+							mockDriver.EXPECT().StartVM("some-vm"),
+							mockFS.EXPECT().Exists("some-private-key-path").Return(true, nil),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(contains("iface eth1 inet dhcp"), addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(contains("sudo ifup eth1"), addresses[:1], []byte("some-private-key"), 5*time.Minute, gomock.Any(), ioutil.Discard).Do(
+								func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, stdout io.Writer, _ io.Writer) {
+									stdout.Write([]byte("10.0.0.5\n"))
+								},
+							),
+							mockPicker.EXPECT().DomainForIP("10.0.0.4").Return("10.0.0.4.xip.io"),
+							mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("10.0.0.5", "some-domain", &config.VMConfig{
+								CPUs:          2,
+								Memory:        uint64(4096),
+								OVAPath:       "some-ova-path",
+								Network:       "bridged",
+								BridgeAdapter: "some-adapter",
+							})),
+							mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/domain", "some-domain"),
+							mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/ip", "10.0.0.5"),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(reloadsEnvironment(), gomock.Any(), []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+							mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(false, nil),
+						)
+
+						Expect(vbx.StartVM(vmConfig)).To(Succeed())
+						Expect(vmConfig.Domain).To(Equal("some-domain"))
+					})
+				})
+
+				Context("when the VM does not get an IP from DHCP", func() {
+					It("should return an error", func() {
+						gomock.InOrder(
+							mockDriver.EXPECT().StartVM("some-vm"),
+							mockFS.EXPECT().Exists("some-private-key-path").Return(true, nil),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(contains("iface eth1 inet dhcp"), addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(contains("sudo ifup eth1"), addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), ioutil.Discard).Return(errors.New("some-error")),
+						)
+
+						Expect(vbx.StartVM(vmConfig)).To(MatchError("failed to get an IP address for the bridged network from DHCP: some-error"))
+					})
+				})
+
+				Context("when the VM reports something other than an IP", func() {
+					It("should return an error", func() {
+						gomock.InOrder(
+							mockDriver.EXPECT().StartVM("some-vm"),
+							mockFS.EXPECT().Exists("some-private-key-path").Return(true, nil),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(contains("iface eth1 inet dhcp"), addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+							mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+							mockSSH.EXPECT().RunSSHCommand(contains("sudo ifup eth1"), addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), ioutil.Discard).Do(
+								func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, stdout io.Writer, _ io.Writer) {
+									stdout.Write([]byte("some-garbage\n"))
+								},
+							),
+						)
+
+						Expect(vbx.StartVM(vmConfig)).To(MatchError("failed to get an IP address for the bridged network from DHCP: 'some-garbage' is not an IPv4 address"))
+					})
+				})
+			})
+
+			Context("when a bad ip is passed to StartVM command", func() {
+				It("should return an error", func() {
+					addresses := []ssh.SSHAddress{
+						{
+							IP:   "127.0.0.1",
+							Port: "some-port",
+						},
+						{
+							IP:   "some-bad-ip",
+							Port: "22",
+						},
+					}
+					gomock.InOrder(
+						mockDriver.EXPECT().StartVM("some-vm"),
+						mockFS.EXPECT().Exists("some-private-key-path").Return(false, nil),
+						mockSSH.EXPECT().GenerateKeypair().Return([]byte("some-private-key"), []byte("some-public-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(`echo -n "some-public-key" > /home/vcap/.ssh/authorized_keys`, addresses, []byte("some-insecure-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Write("some-private-key-path", bytes.NewReader([]byte("some-private-key")), false),
+						mockFS.EXPECT().Chmod("some-private-key-path", os.FileMode(0600)),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(`echo -e '
+auto lo
+iface lo inet loopback
+
+auto eth0
+iface eth0 inet dhcp
+
+auto eth1
+iface eth1 inet static
+address some-bad-ip
 netmask 255.255.255.0' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(contains("grep -qF ' inet some-bad-ip/'"), addresses[:1], []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+					)
+
+					Expect(vbx.StartVM(&config.VMConfig{
+						Name:    "some-vm",
+						IP:      "some-bad-ip",
+						SSHPort: "some-port",
+						Domain:  "some-domain",
+					})).To(MatchError("some-bad-ip is not a supported IP address"))
+				})
+			})
+
+			Context("when the http proxy field is empty", func() {
+				It("should not appear in the environment file", func() {
+					addresses := []ssh.SSHAddress{
+						{
+							IP:   "127.0.0.1",
+							Port: "some-port",
+						},
+						{
+							IP:   "192.168.22.11",
+							Port: "22",
+						},
+					}
+					conf.HTTPProxy = ""
+					conf.HTTPSProxy = "127.0.0.1"
+					gomock.InOrder(
+						mockDriver.EXPECT().StartVM("some-vm"),
+						mockFS.EXPECT().Exists("some-private-key-path").Return(false, nil),
+						mockSSH.EXPECT().GenerateKeypair().Return([]byte("some-private-key"), []byte("some-public-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(`echo -n "some-public-key" > /home/vcap/.ssh/authorized_keys`, addresses, []byte("some-insecure-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Write("some-private-key-path", bytes.NewReader([]byte("some-private-key")), false),
+						mockFS.EXPECT().Chmod("some-private-key-path", os.FileMode(0600)),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 						mockSSH.EXPECT().RunSSHCommand(`echo -e '
+auto lo
+iface lo inet loopback
+
+auto eth0
+iface eth0 inet dhcp
+
+auto eth1
+iface eth1 inet static
+address 192.168.22.11
+netmask 255.255.255.0' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(contains("grep -qF ' inet 192.168.22.11/'"), addresses[:1], []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(setsEnvironment(`
 PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
 
 HTTPS_PROXY=192.168.22.1
 NO_PROXY=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy
 
 https_proxy=192.168.22.1
-no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy' | sudo tee /etc/environment`,
+no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy`),
 							addresses,
 							[]byte("some-private-key"),
 							5*time.Minute,
 							ioutil.Discard,
 							ioutil.Discard),
-						mockDriver.EXPECT().StopVM("some-vm"),
-						mockDriver.EXPECT().StartVM("some-vm"),
+						mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(false, nil),
 					)
 
 					Expect(vbx.StartVM(&config.VMConfig{
@@ -926,21 +1584,22 @@ iface eth1 inet static
 address 192.168.22.11
 netmask 255.255.255.0' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-						mockSSH.EXPECT().RunSSHCommand(`echo -e '
+						mockSSH.EXPECT().RunSSHCommand(contains("grep -qF ' inet 192.168.22.11/'"), addresses[:1], []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(setsEnvironment(`
 PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
 HTTP_PROXY=192.168.22.1
 
 NO_PROXY=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy
 http_proxy=192.168.22.1
 
-no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy' | sudo tee /etc/environment`,
+no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io,some-no-proxy`),
 							addresses,
 							[]byte("some-private-key"),
 							5*time.Minute,
 							ioutil.Discard,
 							ioutil.Discard),
-						mockDriver.EXPECT().StopVM("some-vm"),
-						mockDriver.EXPECT().StartVM("some-vm"),
+						mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(false, nil),
 					)
 
 					Expect(vbx.StartVM(&config.VMConfig{
@@ -988,21 +1647,22 @@ iface eth1 inet static
 address 192.168.22.11
 netmask 255.255.255.0' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-						mockSSH.EXPECT().RunSSHCommand(`echo -e '
+						mockSSH.EXPECT().RunSSHCommand(contains("grep -qF ' inet 192.168.22.11/'"), addresses[:1], []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(setsEnvironment(`
 PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
 HTTP_PROXY=192.168.22.1
 HTTPS_PROXY=192.168.22.1
 NO_PROXY=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io
 http_proxy=192.168.22.1
 https_proxy=192.168.22.1
-no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io' | sudo tee /etc/environment`,
+no_proxy=localhost,127.0.0.1,192.168.22.1,192.168.22.11,local2.pcfdev.io,.local2.pcfdev.io`),
 							addresses,
 							[]byte("some-private-key"),
 							5*time.Minute,
 							ioutil.Discard,
 							ioutil.Discard),
-						mockDriver.EXPECT().StopVM("some-vm"),
-						mockDriver.EXPECT().StartVM("some-vm"),
+						mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "disk_resize_pending")).Return(false, nil),
 					)
 
 					Expect(vbx.StartVM(&config.VMConfig{
@@ -1266,7 +1926,7 @@ netmask 255.255.255.0' | sudo tee /etc/network/interfaces`), addresses, []byte("
 				})
 			})
 
-			Context("when VM fails to stop", func() {
+			Context("when the static IP address does not come up", func() {
 				It("should return an error", func() {
 					addresses := []ssh.SSHAddress{
 						{
@@ -1298,27 +1958,14 @@ iface eth1 inet static
 address 192.168.11.11
 netmask 255.255.255.0' | sudo tee /etc/network/interfaces`, addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
-						mockSSH.EXPECT().RunSSHCommand(`echo -e '
-PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin:/usr/games:/usr/local/games
-HTTP_PROXY=some-http-proxy
-HTTPS_PROXY=some-https-proxy
-NO_PROXY=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.pcfdev.io,some-no-proxy
-http_proxy=some-http-proxy
-https_proxy=some-https-proxy
-no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.pcfdev.io,some-no-proxy' | sudo tee /etc/environment`,
-							addresses,
-							[]byte("some-private-key"),
-							5*time.Minute,
-							ioutil.Discard,
-							ioutil.Discard),
-						mockDriver.EXPECT().StopVM("some-vm").Return(errors.New("some-error")),
+						mockSSH.EXPECT().RunSSHCommand(contains("grep -qF ' inet 192.168.11.11/'"), addresses[:1], []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard).Return(errors.New("some-error")),
 					)
 					Expect(vbx.StartVM(&config.VMConfig{
 						Name:    "some-vm",
 						IP:      "192.168.11.11",
 						SSHPort: "some-port",
 						Domain:  "local.pcfdev.io",
-					})).To(MatchError("some-error"))
+					})).To(MatchError("failed to bring up the IP address 192.168.11.11: some-error"))
 				})
 			})
 		})
@@ -1458,23 +2105,223 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 	})
 
 	Describe("#VMConfig", func() {
-		It("should get the vm config", func() {
+		It("should rebuild the vm config from the VM extra data", func() {
 			gomock.InOrder(
 				mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
 				mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
-				mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"ip":"192.168.22.11","domain":"local2.pcfdev.io"}`), nil),
+				mockDriver.EXPECT().ExtraData("some-vm").Return(map[string]string{
+					"pcfdev/owner":           "pcfdev-cli",
+					"pcfdev/ip":              "192.168.22.11",
+					"pcfdev/domain":          "local2.pcfdev.io",
+					"pcfdev/cpus":            "3",
+					"pcfdev/memory":          "4000",
+					"pcfdev/ova-path":        "some-ova-path",
+					"pcfdev/ova-version":     "some-older-ova-version",
+					"pcfdev/master-password": "true",
+				}, nil),
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "vm_config")).Return(false, nil),
 			)
 
 			Expect(vbx.VMConfig("some-vm")).To(Equal(&config.VMConfig{
-				Domain:   "local2.pcfdev.io",
-				IP:       "192.168.22.11",
-				Memory:   uint64(4000),
-				Name:     "some-vm",
-				SSHPort:  "some-port",
-				Provider: "virtualbox",
+				Domain:         "local2.pcfdev.io",
+				IP:             "192.168.22.11",
+				CPUs:           3,
+				OVAPath:        "some-ova-path",
+				OVAVersion:     "some-older-ova-version",
+				Memory:         uint64(4000),
+				Name:           "some-vm",
+				SSHPort:        "some-port",
+				Provider:       "virtualbox",
+				MasterPassword: true,
 			}))
 		})
 
+		Context("when the VM has extra data and an outdated vm_config file", func() {
+			It("should migrate the file and write it back", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+					mockDriver.EXPECT().ExtraData("some-vm").Return(map[string]string{
+						"pcfdev/owner":  "pcfdev-cli",
+						"pcfdev/ip":     "192.168.22.11",
+						"pcfdev/domain": "local2.pcfdev.io",
+					}, nil),
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "vm_config")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"version":2,"ip":"192.168.22.11","domain":"local2.pcfdev.io","cpus":3,"memory":4000,"ova_path":"some-ova-path"}`), nil),
+					mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("192.168.22.11", "local2.pcfdev.io", &config.VMConfig{
+						CPUs:    3,
+						Memory:  uint64(4000),
+						OVAPath: "some-ova-path",
+					})),
+				)
+
+				vmConfig, err := vbx.VMConfig("some-vm")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmConfig.IP).To(Equal("192.168.22.11"))
+			})
+		})
+
+		Context("when the VM has extra data without an OVA version", func() {
+			It("should take the OVA version from the vm_config file", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+					mockDriver.EXPECT().ExtraData("some-vm").Return(map[string]string{
+						"pcfdev/owner":  "pcfdev-cli",
+						"pcfdev/ip":     "192.168.22.11",
+						"pcfdev/domain": "local2.pcfdev.io",
+					}, nil),
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "vm_config")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"version":3,"ip":"192.168.22.11","domain":"local2.pcfdev.io","network":"hostonly","ova_version":"some-ova-version"}`), nil),
+				)
+
+				vmConfig, err := vbx.VMConfig("some-vm")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmConfig.OVAVersion).To(Equal("some-ova-version"))
+			})
+		})
+
+		Context("when the VM has extra data and a vm_config file that cannot be read", func() {
+			expectVMConfigFile := func(contents string) {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+					mockDriver.EXPECT().ExtraData("some-vm").Return(map[string]string{
+						"pcfdev/owner":  "pcfdev-cli",
+						"pcfdev/ip":     "192.168.22.11",
+						"pcfdev/domain": "local2.pcfdev.io",
+					}, nil),
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "vm_config")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(contents), nil),
+				)
+			}
+
+			It("should ignore a corrupt file", func() {
+				expectVMConfigFile("some-invalid-json")
+
+				vmConfig, err := vbx.VMConfig("some-vm")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmConfig.IP).To(Equal("192.168.22.11"))
+				Expect(vmConfig.Domain).To(Equal("local2.pcfdev.io"))
+			})
+
+			It("should ignore a file from a newer version of the plugin", func() {
+				expectVMConfigFile(`{"version":99,"ip":"192.168.22.11","domain":"local2.pcfdev.io"}`)
+
+				vmConfig, err := vbx.VMConfig("some-vm")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmConfig.IP).To(Equal("192.168.22.11"))
+				Expect(vmConfig.Domain).To(Equal("local2.pcfdev.io"))
+			})
+		})
+
+		Context("when the VM has no extra data", func() {
+			It("should read the ip and domain from the vm_config file", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+					mockDriver.EXPECT().ExtraData("some-vm").Return(map[string]string{}, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"version":3,"ip":"192.168.22.11","domain":"local2.pcfdev.io","cpus":3,"memory":4000,"ova_path":"some-ova-path","network":"hostonly"}`), nil),
+				)
+
+				Expect(vbx.VMConfig("some-vm")).To(Equal(&config.VMConfig{
+					Domain:   "local2.pcfdev.io",
+					IP:       "192.168.22.11",
+					CPUs:     3,
+					OVAPath:  "some-ova-path",
+					Memory:   uint64(4000),
+					Name:     "some-vm",
+					SSHPort:  "some-port",
+					Provider: "virtualbox",
+					Network:  "hostonly",
+				}))
+			})
+
+			Context("when the VM has the name the plugin gives the VM it downloads", func() {
+				It("should take the OVA version from the plugin", func() {
+					gomock.InOrder(
+						mockDriver.EXPECT().GetMemory("pcfdev-default").Return(uint64(4000), nil),
+						mockDriver.EXPECT().GetHostForwardPort("pcfdev-default", "ssh").Return("some-port", nil),
+						mockDriver.EXPECT().ExtraData("pcfdev-default").Return(map[string]string{}, nil),
+						mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"version":3,"ip":"192.168.22.11","domain":"local2.pcfdev.io","network":"hostonly"}`), nil),
+					)
+
+					vmConfig, err := vbx.VMConfig("pcfdev-default")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(vmConfig.OVAVersion).To(Equal("some-ova-version"))
+				})
+			})
+
+			Context("when the vm_config file has an older version", func() {
+				It("should migrate the file and write it back", func() {
+					gomock.InOrder(
+						mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+						mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+						mockDriver.EXPECT().ExtraData("some-vm").Return(map[string]string{}, nil),
+						mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"ip":"192.168.22.11","domain":"local2.pcfdev.io"}`), nil),
+						mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), vmConfigFileContents("192.168.22.11", "local2.pcfdev.io", &config.VMConfig{
+							Memory:  uint64(4000),
+							OVAPath: filepath.Join("some-ova-dir", "some-vm.ova"),
+						})),
+					)
+
+					Expect(vbx.VMConfig("some-vm")).To(Equal(&config.VMConfig{
+						Domain:   "local2.pcfdev.io",
+						IP:       "192.168.22.11",
+						OVAPath:  filepath.Join("some-ova-dir", "some-vm.ova"),
+						Memory:   uint64(4000),
+						Name:     "some-vm",
+						SSHPort:  "some-port",
+						Provider: "virtualbox",
+						Network:  "hostonly",
+					}))
+				})
+
+				Context("when writing the migrated file fails", func() {
+					It("should return an error", func() {
+						gomock.InOrder(
+							mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+							mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+							mockDriver.EXPECT().ExtraData("some-vm").Return(map[string]string{}, nil),
+							mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"ip":"192.168.22.11","domain":"local2.pcfdev.io"}`), nil),
+							mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), gomock.Any()).Return(errors.New("some-error")),
+						)
+
+						_, err := vbx.VMConfig("some-vm")
+						Expect(err).To(MatchError("some-error"))
+					})
+				})
+			})
+
+			Context("when retrieving the ip and domain fails", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+						mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+						mockDriver.EXPECT().ExtraData("some-vm").Return(map[string]string{}, nil),
+						mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return(nil, errors.New("some-error")),
+					)
+
+					_, err := vbx.VMConfig("some-vm")
+					Expect(err).To(MatchError("some-error"))
+				})
+			})
+
+			Context("when retrieving the vm_config file is not valid json", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+						mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
+						mockDriver.EXPECT().ExtraData("some-vm").Return(map[string]string{}, nil),
+						mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`some-invalid-json`), nil),
+					)
+
+					_, err := vbx.VMConfig("some-vm")
+					Expect(err).To(MatchError("invalid vm_config: invalid character 's' looking for beginning of value"))
+				})
+			})
+		})
+
 		Context("when the driver fails to get the memory", func() {
 			It("should return an error", func() {
 				mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(0), errors.New("some-error"))
@@ -1496,33 +2343,413 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 			})
 		})
 
-		Context("when retrieving the ip and domain fails", func() {
+		Context("when the driver fails to get the extra data", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
 					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
-					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return(nil, errors.New("some-error")),
+					mockDriver.EXPECT().ExtraData("some-vm").Return(nil, errors.New("some-error")),
 				)
 
 				_, err := vbx.VMConfig("some-vm")
 				Expect(err).To(MatchError("some-error"))
 			})
 		})
+	})
 
-		Context("when retrieving the vm_config file is not valid json", func() {
+	Describe("#VMConfigFile", func() {
+		It("should return the stored vm_config document after migrating it", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
+				mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`{"ip":"192.168.22.11","domain":"local2.pcfdev.io"}`), nil),
+				mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), gomock.Any()),
+			)
+
+			Expect(vbx.VMConfigFile("some-vm")).To(Equal(&config.VMConfigFile{
+				Version: 3,
+				IP:      "192.168.22.11",
+				Domain:  "local2.pcfdev.io",
+				Memory:  uint64(4000),
+				OVAPath: filepath.Join("some-ova-dir", "some-vm.ova"),
+				Network: "hostonly",
+			}))
+		})
+
+		Context("when the vm_config file cannot be read", func() {
 			It("should return an error", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().GetMemory("some-vm").Return(uint64(4000), nil),
-					mockDriver.EXPECT().GetHostForwardPort("some-vm", "ssh").Return("some-port", nil),
-					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return([]byte(`some-invalid-json`), nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "vm_config")).Return(nil, errors.New("some-error")),
 				)
 
-				_, err := vbx.VMConfig("some-vm")
+				_, err := vbx.VMConfigFile("some-vm")
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#RecordMasterPassword", func() {
+		It("should record the master password in the extra data and the vm_config file", func() {
+			vmConfig := &config.VMConfig{
+				Name:    "some-vm",
+				IP:      "192.168.22.11",
+				Domain:  "local2.pcfdev.io",
+				Network: "hostonly",
+			}
+			gomock.InOrder(
+				mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/master-password", "true"),
+				mockFS.EXPECT().WriteAtomic(filepath.Join("some-vm-dir", "vm_config"), bytes.NewReader([]byte(`{
+  "version": 3,
+  "ip": "192.168.22.11",
+  "domain": "local2.pcfdev.io",
+  "cpus": 0,
+  "memory": 0,
+  "ova_path": "",
+  "network": "hostonly",
+  "master_password": true
+}`))),
+			)
+
+			Expect(vbx.RecordMasterPassword(vmConfig)).To(Succeed())
+			Expect(vmConfig.MasterPassword).To(BeTrue())
+		})
+
+		Context("when setting the extra data fails", func() {
+			It("should return an error", func() {
+				mockDriver.EXPECT().SetExtraData("some-vm", "pcfdev/master-password", "true").Return(errors.New("some-error"))
+
+				Expect(vbx.RecordMasterPassword(&config.VMConfig{Name: "some-vm"})).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#DiskSize", func() {
+		It("should return the size of the attached disk", func() {
+			gomock.InOrder(
+				mockDriver.EXPECT().GetVMDisk("some-vm").Return("some-disk.vmdk", nil),
+				mockDriver.EXPECT().GetDiskSize("some-disk.vmdk").Return(uint64(40960), nil),
+			)
+
+			Expect(vbx.DiskSize(&config.VMConfig{Name: "some-vm"})).To(Equal(uint64(40960)))
+		})
+
+		Context("when the driver fails to find the attached disk", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().GetVMDisk("some-vm").Return("", errors.New("some-error"))
+
+				_, err := vbx.DiskSize(&config.VMConfig{Name: "some-vm"})
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#ResizeDisk", func() {
+		It("should convert the disk to VDI, resize it and mark the guest for growing", func() {
+			diskPath := filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vmdk")
+			resizableDiskPath := filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")
+
+			gomock.InOrder(
+				mockDriver.EXPECT().GetVMDisk("some-vm").Return(diskPath, nil),
+				mockDriver.EXPECT().GetDiskFormat(diskPath).Return("VMDK", nil),
+				mockDriver.EXPECT().ConvertDisk(diskPath, resizableDiskPath, "VDI"),
+				mockDriver.EXPECT().ReattachDisk("some-vm", resizableDiskPath),
+				mockDriver.EXPECT().DeleteDisk(diskPath),
+				mockDriver.EXPECT().ResizeDisk(resizableDiskPath, uint64(81920)),
+				mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "disk_resize_pending"), strings.NewReader(""), false),
+			)
+
+			Expect(vbx.ResizeDisk(&config.VMConfig{Name: "some-vm"}, uint64(81920))).To(Succeed())
+		})
+
+		Context("when the disk is already resizable", func() {
+			It("should resize it in place", func() {
+				diskPath := filepath.Join("some-vm-dir", "some-vm", "some-vm-disk1.vdi")
+
+				gomock.InOrder(
+					mockDriver.EXPECT().GetVMDisk("some-vm").Return(diskPath, nil),
+					mockDriver.EXPECT().GetDiskFormat(diskPath).Return("VDI", nil),
+					mockDriver.EXPECT().ResizeDisk(diskPath, uint64(81920)),
+					mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "disk_resize_pending"), strings.NewReader(""), false),
+				)
+
+				Expect(vbx.ResizeDisk(&config.VMConfig{Name: "some-vm"}, uint64(81920))).To(Succeed())
+			})
+		})
+
+		Context("when converting the disk fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetVMDisk("some-vm").Return("some-disk.vmdk", nil),
+					mockDriver.EXPECT().GetDiskFormat("some-disk.vmdk").Return("VMDK", nil),
+					mockDriver.EXPECT().ConvertDisk("some-disk.vmdk", "some-disk.vdi", "VDI").Return(errors.New("some-error")),
+				)
+
+				Expect(vbx.ResizeDisk(&config.VMConfig{Name: "some-vm"}, uint64(81920))).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when reattaching the disk fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetVMDisk("some-vm").Return("some-disk.vmdk", nil),
+					mockDriver.EXPECT().GetDiskFormat("some-disk.vmdk").Return("VMDK", nil),
+					mockDriver.EXPECT().ConvertDisk("some-disk.vmdk", "some-disk.vdi", "VDI"),
+					mockDriver.EXPECT().ReattachDisk("some-vm", "some-disk.vdi").Return(errors.New("some-error")),
+				)
+
+				Expect(vbx.ResizeDisk(&config.VMConfig{Name: "some-vm"}, uint64(81920))).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when resizing the disk fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().GetVMDisk("some-vm").Return("some-disk.vdi", nil),
+					mockDriver.EXPECT().GetDiskFormat("some-disk.vdi").Return("VDI", nil),
+					mockDriver.EXPECT().ResizeDisk("some-disk.vdi", uint64(81920)).Return(errors.New("some-error")),
+				)
+
+				Expect(vbx.ResizeDisk(&config.VMConfig{Name: "some-vm"}, uint64(81920))).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#SharedFolders", func() {
+		It("should return the saved shared folders", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+				mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-some-guest-path","host_path":"some-host-path","guest_path":"/some-guest-path","read_only":true}]`), nil),
+			)
+
+			Expect(vbx.SharedFolders(&config.VMConfig{Name: "some-vm"})).To(Equal([]*config.SharedFolder{
+				{Name: "pcfdev-some-guest-path", HostPath: "some-host-path", GuestPath: "/some-guest-path", ReadOnly: true},
+			}))
+		})
+
+		Context("when no shared folders have been saved", func() {
+			It("should return no shared folders", func() {
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(false, nil)
+
+				Expect(vbx.SharedFolders(&config.VMConfig{Name: "some-vm"})).To(BeEmpty())
+			})
+		})
+
+		Context("when the saved shared folders are not valid json", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`some-invalid-json`), nil),
+				)
+
+				_, err := vbx.SharedFolders(&config.VMConfig{Name: "some-vm"})
 				Expect(err).To(MatchError("invalid character 's' looking for beginning of value"))
 			})
 		})
 	})
 
+	Describe("#AddSharedFolder", func() {
+		It("should add the shared folder to the VM and save it", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+				mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-some-guest-path","host_path":"some-host-path","guest_path":"/some-guest-path","read_only":false}]`), nil),
+				mockFS.EXPECT().Exists("some-other-host-path").Return(true, nil),
+				mockDriver.EXPECT().AddSharedFolder("some-vm", "pcfdev-var-vcap-some-other-guest-path-4124afae", "some-other-host-path", true),
+				mockFS.EXPECT().Write(
+					filepath.Join("some-vm-dir", "shared_folders"),
+					bytes.NewReader([]byte(`[{"name":"pcfdev-some-guest-path","host_path":"some-host-path","guest_path":"/some-guest-path","read_only":false},{"name":"pcfdev-var-vcap-some-other-guest-path-4124afae","host_path":"some-other-host-path","guest_path":"/var/vcap/some-other-guest-path","read_only":true}]`)),
+					false,
+				),
+			)
+
+			Expect(vbx.AddSharedFolder(&config.VMConfig{Name: "some-vm"}, &config.SharedFolder{
+				HostPath:  "some-other-host-path",
+				GuestPath: "/var/vcap/some-other-guest-path",
+				ReadOnly:  true,
+			})).To(Succeed())
+		})
+
+		Context("when a shared folder is already mounted at the guest path", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-some-guest-path","host_path":"some-host-path","guest_path":"/some-guest-path","read_only":false}]`), nil),
+				)
+
+				Expect(vbx.AddSharedFolder(&config.VMConfig{Name: "some-vm"}, &config.SharedFolder{
+					HostPath:  "some-other-host-path",
+					GuestPath: "/some-guest-path",
+				})).To(MatchError("a shared folder is already mounted at /some-guest-path"))
+			})
+		})
+
+		Context("when another guest path reads the same once sanitized", func() {
+			It("should give the shared folder a different name", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-a-b-71b7c48b","host_path":"some-host-path","guest_path":"/a-b","read_only":false}]`), nil),
+					mockFS.EXPECT().Exists("some-other-host-path").Return(true, nil),
+					mockDriver.EXPECT().AddSharedFolder("some-vm", "pcfdev-a-b-31a6ad9d", "some-other-host-path", false),
+					mockFS.EXPECT().Write(
+						filepath.Join("some-vm-dir", "shared_folders"),
+						bytes.NewReader([]byte(`[{"name":"pcfdev-a-b-71b7c48b","host_path":"some-host-path","guest_path":"/a-b","read_only":false},{"name":"pcfdev-a-b-31a6ad9d","host_path":"some-other-host-path","guest_path":"/a_b","read_only":false}]`)),
+						false,
+					),
+				)
+
+				Expect(vbx.AddSharedFolder(&config.VMConfig{Name: "some-vm"}, &config.SharedFolder{
+					HostPath:  "some-other-host-path",
+					GuestPath: "/a_b",
+				})).To(Succeed())
+			})
+		})
+
+		Context("when a shared folder with the same name exists", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-a-b-31a6ad9d","host_path":"some-host-path","guest_path":"/some-guest-path","read_only":false}]`), nil),
+					mockFS.EXPECT().Exists("some-other-host-path").Return(true, nil),
+				)
+
+				Expect(vbx.AddSharedFolder(&config.VMConfig{Name: "some-vm"}, &config.SharedFolder{
+					HostPath:  "some-other-host-path",
+					GuestPath: "/a_b",
+				})).To(MatchError("a shared folder named pcfdev-a-b-31a6ad9d already exists"))
+			})
+		})
+
+		Context("when the host path does not exist", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(false, nil),
+					mockFS.EXPECT().Exists("some-host-path").Return(false, nil),
+				)
+
+				Expect(vbx.AddSharedFolder(&config.VMConfig{Name: "some-vm"}, &config.SharedFolder{
+					HostPath:  "some-host-path",
+					GuestPath: "/some-guest-path",
+				})).To(MatchError("some-host-path does not exist"))
+			})
+		})
+
+		Context("when the driver fails to add the shared folder", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(false, nil),
+					mockFS.EXPECT().Exists("some-host-path").Return(true, nil),
+					mockDriver.EXPECT().AddSharedFolder("some-vm", "pcfdev-some-guest-path-d091a2c2", "some-host-path", false).Return(errors.New("some-error")),
+				)
+
+				Expect(vbx.AddSharedFolder(&config.VMConfig{Name: "some-vm"}, &config.SharedFolder{
+					HostPath:  "some-host-path",
+					GuestPath: "/some-guest-path",
+				})).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#RemoveSharedFolder", func() {
+		It("should remove the shared folder from the VM and save the remaining shared folders", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+				mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-some-guest-path","host_path":"some-host-path","guest_path":"/some-guest-path","read_only":false}]`), nil),
+				mockDriver.EXPECT().RemoveSharedFolder("some-vm", "pcfdev-some-guest-path"),
+				mockFS.EXPECT().Write(filepath.Join("some-vm-dir", "shared_folders"), bytes.NewReader([]byte(`[]`)), false),
+			)
+
+			Expect(vbx.RemoveSharedFolder(&config.VMConfig{Name: "some-vm"}, "/some-guest-path")).To(Succeed())
+		})
+
+		Context("when no shared folder is mounted at the guest path", func() {
+			It("should return an error", func() {
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(false, nil)
+
+				Expect(vbx.RemoveSharedFolder(&config.VMConfig{Name: "some-vm"}, "/some-guest-path")).To(MatchError("no shared folder is mounted at /some-guest-path"))
+			})
+		})
+
+		Context("when the driver fails to remove the shared folder", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-some-guest-path","host_path":"some-host-path","guest_path":"/some-guest-path","read_only":false}]`), nil),
+					mockDriver.EXPECT().RemoveSharedFolder("some-vm", "pcfdev-some-guest-path").Return(errors.New("some-error")),
+				)
+
+				Expect(vbx.RemoveSharedFolder(&config.VMConfig{Name: "some-vm"}, "/some-guest-path")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("#MountSharedFolders", func() {
+		var addresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			addresses = []ssh.SSHAddress{
+				{
+					IP:   "127.0.0.1",
+					Port: "some-port",
+				},
+				{
+					IP:   "192.168.11.11",
+					Port: "22",
+				},
+			}
+		})
+
+		It("should mount each shared folder in the guest", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+				mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-some-guest-path","host_path":"some-host-path","guest_path":"/some-guest-path","read_only":false},{"name":"pcfdev-some-other-guest-path","host_path":"some-other-host-path","guest_path":"/some-other-guest-path","read_only":true}]`), nil),
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().RunSSHCommand(
+					"sudo mkdir -p '/some-guest-path' && (mountpoint -q '/some-guest-path' || sudo mount -t vboxsf -o uid=vcap,gid=vcap pcfdev-some-guest-path '/some-guest-path')",
+					addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+				mockSSH.EXPECT().RunSSHCommand(
+					"sudo mkdir -p '/some-other-guest-path' && (mountpoint -q '/some-other-guest-path' || sudo mount -t vboxsf -o uid=vcap,gid=vcap,ro pcfdev-some-other-guest-path '/some-other-guest-path')",
+					addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+			)
+
+			Expect(vbx.MountSharedFolders(&config.VMConfig{Name: "some-vm", IP: "192.168.11.11", SSHPort: "some-port"})).To(Succeed())
+		})
+
+		Context("when the guest path contains a single quote", func() {
+			It("should quote it for the shell", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-some-guest-path","host_path":"some-host-path","guest_path":"/some-guest's-path","read_only":false}]`), nil),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand(
+						`sudo mkdir -p '/some-guest'\''s-path' && (mountpoint -q '/some-guest'\''s-path' || sudo mount -t vboxsf -o uid=vcap,gid=vcap pcfdev-some-guest-path '/some-guest'\''s-path')`,
+						addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard),
+				)
+
+				Expect(vbx.MountSharedFolders(&config.VMConfig{Name: "some-vm", IP: "192.168.11.11", SSHPort: "some-port"})).To(Succeed())
+			})
+		})
+
+		Context("when there are no shared folders", func() {
+			It("should not connect to the guest", func() {
+				mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(false, nil)
+
+				Expect(vbx.MountSharedFolders(&config.VMConfig{Name: "some-vm", IP: "192.168.11.11", SSHPort: "some-port"})).To(Succeed())
+			})
+		})
+
+		Context("when mounting a shared folder fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Exists(filepath.Join("some-vm-dir", "shared_folders")).Return(true, nil),
+					mockFS.EXPECT().Read(filepath.Join("some-vm-dir", "shared_folders")).Return([]byte(`[{"name":"pcfdev-some-guest-path","host_path":"some-host-path","guest_path":"/some-guest-path","read_only":false}]`), nil),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand(gomock.Any(), addresses, []byte("some-private-key"), 5*time.Minute, ioutil.Discard, ioutil.Discard).Return(errors.New("some-error")),
+				)
+
+				Expect(vbx.MountSharedFolders(&config.VMConfig{Name: "some-vm", IP: "192.168.11.11", SSHPort: "some-port"})).To(MatchError("some-error"))
+			})
+		})
+	})
+
 	Describe("#ResumeSavedVM", func() {
 		It("should start the VM", func() {
 			mockDriver.EXPECT().StartVM("some-vm")
@@ -1560,18 +2787,89 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 	Describe("#GetVMName", func() {
 		Context("if there is one PCF Dev VM present", func() {
 			It("should return the name of that VM", func() {
-				mockDriver.EXPECT().VMs().Return([]string{"some-vm-name", "pcfdev-our-vm"}, nil)
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"some-vm-name", "pcfdev-our-vm"}, nil),
+					mockDriver.EXPECT().ExtraData("some-vm-name").Return(map[string]string{}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-our-vm").Return(map[string]string{"pcfdev/owner": "pcfdev-cli"}, nil),
+				)
 				Expect(vbx.GetVMName()).To(Equal("pcfdev-our-vm"))
 			})
 		})
 
+		Context("if a VM uses the pcfdev- prefix but is tagged by another tool", func() {
+			It("should not treat it as a PCF Dev VM", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-someone-elses-vm"}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-someone-elses-vm").Return(map[string]string{"pcfdev/owner": "someone-else"}, nil),
+				)
+				Expect(vbx.GetVMName()).To(Equal(""))
+			})
+		})
+
+		Context("if an untagged VM was imported by an older version of the plugin", func() {
+			It("should tag that VM and return its name", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"some-vm-name", "pcfdev-0.0.0"}, nil),
+					mockDriver.EXPECT().ExtraData("some-vm-name").Return(map[string]string{}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-0.0.0").Return(map[string]string{}, nil),
+					mockDriver.EXPECT().GetVMDisk("pcfdev-0.0.0").Return(filepath.Join("some-vm-dir", "pcfdev-0.0.0", "pcfdev-0.0.0-disk1.vmdk"), nil),
+					mockDriver.EXPECT().SetExtraData("pcfdev-0.0.0", "pcfdev/owner", "pcfdev-cli"),
+				)
+				Expect(vbx.GetVMName()).To(Equal("pcfdev-0.0.0"))
+			})
+
+			Context("when tagging the VM fails", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.0"}, nil),
+						mockDriver.EXPECT().ExtraData("pcfdev-0.0.0").Return(map[string]string{}, nil),
+						mockDriver.EXPECT().GetVMDisk("pcfdev-0.0.0").Return(filepath.Join("some-vm-dir", "pcfdev-0.0.0", "pcfdev-0.0.0-disk1.vmdk"), nil),
+						mockDriver.EXPECT().SetExtraData("pcfdev-0.0.0", "pcfdev/owner", "pcfdev-cli").Return(errors.New("some-error")),
+					)
+					_, err := vbx.GetVMName()
+					Expect(err).To(MatchError("some-error"))
+				})
+			})
+		})
+
+		Context("if an untagged VM uses the pcfdev- prefix but its disk is outside the VM directory", func() {
+			It("should not treat it as a PCF Dev VM", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-someone-elses-vm", "pcfdev-diskless-vm"}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-someone-elses-vm").Return(map[string]string{}, nil),
+					mockDriver.EXPECT().GetVMDisk("pcfdev-someone-elses-vm").Return(filepath.Join("some-other-dir", "some-disk.vmdk"), nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-diskless-vm").Return(map[string]string{}, nil),
+					mockDriver.EXPECT().GetVMDisk("pcfdev-diskless-vm").Return("", errors.New("some-error")),
+				)
+				Expect(vbx.GetVMName()).To(Equal(""))
+			})
+		})
+
+		Context("if an untagged VM has a name given to it by the plugin", func() {
+			It("should return the name of that VM", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-default", "pcfdev-custom"}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-default").Return(map[string]string{}, nil),
+					mockDriver.EXPECT().GetVMDisk("pcfdev-default").Return(filepath.Join("some-other-dir", "some-disk.vmdk"), nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-custom").Return(map[string]string{"pcfdev/owner": "someone-else"}, nil),
+				)
+				Expect(vbx.GetVMName()).To(Equal("pcfdev-default"))
+			})
+		})
+
 		Context("if there is more than one PCF Dev VM present", func() {
 			It("should return an error", func() {
-				mockDriver.EXPECT().VMs().Return([]string{"some-vm-name", "pcfdev-our-vm", "pcfdev-other-vm"}, nil)
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"some-vm-name", "pcfdev-our-vm", "pcfdev-other-vm"}, nil),
+					mockDriver.EXPECT().ExtraData("some-vm-name").Return(map[string]string{}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-our-vm").Return(map[string]string{"pcfdev/owner": "pcfdev-cli"}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-other-vm").Return(map[string]string{"pcfdev/owner": "pcfdev-cli"}, nil),
+				)
 				_, err := vbx.GetVMName()
 				Expect(err).To(MatchError("multiple PCF Dev VMs found"))
 			})
 		})
+
 		Context("if Driver.VMs() returns an error", func() {
 			It("should return an error", func() {
 				mockDriver.EXPECT().VMs().Return(nil, errors.New("some-error"))
@@ -1580,9 +2878,23 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 			})
 		})
 
+		Context("if reading the extra data of a VM returns an error", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"some-vm-name"}, nil),
+					mockDriver.EXPECT().ExtraData("some-vm-name").Return(nil, errors.New("some-error")),
+				)
+				_, err := vbx.GetVMName()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
 		Context("when there are no PCF Dev VMs present", func() {
 			It("should return an empty string", func() {
-				mockDriver.EXPECT().VMs().Return([]string{"some-vm-name"}, nil)
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"some-vm-name"}, nil),
+					mockDriver.EXPECT().ExtraData("some-vm-name").Return(map[string]string{}, nil),
+				)
 				Expect(vbx.GetVMName()).To(Equal(""))
 			})
 		})
@@ -1604,6 +2916,28 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 		})
 	})
 
+	Describe("#CheckNetwork", func() {
+		It("should check the candidate subnets against the host-only interfaces", func() {
+			vboxnets := []*network.Interface{&network.Interface{Name: "some-vboxnet"}}
+			candidates := []*address.Candidate{&address.Candidate{Subnet: "192.168.11.1"}}
+			gomock.InOrder(
+				mockDriver.EXPECT().GetHostOnlyInterfaces().Return(vboxnets, nil),
+				mockPicker.EXPECT().Check(vboxnets).Return(candidates, nil),
+			)
+
+			Expect(vbx.CheckNetwork()).To(Equal(candidates))
+		})
+
+		Context("when there is an error listing host-only interfaces", func() {
+			It("should return the error", func() {
+				mockDriver.EXPECT().GetHostOnlyInterfaces().Return(nil, errors.New("some-error"))
+
+				_, err := vbx.CheckNetwork()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
+
 	Describe("#Version", func() {
 		It("return the VBoxDriver version", func() {
 			driverVersion := &vboxdriver.VBoxDriverVersion{Major: 1, Minor: 0, Build: 0}
@@ -1640,22 +2974,30 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 	})
 
 	Describe("#DestroyPCFDevVMs", func() {
-		It("should destroy VMs and Disks that begin with pcfdev-", func() {
+		It("should destroy tagged and untagged older PCF Dev VMs and the disks in the VM directory", func() {
 			gomock.InOrder(
-				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.0", "pcfdev-0.0.1", "some-bad-vm-name"}, nil),
+				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.0", "pcfdev-0.0.1", "pcfdev-someone-elses-vm", "some-bad-vm-name"}, nil),
+				mockDriver.EXPECT().ExtraData("pcfdev-0.0.0").Return(map[string]string{}, nil),
+				mockDriver.EXPECT().GetVMDisk("pcfdev-0.0.0").Return(filepath.Join("some-vm-dir", "pcfdev-0.0.0", "pcfdev-disk1.vmdk"), nil),
+				mockDriver.EXPECT().SetExtraData("pcfdev-0.0.0", "pcfdev/owner", "pcfdev-cli"),
+				mockDriver.EXPECT().ExtraData("pcfdev-0.0.1").Return(map[string]string{"pcfdev/owner": "pcfdev-cli"}, nil),
+				mockDriver.EXPECT().ExtraData("pcfdev-someone-elses-vm").Return(map[string]string{"pcfdev/owner": "someone-else"}, nil),
+				mockDriver.EXPECT().ExtraData("some-bad-vm-name").Return(map[string]string{}, nil),
 				mockDriver.EXPECT().PowerOffVM("pcfdev-0.0.0"),
 				mockDriver.EXPECT().DestroyVM("pcfdev-0.0.0"),
 				mockDriver.EXPECT().PowerOffVM("pcfdev-0.0.1"),
 				mockDriver.EXPECT().DestroyVM("pcfdev-0.0.1"),
-				mockDriver.EXPECT().VMs().Return([]string{}, nil),
+				mockDriver.EXPECT().VMs().Return([]string{"pcfdev-someone-elses-vm", "some-bad-vm-name"}, nil),
+				mockDriver.EXPECT().ExtraData("pcfdev-someone-elses-vm").Return(map[string]string{"pcfdev/owner": "someone-else"}, nil),
+				mockDriver.EXPECT().ExtraData("some-bad-vm-name").Return(map[string]string{}, nil),
 				mockDriver.EXPECT().Disks().Return([]string{
-					filepath.Join("some-dir", "pcfdev-disk1.vmdk"),
+					filepath.Join("some-vm-dir", "pcfdev-disk1.vmdk.compressed"),
+					filepath.Join("some-vm-dir", "pcfdev-0.0.0", "pcfdev-disk1.vmdk"),
 					filepath.Join("some-other-dir", "pcfdev-disk1.vmdk"),
-					filepath.Join("some-other-dir", "some-other-disk.vmdk"),
 				}, nil),
-				mockDriver.EXPECT().DeleteDisk(filepath.Join("some-dir", "pcfdev-disk1.vmdk")),
-				mockDriver.EXPECT().DeleteDisk(filepath.Join("some-other-dir", "pcfdev-disk1.vmdk")),
-				mockDriver.EXPECT().Disks().Return([]string{filepath.Join("some-other-dir", "some-other-disk.vmdk")}, nil),
+				mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "pcfdev-disk1.vmdk.compressed")),
+				mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "pcfdev-0.0.0", "pcfdev-disk1.vmdk")),
+				mockDriver.EXPECT().Disks().Return([]string{filepath.Join("some-other-dir", "pcfdev-disk1.vmdk")}, nil),
 			)
 
 			Expect(vbx.DestroyPCFDevVMs()).To(Succeed())
@@ -1678,9 +3020,8 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 				gomock.InOrder(
 					mockDriver.EXPECT().VMs().Return([]string{}, nil),
 					mockDriver.EXPECT().VMs().Return([]string{}, nil),
-					mockDriver.EXPECT().Disks().Return([]string{filepath.Join("some-dir", "pcfdev-disk1.vmdk"), filepath.Join("some-other-dir", "pcfdev-disk1.vmdk")}, nil),
-					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-dir", "pcfdev-disk1.vmdk")),
-					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-other-dir", "pcfdev-disk1.vmdk")),
+					mockDriver.EXPECT().Disks().Return([]string{filepath.Join("some-vm-dir", "pcfdev-disk1.vmdk")}, nil),
+					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "pcfdev-disk1.vmdk")),
 					mockDriver.EXPECT().Disks().Return(nil, errors.New("some-error")),
 				)
 
@@ -1693,10 +3034,10 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 				gomock.InOrder(
 					mockDriver.EXPECT().VMs().Return([]string{}, nil),
 					mockDriver.EXPECT().VMs().Return([]string{}, nil),
-					mockDriver.EXPECT().Disks().Return([]string{filepath.Join("some-dir", "pcfdev-disk1.vmdk"), filepath.Join("some-other-dir", "pcfdev-disk1.vmdk")}, nil),
-					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-dir", "pcfdev-disk1.vmdk")),
-					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-other-dir", "pcfdev-disk1.vmdk")).Return(errors.New("some-error")),
-					mockDriver.EXPECT().Disks().Return([]string{filepath.Join("some-other-dir", "pcfdev-disk1.vmdk")}, nil),
+					mockDriver.EXPECT().Disks().Return([]string{filepath.Join("some-vm-dir", "pcfdev-disk1.vmdk"), filepath.Join("some-vm-dir", "pcfdev-disk2.vmdk")}, nil),
+					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "pcfdev-disk1.vmdk")),
+					mockDriver.EXPECT().DeleteDisk(filepath.Join("some-vm-dir", "pcfdev-disk2.vmdk")).Return(errors.New("some-error")),
+					mockDriver.EXPECT().Disks().Return([]string{filepath.Join("some-vm-dir", "pcfdev-disk2.vmdk")}, nil),
 				)
 
 				Expect(vbx.DestroyPCFDevVMs()).To(MatchError("failed to destroy all pcfdev disks"))
@@ -1711,15 +3052,29 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 			})
 		})
 
+		Context("when reading the extra data of a VM fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.0"}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-0.0.0").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(vbx.DestroyPCFDevVMs()).To(MatchError("some-error"))
+			})
+		})
+
 		Context("when destroying a VM fails", func() {
 			It("should continue on to the next VM", func() {
 				gomock.InOrder(
-					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.0", "pcfdev-0.0.1", "some-bad-vm-name"}, nil),
+					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.0", "pcfdev-0.0.1"}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-0.0.0").Return(map[string]string{"pcfdev/owner": "pcfdev-cli"}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-0.0.1").Return(map[string]string{"pcfdev/owner": "pcfdev-cli"}, nil),
 					mockDriver.EXPECT().PowerOffVM("pcfdev-0.0.0"),
 					mockDriver.EXPECT().DestroyVM("pcfdev-0.0.0").Return(errors.New("some-error")),
 					mockDriver.EXPECT().PowerOffVM("pcfdev-0.0.1"),
 					mockDriver.EXPECT().DestroyVM("pcfdev-0.0.1"),
 					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.0"}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-0.0.0").Return(map[string]string{"pcfdev/owner": "pcfdev-cli"}, nil),
 				)
 
 				Expect(vbx.DestroyPCFDevVMs()).To(MatchError("failed to destroy all pcfdev vms"))
@@ -1730,6 +3085,7 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 			It("shoudl return an error", func() {
 				gomock.InOrder(
 					mockDriver.EXPECT().VMs().Return([]string{"pcfdev-0.0.1"}, nil),
+					mockDriver.EXPECT().ExtraData("pcfdev-0.0.1").Return(map[string]string{"pcfdev/owner": "pcfdev-cli"}, nil),
 					mockDriver.EXPECT().PowerOffVM("pcfdev-0.0.1"),
 					mockDriver.EXPECT().DestroyVM("pcfdev-0.0.1"),
 					mockDriver.EXPECT().VMs().Return(nil, errors.New("some-error")),
@@ -1740,3 +3096,78 @@ no_proxy=localhost,127.0.0.1,192.168.11.1,192.168.11.11,local.pcfdev.io,.local.p
 		})
 	})
 })
+
+func vmConfigFileContents(ip string, domain string, vmConfig *config.VMConfig) *bytes.Reader {
+	contents, err := config.NewVMConfigFile(&config.VMConfig{
+		IP:            ip,
+		Domain:        domain,
+		CPUs:          vmConfig.CPUs,
+		Memory:        vmConfig.Memory,
+		OVAPath:       vmConfig.OVAPath,
+		Network:       vmConfig.Network,
+		BridgeAdapter: vmConfig.BridgeAdapter,
+		OVAVersion:    vmConfig.OVAVersion,
+	}).Marshal()
+	Expect(err).NotTo(HaveOccurred())
+	return bytes.NewReader(contents)
+}
+
+func setsEnvironment(settings string) *environmentMatcher {
+	return &environmentMatcher{
+		ExpectedSettings: &settings,
+	}
+}
+
+func reloadsEnvironment() *environmentMatcher {
+	return &environmentMatcher{}
+}
+
+type environmentMatcher struct {
+	ExpectedSettings *string
+	actual           string
+}
+
+func (e *environmentMatcher) Matches(x interface{}) bool {
+	var isAString bool
+	e.actual, isAString = x.(string)
+	if !isAString {
+		return false
+	}
+
+	prefix := `settings="$(echo -e '`
+	if e.ExpectedSettings != nil {
+		prefix += *e.ExpectedSettings + `')"` + "\n"
+	}
+	if !strings.HasPrefix(e.actual, prefix) {
+		return false
+	}
+	rest := strings.TrimPrefix(e.actual, prefix)
+	return strings.Contains(rest, "sudo tee /etc/environment") &&
+		strings.Contains(rest, "initctl set-env --global") &&
+		strings.Contains(rest, "monit restart all")
+}
+
+func (e *environmentMatcher) String() string {
+	return fmt.Sprintf(`Expected "%s" to write the settings to /etc/environment, set them for upstart jobs and restart the monit jobs`, e.actual)
+}
+
+func contains(expected string) *containsMatcher {
+	return &containsMatcher{
+		ExpectedSubstring: expected,
+	}
+}
+
+type containsMatcher struct {
+	ExpectedSubstring string
+	actual            string
+}
+
+func (k *containsMatcher) Matches(x interface{}) bool {
+	var isAString bool
+	k.actual, isAString = x.(string)
+	return isAString && strings.Contains(k.actual, k.ExpectedSubstring)
+}
+
+func (k *containsMatcher) String() string {
+	return fmt.Sprintf(`Expected "%s" to contain "%s"`, k.actual, k.ExpectedSubstring)
+}
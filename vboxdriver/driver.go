@@ -40,6 +40,15 @@ func (v *VBoxDriver) VBoxManage(arg ...string) (output []byte, err error) {
 	return v.CmdRunner.Run(vBoxManagePath, arg...)
 }
 
+func (v *VBoxDriver) VBoxManageUntil(stop <-chan struct{}, arg ...string) (output []byte, err error) {
+	vBoxManagePath, err := helpers.VBoxManagePath()
+	if err != nil {
+		return nil, errors.New("could not find VBoxManage executable")
+	}
+
+	return v.CmdRunner.RunUntil(stop, vBoxManagePath, arg...)
+}
+
 func (d *VBoxDriver) StartVM(vmName string) error {
 	_, err := d.VBoxManage("startvm", vmName, "--type", "headless")
 	return err
@@ -202,6 +211,14 @@ func (d *VBoxDriver) ConfigureHostOnlyInterface(interfaceName string, ip string)
 	return nil
 }
 
+func (d *VBoxDriver) RemoveHostOnlyInterface(interfaceName string) error {
+	if _, err := d.VBoxManage("hostonlyif", "remove", interfaceName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (d *VBoxDriver) GetHostOnlyInterfaces() (interfaces []*network.Interface, err error) {
 	output, err := d.VBoxManage("list", "hostonlyifs")
 	if err != nil {
@@ -254,11 +271,39 @@ func (d *VBoxDriver) SetCPUs(vmName string, cpus int) error {
 	return err
 }
 
+func (d *VBoxDriver) EnableConsoleLog(vmName string, logPath string) error {
+	_, err := d.VBoxManage("modifyvm", vmName, "--uart1", "0x3F8", "4", "--uartmode1", "file", logPath)
+	return err
+}
+
 func (d *VBoxDriver) AttachNetworkInterface(interfaceName string, vmName string) error {
 	_, err := d.VBoxManage("modifyvm", vmName, "--nic2", "hostonly", "--nictype2", "virtio", "--hostonlyadapter2", interfaceName)
 	return err
 }
 
+func (d *VBoxDriver) AttachBridgedInterface(interfaceName string, vmName string) error {
+	_, err := d.VBoxManage("modifyvm", vmName, "--nic2", "bridged", "--nictype2", "virtio", "--bridgeadapter2", interfaceName)
+	return err
+}
+
+func (d *VBoxDriver) BridgedInterfaces() (interfaces []string, err error) {
+	output, err := d.VBoxManage("list", "bridgedifs")
+	if err != nil {
+		return nil, err
+	}
+
+	interfaces = []string{}
+	nameRegex := regexp.MustCompile(`(?m)^Name:\s+(.+?)\s*$`)
+	statusRegex := regexp.MustCompile(`(?m)^Status:\s+Up\s*$`)
+	for _, block := range strings.Split(strings.Replace(string(output), "\r\n", "\n", -1), "\n\n") {
+		if matches := nameRegex.FindStringSubmatch(block); len(matches) > 1 && statusRegex.MatchString(block) {
+			interfaces = append(interfaces, matches[1])
+		}
+	}
+
+	return interfaces, nil
+}
+
 func (d *VBoxDriver) ForwardPort(vmName string, ruleName string, hostPort string, guestPort string) error {
 	_, err := d.VBoxManage("modifyvm", vmName, "--natpf1", fmt.Sprintf("%s,tcp,127.0.0.1,%s,,%s", ruleName, hostPort, guestPort))
 	return err
@@ -295,6 +340,28 @@ func (d *VBoxDriver) VMs() ([]string, error) {
 	return vms, nil
 }
 
+func (d *VBoxDriver) SetExtraData(vmName string, key string, value string) error {
+	_, err := d.VBoxManage("setextradata", vmName, key, value)
+	return err
+}
+
+func (d *VBoxDriver) ExtraData(vmName string) (map[string]string, error) {
+	output, err := d.VBoxManage("getextradata", vmName, "enumerate")
+	if err != nil {
+		return nil, err
+	}
+
+	extraData := map[string]string{}
+	regex := regexp.MustCompile(`^Key: (.+?), Value: (.*)$`)
+	for _, line := range strings.Split(strings.Replace(string(output), "\r\n", "\n", -1), "\n") {
+		if matches := regex.FindStringSubmatch(line); len(matches) > 2 {
+			extraData[matches[1]] = matches[2]
+		}
+	}
+
+	return extraData, nil
+}
+
 func (d *VBoxDriver) RunningVMs() (vms []string, err error) {
 	output, err := d.VBoxManage("list", "runningvms")
 	if err != nil {
@@ -350,6 +417,77 @@ func (d *VBoxDriver) CloneDisk(src, dst string) error {
 	return nil
 }
 
+func (d *VBoxDriver) ConvertDisk(src string, dst string, format string) error {
+	_, err := d.VBoxManage("clonemedium", "disk", src, dst, "--format", format)
+	return err
+}
+
+func (d *VBoxDriver) ReattachDisk(vmName string, diskPath string) error {
+	_, err := d.VBoxManage("storageattach", vmName, "--storagectl", "SATA", "--medium", diskPath, "--type", "hdd", "--port", "0", "--device", "0")
+	return err
+}
+
+func (d *VBoxDriver) ResizeDisk(diskPath string, size uint64) error {
+	_, err := d.VBoxManage("modifymedium", "disk", diskPath, "--resize", strconv.FormatUint(size, 10))
+	return err
+}
+
+func (d *VBoxDriver) GetVMDisk(vmName string) (diskPath string, err error) {
+	output, err := d.VBoxManage("showvminfo", vmName, "--machinereadable")
+	if err != nil {
+		return "", err
+	}
+
+	regex := regexp.MustCompile(`"SATA-0-0"="(.+)"`)
+	if matches := regex.FindStringSubmatch(string(output)); len(matches) > 1 {
+		return matches[1], nil
+	}
+
+	return "", fmt.Errorf("failed to determine disk attached to '%s'", vmName)
+}
+
+func (d *VBoxDriver) GetDiskSize(diskPath string) (size uint64, err error) {
+	output, err := d.VBoxManage("showmediuminfo", "disk", diskPath)
+	if err != nil {
+		return uint64(0), err
+	}
+
+	regex := regexp.MustCompile(`Capacity:\s+(\d+) MBytes`)
+	if matches := regex.FindStringSubmatch(string(output)); len(matches) > 1 {
+		return strconv.ParseUint(matches[1], 10, 64)
+	}
+
+	return uint64(0), fmt.Errorf("failed to determine size of disk '%s'", diskPath)
+}
+
+func (d *VBoxDriver) GetDiskFormat(diskPath string) (format string, err error) {
+	output, err := d.VBoxManage("showmediuminfo", "disk", diskPath)
+	if err != nil {
+		return "", err
+	}
+
+	regex := regexp.MustCompile(`Storage format:\s+(\S+)`)
+	if matches := regex.FindStringSubmatch(string(output)); len(matches) > 1 {
+		return matches[1], nil
+	}
+
+	return "", fmt.Errorf("failed to determine format of disk '%s'", diskPath)
+}
+
+func (d *VBoxDriver) AddSharedFolder(vmName string, name string, hostPath string, readOnly bool) error {
+	args := []string{"sharedfolder", "add", vmName, "--name", name, "--hostpath", hostPath}
+	if readOnly {
+		args = append(args, "--readonly")
+	}
+	_, err := d.VBoxManage(args...)
+	return err
+}
+
+func (d *VBoxDriver) RemoveSharedFolder(vmName string, name string) error {
+	_, err := d.VBoxManage("sharedfolder", "remove", vmName, "--name", name)
+	return err
+}
+
 func (d *VBoxDriver) DeleteDisk(diskPath string) error {
 	exists, err := d.FS.Exists(diskPath)
 	if err != nil {
@@ -387,6 +525,24 @@ func (d *VBoxDriver) Disks() ([]string, error) {
 	return disks, nil
 }
 
+func (d *VBoxDriver) UnattachedDisks() ([]string, error) {
+	output, err := d.VBoxManage("list", "hdds")
+	if err != nil {
+		return nil, err
+	}
+
+	disks := []string{}
+	regex := regexp.MustCompile(`(?m:^Location:\s+(.+))`)
+	for _, block := range strings.Split(strings.Replace(string(output), "\r\n", "\n", -1), "\n\n") {
+		matches := regex.FindStringSubmatch(block)
+		if len(matches) > 1 && !strings.Contains(block, "In use by VMs:") {
+			disks = append(disks, strings.TrimSpace(matches[1]))
+		}
+	}
+
+	return disks, nil
+}
+
 func (d *VBoxDriver) Version() (*VBoxDriverVersion, error) {
 	output, err := d.VBoxManage("--version")
 	if err != nil {
@@ -74,6 +74,14 @@ var _ = Describe("driver", func() {
 		})
 	})
 
+	Describe("#VBoxManageUntil", func() {
+		It("should execute VBoxManage with given args", func() {
+			output, err := driver.VBoxManageUntil(make(chan struct{}), "help")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).To(ContainSubstring("Oracle VM VirtualBox Command Line Management Interface"))
+		})
+	})
+
 	Describe("#UseDNSProxy", func() {
 		It("should turn on natdnshostresolver1", func() {
 			Expect(driver.UseDNSProxy(vmName)).To(Succeed())
@@ -420,6 +428,19 @@ var _ = Describe("driver", func() {
 		})
 	})
 
+	Describe("#RemoveHostOnlyInterface", func() {
+		It("should remove the hostonlyif", func() {
+			interfaceName, err := driver.CreateHostOnlyInterface("192.168.78.1")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(driver.RemoveHostOnlyInterface(interfaceName)).To(Succeed())
+
+			output, err := exec.Command(vBoxManagePath, "list", "hostonlyifs").Output()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(output)).NotTo(ContainSubstring("Name:            " + interfaceName + "\n"))
+		})
+	})
+
 	Describe("#CreateHostOnlyInterface", func() {
 		var interfaceName string
 
@@ -559,6 +580,32 @@ var _ = Describe("driver", func() {
 		})
 	})
 
+	Describe("#AttachBridgedInterface", func() {
+		It("should bridge the second adapter of the vm to the given host interface", func() {
+			bridgedInterfaces, err := driver.BridgedInterfaces()
+			Expect(err).NotTo(HaveOccurred())
+			if len(bridgedInterfaces) == 0 {
+				Skip("no host interface is up")
+			}
+
+			Expect(driver.AttachBridgedInterface(bridgedInterfaces[0], vmName)).To(Succeed())
+
+			showvmInfoCommand := exec.Command(vBoxManagePath, "showvminfo", vmName, "--machinereadable")
+			session, err := gexec.Start(showvmInfoCommand, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(session).Should(gexec.Exit(0))
+			Expect(session).To(gbytes.Say(`bridgeadapter2="` + regexp.QuoteMeta(bridgedInterfaces[0]) + `"`))
+			Expect(session).To(gbytes.Say(`nic2="bridged"`))
+		})
+
+		Context("when attaching a bridged interface fails", func() {
+			It("should return an error", func() {
+				err := driver.AttachBridgedInterface("some-interface-name", "some-bad-vm-name")
+				Expect(err).To(MatchError(MatchRegexp("failed to execute '.* modifyvm some-bad-vm-name --nic2 bridged --nictype2 virtio --bridgeadapter2 some-interface-name': exit status 1")))
+			})
+		})
+	})
+
 	Describe("#ForwardPort", func() {
 		It("should forward guest port to the given host port", func() {
 			sshClient := &ssh.SSH{}
@@ -629,6 +676,26 @@ var _ = Describe("driver", func() {
 		})
 	})
 
+	Describe("#EnableConsoleLog", func() {
+		It("should log the serial console to a file", func() {
+			logPath := filepath.Join(os.TempDir(), "some-console.log")
+			Expect(driver.EnableConsoleLog(vmName, logPath)).To(Succeed())
+
+			showvmInfoCommand := exec.Command(vBoxManagePath, "showvminfo", vmName, "--machinereadable")
+			session, err := gexec.Start(showvmInfoCommand, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(session).Should(gexec.Exit(0))
+			Expect(session).To(gbytes.Say(`uart1="0x03f8,4"`))
+			Expect(session).To(gbytes.Say(`uartmode1="file,` + regexp.QuoteMeta(logPath) + `"`))
+		})
+
+		Context("when enabling the console log fails", func() {
+			It("should return an error", func() {
+				Expect(driver.EnableConsoleLog("some-bad-vm-name", "some-path")).To(MatchError(MatchRegexp("failed to execute '.* modifyvm some-bad-vm-name --uart1 0x3F8 4 --uartmode1 file some-path'")))
+			})
+		})
+	})
+
 	Describe("#SetCPUs", func() {
 		It("should set vm cpus", func() {
 			Expect(driver.SetCPUs(vmName, 2)).To(Succeed())
@@ -779,6 +846,210 @@ var _ = Describe("driver", func() {
 		})
 	})
 
+	Describe("#SetExtraData", func() {
+		It("should store the key and value on the VM", func() {
+			Expect(driver.SetExtraData(vmName, "pcfdev/some-key", "some-value")).To(Succeed())
+			Expect(driver.SetExtraData(vmName, "pcfdev/some-other-key", "some other value")).To(Succeed())
+
+			extraData, err := driver.ExtraData(vmName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(extraData).To(HaveKeyWithValue("pcfdev/some-key", "some-value"))
+			Expect(extraData).To(HaveKeyWithValue("pcfdev/some-other-key", "some other value"))
+		})
+
+		Context("when the VM does not exist", func() {
+			It("should return an error", func() {
+				Expect(driver.SetExtraData("some-bad-vm-name", "pcfdev/some-key", "some-value")).NotTo(Succeed())
+			})
+		})
+	})
+
+	Describe("#ExtraData", func() {
+		Context("when the VM has no pcfdev extra data", func() {
+			It("should not return any pcfdev keys", func() {
+				extraData, err := driver.ExtraData(vmName)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(extraData).NotTo(HaveKey("pcfdev/some-key"))
+			})
+		})
+
+		Context("when the VM does not exist", func() {
+			It("should return an error", func() {
+				_, err := driver.ExtraData("some-bad-vm-name")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("#UnattachedDisks", func() {
+		var diskPath string
+
+		BeforeEach(func() {
+			diskPath = filepath.Join(os.TempDir(), "some-unattached-disk.vmdk")
+			_, err := exec.Command(vBoxManagePath, "createmedium", "--filename", diskPath, "--size", "1024", "--format", "VMDK").CombinedOutput()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			exec.Command(vBoxManagePath, "closemedium", diskPath, "--delete").Run()
+		})
+
+		It("should return the disks that are not attached to a VM", func() {
+			Expect(driver.UnattachedDisks()).To(ContainElement(diskPath))
+		})
+	})
+
+	Describe("disk resizing", func() {
+		var tmpDir string
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "pcfdev-vbox-driver")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(exec.Command(
+				vBoxManagePath, "createvm", "--name", "some-vm", "--ostype", "Ubuntu_64", "--basefolder", tmpDir, "--register").Run(),
+			).To(Succeed())
+			Expect(exec.Command(
+				vBoxManagePath, "createmedium", "disk", "--filename", filepath.Join(tmpDir, "some-disk.vmdk"), "--size", "1", "--format", "VMDK").Run(),
+			).To(Succeed())
+			Expect(driver.AttachDisk("some-vm", filepath.Join(tmpDir, "some-disk.vmdk"))).To(Succeed())
+		})
+
+		AfterEach(func() {
+			exec.Command(vBoxManagePath, "unregistervm", "some-vm", "--delete").Run()
+			exec.Command(vBoxManagePath, "closemedium", "disk", filepath.Join(tmpDir, "some-disk.vmdk")).Run()
+			exec.Command(vBoxManagePath, "closemedium", "disk", filepath.Join(tmpDir, "some-disk.vdi")).Run()
+			os.RemoveAll(tmpDir)
+		})
+
+		Describe("#GetVMDisk", func() {
+			It("should return the path of the attached disk", func() {
+				diskPath, err := driver.GetVMDisk("some-vm")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diskPath).To(HaveSuffix("some-disk.vmdk"))
+			})
+
+			Context("when the VM has no disk attached", func() {
+				It("should return an error", func() {
+					Expect(exec.Command(vBoxManagePath, "storageattach", "some-vm", "--storagectl", "SATA", "--port", "0", "--device", "0", "--medium", "none").Run()).To(Succeed())
+
+					_, err := driver.GetVMDisk("some-vm")
+					Expect(err).To(MatchError("failed to determine disk attached to 'some-vm'"))
+				})
+			})
+		})
+
+		Describe("#GetDiskSize", func() {
+			It("should return the size of the disk in megabytes", func() {
+				Expect(driver.GetDiskSize(filepath.Join(tmpDir, "some-disk.vmdk"))).To(Equal(uint64(1)))
+			})
+
+			Context("when the disk does not exist", func() {
+				It("should return an error", func() {
+					_, err := driver.GetDiskSize("some-bad-disk")
+					Expect(err).To(MatchError(MatchRegexp("failed to execute '.* showmediuminfo disk some-bad-disk':")))
+				})
+			})
+		})
+
+		Describe("#GetDiskFormat", func() {
+			It("should return the format of the disk", func() {
+				Expect(driver.GetDiskFormat(filepath.Join(tmpDir, "some-disk.vmdk"))).To(Equal("VMDK"))
+			})
+		})
+
+		Describe("#ConvertDisk", func() {
+			It("should clone the disk into the given format", func() {
+				Expect(driver.ConvertDisk(filepath.Join(tmpDir, "some-disk.vmdk"), filepath.Join(tmpDir, "some-disk.vdi"), "VDI")).To(Succeed())
+
+				Expect(driver.GetDiskFormat(filepath.Join(tmpDir, "some-disk.vdi"))).To(Equal("VDI"))
+			})
+		})
+
+		Describe("#ReattachDisk", func() {
+			It("should replace the attached disk", func() {
+				Expect(driver.ConvertDisk(filepath.Join(tmpDir, "some-disk.vmdk"), filepath.Join(tmpDir, "some-disk.vdi"), "VDI")).To(Succeed())
+				Expect(driver.ReattachDisk("some-vm", filepath.Join(tmpDir, "some-disk.vdi"))).To(Succeed())
+
+				diskPath, err := driver.GetVMDisk("some-vm")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(diskPath).To(HaveSuffix("some-disk.vdi"))
+			})
+		})
+
+		Describe("#ResizeDisk", func() {
+			It("should grow the disk", func() {
+				Expect(driver.ConvertDisk(filepath.Join(tmpDir, "some-disk.vmdk"), filepath.Join(tmpDir, "some-disk.vdi"), "VDI")).To(Succeed())
+				Expect(driver.ResizeDisk(filepath.Join(tmpDir, "some-disk.vdi"), uint64(2))).To(Succeed())
+
+				Expect(driver.GetDiskSize(filepath.Join(tmpDir, "some-disk.vdi"))).To(Equal(uint64(2)))
+			})
+
+			Context("when resizing fails", func() {
+				It("should return an error", func() {
+					Expect(driver.ResizeDisk("some-bad-disk", uint64(2))).To(
+						MatchError(MatchRegexp("failed to execute '.* modifymedium disk some-bad-disk --resize 2':")))
+				})
+			})
+		})
+	})
+
+	Describe("#AddSharedFolder", func() {
+		var tmpDir string
+
+		BeforeEach(func() {
+			var err error
+			tmpDir, err = ioutil.TempDir("", "pcfdev-vbox-driver")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(tmpDir)
+		})
+
+		It("should add a shared folder to the VM", func() {
+			Expect(driver.AddSharedFolder(vmName, "some-share", tmpDir, true)).To(Succeed())
+
+			command := exec.Command(vBoxManagePath, "showvminfo", vmName, "--machinereadable")
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(session, 10*time.Second).Should(gexec.Exit(0))
+			Expect(session).To(gbytes.Say(`SharedFolderNameMachineMapping1="some-share"`))
+		})
+
+		Context("when adding the shared folder fails", func() {
+			It("should return an error", func() {
+				Expect(driver.AddSharedFolder("some-bad-vm", "some-share", tmpDir, false)).To(
+					MatchError(MatchRegexp("failed to execute '.* sharedfolder add some-bad-vm --name some-share --hostpath .*':")))
+			})
+		})
+	})
+
+	Describe("#RemoveSharedFolder", func() {
+		It("should remove the shared folder from the VM", func() {
+			tmpDir, err := ioutil.TempDir("", "pcfdev-vbox-driver")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(tmpDir)
+
+			Expect(driver.AddSharedFolder(vmName, "some-share", tmpDir, false)).To(Succeed())
+			Expect(driver.RemoveSharedFolder(vmName, "some-share")).To(Succeed())
+
+			command := exec.Command(vBoxManagePath, "showvminfo", vmName, "--machinereadable")
+			session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(session, 10*time.Second).Should(gexec.Exit(0))
+			Expect(session).NotTo(gbytes.Say(`SharedFolderNameMachineMapping1="some-share"`))
+		})
+
+		Context("when removing the shared folder fails", func() {
+			It("should return an error", func() {
+				Expect(driver.RemoveSharedFolder(vmName, "some-bad-share")).To(
+					MatchError(MatchRegexp("failed to execute '.* sharedfolder remove .* --name some-bad-share':")))
+			})
+		})
+	})
+
 	Describe("#DeleteDisk", func() {
 		var diskPath string
 
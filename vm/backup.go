@@ -0,0 +1,270 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pivotal-cf/pcfdev-cli/helpers"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+)
+
+const (
+	mysqlCommand     = "sudo /var/vcap/packages/mariadb/bin/mysql --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf"
+	mysqldumpCommand = "sudo /var/vcap/packages/mariadb/bin/mysqldump --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf --single-transaction --add-drop-database --databases"
+	blobstoreDir     = "/var/vcap/store/blobstore"
+)
+
+var backupSizeCommand = fmt.Sprintf(
+	`sudo du -sb %s | cut -f1 && %s -N -B -e "SELECT COALESCE(SUM(data_length + index_length), 0) FROM information_schema.tables WHERE table_schema IN ('ccdb', 'uaadb')"`,
+	blobstoreDir, mysqlCommand,
+)
+
+// stopDataComponentsCommand stops every job except the database, so that nothing reads or writes the blobstore and databases while they are replaced.
+const stopDataComponentsCommand = `for i in $(seq 1 60); do ` +
+	`processes=$(sudo /var/vcap/bosh/bin/monit summary | grep "^Process" | grep -v -e mysql -e mariadb | grep -v "not monitored$"); ` +
+	`if [ -z "$processes" ]; then exit 0; fi; ` +
+	`echo "$processes" | cut -d "'" -f2 | xargs -n 1 sudo /var/vcap/bosh/bin/monit stop; sleep 5; ` +
+	`done; exit 1`
+
+const startComponentsCommand = `sudo /var/vcap/bosh/bin/monit start all && ` + waitForComponentsCommand
+
+type backupManifest struct {
+	OVAVersion string `json:"ova_version"`
+	Domain     string `json:"domain"`
+}
+
+type backupArtifact struct {
+	filename      string
+	exportCommand string
+	importCommand string
+	containsURLs  bool
+}
+
+var backupArtifacts = []backupArtifact{
+	{
+		filename:      "ccdb.sql",
+		exportCommand: mysqldumpCommand + " ccdb",
+		importCommand: mysqlCommand,
+		containsURLs:  true,
+	},
+	{
+		filename:      "uaadb.sql",
+		exportCommand: mysqldumpCommand + " uaadb",
+		importCommand: mysqlCommand,
+		containsURLs:  true,
+	},
+	{
+		filename:      "blobstore.tgz",
+		exportCommand: fmt.Sprintf("sudo tar -C %s -czf - .", blobstoreDir),
+		importCommand: fmt.Sprintf("sudo rm -rf %[1]s && sudo mkdir -p %[1]s && sudo tar -C %[1]s -xzf -", blobstoreDir),
+	},
+}
+
+func (r *Running) Backup(path string) error {
+	r.UI.Say("Backing up PCF Dev...")
+
+	tempDir, err := r.FS.TempDir()
+	if err != nil {
+		return &BackupError{err}
+	}
+	defer r.FS.Remove(tempDir)
+
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return &BackupError{err}
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: r.VMConfig.SSHPort},
+		{IP: r.VMConfig.IP, Port: "22"},
+	}
+
+	size, err := r.estimateBackupSize(addresses, privateKeyBytes)
+	if err != nil {
+		return &BackupError{err}
+	}
+	for _, dir := range []string{tempDir, filepath.Dir(path)} {
+		if err := r.System.CheckDiskSpace(dir, size); err != nil {
+			return &BackupError{err}
+		}
+	}
+
+	contentPaths := []string{}
+	for _, artifact := range backupArtifacts {
+		artifactPath := filepath.Join(tempDir, artifact.filename)
+		if err := r.exportArtifact(artifact.exportCommand, artifactPath, addresses, privateKeyBytes); err != nil {
+			return &BackupError{err}
+		}
+		contentPaths = append(contentPaths, artifactPath)
+	}
+
+	manifest, err := json.Marshal(&backupManifest{
+		OVAVersion: r.VMConfig.OVAVersion,
+		Domain:     r.VMConfig.Domain,
+	})
+	if err != nil {
+		return &BackupError{err}
+	}
+
+	manifestPath := filepath.Join(tempDir, "manifest.json")
+	if err := r.FS.Write(manifestPath, bytes.NewReader(manifest), false); err != nil {
+		return &BackupError{err}
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), ".tgz")
+	if err := r.FS.Compress(name, filepath.Dir(path), append([]string{manifestPath}, contentPaths...)); err != nil {
+		return &BackupError{err}
+	}
+
+	r.UI.Say(fmt.Sprintf("PCF Dev backed up to %s.", filepath.Join(filepath.Dir(path), name+".tgz")))
+	return nil
+}
+
+func (r *Running) estimateBackupSize(addresses []ssh.SSHAddress, privateKey []byte) (uint64, error) {
+	output, err := r.SSHClient.GetSSHOutput(backupSizeCommand, addresses, privateKey, 5*time.Minute)
+	if err != nil {
+		return 0, err
+	}
+
+	var size uint64
+	sizes := 0
+	for _, line := range strings.Split(output, "\n") {
+		if bytes, err := strconv.ParseUint(strings.TrimSpace(line), 10, 64); err == nil {
+			size += bytes
+			sizes++
+		}
+	}
+	if sizes != 2 {
+		return 0, fmt.Errorf("failed to estimate the size of the backup: %s", strings.TrimSpace(output))
+	}
+	return size, nil
+}
+
+func (r *Running) exportArtifact(command string, path string, addresses []ssh.SSHAddress, privateKey []byte) error {
+	reader, writer := io.Pipe()
+	defer reader.Close()
+
+	go func() {
+		writer.CloseWithError(r.SSHClient.RunSSHCommand(command, addresses, privateKey, 5*time.Minute, writer, ioutil.Discard))
+	}()
+
+	return r.FS.Write(path, reader, false)
+}
+
+func (r *Running) Restore(path string) error {
+	r.UI.Say(fmt.Sprintf("Restoring PCF Dev from %s...", path))
+
+	tempDir, err := r.FS.TempDir()
+	if err != nil {
+		return &RestoreError{err}
+	}
+	defer r.FS.Remove(tempDir)
+
+	manifestPath := filepath.Join(tempDir, "manifest.json")
+	if err := r.FS.Extract(path, manifestPath, `manifest\.json$`); err != nil {
+		return &RestoreError{err}
+	}
+
+	manifestBytes, err := r.FS.Read(manifestPath)
+	if err != nil {
+		return &RestoreError{err}
+	}
+
+	var manifest backupManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return &RestoreError{err}
+	}
+
+	if manifest.OVAVersion == "" || r.VMConfig.OVAVersion == "" {
+		r.UI.Say("Unable to verify that the backup was created with the OVA version of this VM. Restoring anyway...")
+	} else if manifest.OVAVersion != r.VMConfig.OVAVersion {
+		return &RestoreError{fmt.Errorf("backup was created with OVA version %s, but this VM is running OVA version %s", manifest.OVAVersion, r.VMConfig.OVAVersion)}
+	}
+
+	if manifest.Domain != r.VMConfig.Domain {
+		r.UI.Say(fmt.Sprintf("Backup was created for domain %s. Replacing it with %s...", manifest.Domain, r.VMConfig.Domain))
+	}
+
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return &RestoreError{err}
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: r.VMConfig.SSHPort},
+		{IP: r.VMConfig.IP, Port: "22"},
+	}
+
+	r.UI.Say("Stopping PCF Dev components...")
+	if err := r.SSHClient.RunSSHCommand(stopDataComponentsCommand, addresses, privateKeyBytes, 5*time.Minute, ioutil.Discard, ioutil.Discard); err != nil {
+		return &RestoreError{err}
+	}
+
+	importErr := r.importArtifacts(path, tempDir, manifest.Domain, addresses, privateKeyBytes)
+
+	r.UI.Say("Starting PCF Dev components...")
+	startErr := r.SSHClient.RunSSHCommand(startComponentsCommand, addresses, privateKeyBytes, 15*time.Minute, ioutil.Discard, ioutil.Discard)
+	if importErr != nil {
+		return &RestoreError{importErr}
+	}
+	if startErr != nil {
+		return &RestoreError{startErr}
+	}
+
+	r.UI.Say("PCF Dev restored.")
+	return nil
+}
+
+func (r *Running) importArtifacts(path string, tempDir string, backupDomain string, addresses []ssh.SSHAddress, privateKey []byte) error {
+	for _, artifact := range backupArtifacts {
+		artifactPath := filepath.Join(tempDir, artifact.filename)
+		if err := r.FS.Extract(path, artifactPath, regexp.QuoteMeta(artifact.filename)+"$"); err != nil {
+			return err
+		}
+
+		command := artifact.importCommand
+		if artifact.containsURLs && backupDomain != r.VMConfig.Domain {
+			if strings.ContainsAny(backupDomain+r.VMConfig.Domain, "\n") {
+				return fmt.Errorf("unable to replace domain %q with %q", backupDomain, r.VMConfig.Domain)
+			}
+			script := fmt.Sprintf("s/%s/%s/g", sedEscape(backupDomain, `\.*[^$/`), sedEscape(r.VMConfig.Domain, `\&/`))
+			command = fmt.Sprintf("sed -e %s | %s", helpers.ShellQuote(script), command)
+		}
+
+		if err := r.importArtifact(command, artifactPath, addresses, privateKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Running) importArtifact(command string, path string, addresses []ssh.SSHAddress, privateKey []byte) error {
+	file, err := r.FS.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return r.SSHClient.RunSSHCommandWithStdin(command, addresses, privateKey, 5*time.Minute, file, ioutil.Discard, ioutil.Discard)
+}
+
+// sedEscape escapes the characters that are special to sed in a basic regular expression or a replacement.
+func sedEscape(value string, special string) string {
+	escaped := ""
+	for _, char := range value {
+		if strings.ContainsRune(special, char) {
+			escaped += `\`
+		}
+		escaped += string(char)
+	}
+	return escaped
+}
@@ -2,12 +2,15 @@ package vm
 
 import (
 	"errors"
+	"github.com/pivotal-cf/pcfdev-cli/address"
 	"github.com/pivotal-cf/pcfdev-cli/cert"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/debug"
 	"github.com/pivotal-cf/pcfdev-cli/fs"
 	"github.com/pivotal-cf/pcfdev-cli/network"
 	"github.com/pivotal-cf/pcfdev-cli/runner"
+	"github.com/pivotal-cf/pcfdev-cli/seed"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 	"github.com/pivotal-cf/pcfdev-cli/ui"
 	"github.com/pivotal-cf/pcfdev-cli/vbox"
 	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
@@ -15,12 +18,15 @@ import (
 )
 
 type VBoxBuilder struct {
-	Config *config.Config
-	VBox   VBox
-	FS     FS
-	SSH    SSH
-	Client Client
-	UI     UI
+	Config       *config.Config
+	VBox         VBox
+	FS           FS
+	SSH          SSH
+	Client       Client
+	UI           UI
+	AddressTable *address.Table
+	System       System
+	Tracer       *timing.Tracer
 }
 
 func (b *VBoxBuilder) VM(vmName string) (VM, error) {
@@ -36,6 +42,15 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 		}, nil
 	}
 
+	certStore := &cert.CertStore{
+		FS: b.FS,
+		SystemStore: &cert.ConcreteSystemStore{
+			FS:           b.FS,
+			CmdRunner:    &runner.CmdRunner{},
+			AddressTable: b.AddressTable,
+		},
+	}
+
 	unprovisionedVm := &Unprovisioned{
 		VMConfig:  vmConfig,
 		Config:    b.Config,
@@ -47,11 +62,15 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 			UI: b.UI,
 		},
 		Client: b.Client,
+		Tracer: b.Tracer,
 		LogFetcher: &debug.LogFetcher{
-			VMConfig: vmConfig,
-			Config:   b.Config,
-			FS:       b.FS,
-			SSH:      b.SSH,
+			VMConfig:  vmConfig,
+			Config:    b.Config,
+			FS:        b.FS,
+			SSH:       b.SSH,
+			System:    b.System,
+			VBox:      b.VBox,
+			CertStore: certStore,
 			Driver: &vboxdriver.VBoxDriver{
 				FS:        &fs.FS{},
 				CmdRunner: &runner.CmdRunner{},
@@ -70,18 +89,22 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 		HelpText: &ui.HelpText{
 			UI: b.UI,
 		},
-		CertStore: &cert.CertStore{
-			FS: b.FS,
-			SystemStore: &cert.ConcreteSystemStore{
-				FS:        b.FS,
-				CmdRunner: &runner.CmdRunner{},
-			},
+		Seeder: &seed.Seeder{
+			CmdRunner: &runner.CmdRunner{},
+			UI:        b.UI,
 		},
+		Client:    b.Client,
+		System:    b.System,
+		CertStore: certStore,
+		Tracer:    b.Tracer,
 		LogFetcher: &debug.LogFetcher{
-			VMConfig: vmConfig,
-			Config:   b.Config,
-			FS:       b.FS,
-			SSH:      b.SSH,
+			VMConfig:  vmConfig,
+			Config:    b.Config,
+			FS:        b.FS,
+			SSH:       b.SSH,
+			System:    b.System,
+			VBox:      b.VBox,
+			CertStore: certStore,
 			Driver: &vboxdriver.VBoxDriver{
 				FS:        &fs.FS{},
 				CmdRunner: &runner.CmdRunner{},
@@ -103,20 +126,21 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 		}
 
 		return &NotCreated{
-			VBox:     b.VBox,
-			UI:       b.UI,
-			Builder:  b,
-			Config:   b.Config,
-			FS:       b.FS,
-			VMConfig: vmConfig,
-			Network:  &network.Network{},
+			VBox:         b.VBox,
+			UI:           b.UI,
+			Builder:      b,
+			Config:       b.Config,
+			FS:           b.FS,
+			VMConfig:     vmConfig,
+			Network:      &network.Network{},
+			AddressTable: b.AddressTable,
+			Tracer:       b.Tracer,
 		}, nil
 	case vbox.StatusRunning:
 		key, err := b.FS.Read(b.Config.PrivateKeyPath)
 		if err != nil {
-			return &Invalid{
-				Err: errors.New("unable to read private key"),
-			}, nil
+			// A first boot that hung before the key was installed can still be debugged, stopped or destroyed.
+			return unprovisionedVm, nil
 		}
 
 		output, err := b.Client.Status(vmConfig.IP, key)
@@ -141,6 +165,7 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 			SSHClient: b.SSH,
 			VBox:      b.VBox,
 			Builder:   b,
+			Tracer:    b.Tracer,
 		}, nil
 	case vbox.StatusPaused:
 		return &Paused{
@@ -150,6 +175,7 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 			VBox:      b.VBox,
 			Config:    b.Config,
 			FS:        b.FS,
+			Tracer:    b.Tracer,
 		}, nil
 	case vbox.StatusSaved:
 		return &Saved{
@@ -159,6 +185,7 @@ func (b *VBoxBuilder) VM(vmName string) (VM, error) {
 			VBox:      b.VBox,
 			Config:    b.Config,
 			FS:        b.FS,
+			Tracer:    b.Tracer,
 		}, nil
 	default:
 		return &Invalid{
@@ -3,6 +3,7 @@ package vm_test
 import (
 	"errors"
 	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/address"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/vbox"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
@@ -21,6 +22,7 @@ var _ = Describe("Builder", func() {
 			mockFS     *mocks.MockFS
 			mockSSH    *mocks.MockSSH
 			mockClient *mocks.MockClient
+			mockSystem *mocks.MockSystem
 			mockUI     *mocks.MockUI
 			builder    *vm.VBoxBuilder
 			conf       *config.Config
@@ -32,6 +34,7 @@ var _ = Describe("Builder", func() {
 			mockFS = mocks.NewMockFS(mockCtrl)
 			mockSSH = mocks.NewMockSSH(mockCtrl)
 			mockClient = mocks.NewMockClient(mockCtrl)
+			mockSystem = mocks.NewMockSystem(mockCtrl)
 			mockUI = mocks.NewMockUI(mockCtrl)
 			conf = &config.Config{
 				MinMemory:      100,
@@ -41,12 +44,14 @@ var _ = Describe("Builder", func() {
 			}
 
 			builder = &vm.VBoxBuilder{
-				VBox:   mockVBox,
-				FS:     mockFS,
-				SSH:    mockSSH,
-				Client: mockClient,
-				UI:     mockUI,
-				Config: conf,
+				VBox:         mockVBox,
+				FS:           mockFS,
+				SSH:          mockSSH,
+				Client:       mockClient,
+				UI:           mockUI,
+				Config:       conf,
+				AddressTable: address.DefaultTable(),
+				System:       mockSystem,
 			}
 		})
 
@@ -68,6 +73,7 @@ var _ = Describe("Builder", func() {
 				case *vm.NotCreated:
 					Expect(u.VMConfig.Name).To(Equal("some-vm"))
 					Expect(u.Network).NotTo(BeNil())
+					Expect(u.AddressTable).To(BeIdenticalTo(builder.AddressTable))
 				default:
 					Fail("wrong type")
 				}
@@ -179,6 +185,9 @@ var _ = Describe("Builder", func() {
 						Expect(u.CertStore).NotTo(BeNil())
 						Expect(u.CmdRunner).NotTo(BeNil())
 						Expect(u.HelpText).NotTo(BeNil())
+						Expect(u.Seeder).NotTo(BeNil())
+						Expect(u.Client).NotTo(BeNil())
+						Expect(u.System).To(BeIdenticalTo(builder.System))
 					default:
 						Fail("wrong type")
 					}
@@ -246,7 +255,7 @@ var _ = Describe("Builder", func() {
 			})
 
 			Context("when getting private key returns an error", func() {
-				It("should return an invalid vm", func() {
+				It("should return an unprovisioned vm so that it can still be debugged or stopped", func() {
 					expectedVMConfig := &config.VMConfig{
 						IP:      "192.168.11.11",
 						Memory:  uint64(3456),
@@ -260,12 +269,12 @@ var _ = Describe("Builder", func() {
 						mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error")),
 					)
 
-					invalidVM, err := builder.VM("some-vm")
+					unprovisionedVM, err := builder.VM("some-vm")
 					Expect(err).NotTo(HaveOccurred())
 
-					switch u := invalidVM.(type) {
-					case *vm.Invalid:
-						Expect(u.Err).To(MatchError("unable to read private key"))
+					switch u := unprovisionedVM.(type) {
+					case *vm.Unprovisioned:
+						Expect(u.VMConfig).To(Equal(expectedVMConfig))
 					default:
 						Fail("wrong type")
 					}
@@ -1,13 +1,14 @@
 package client
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"strings"
 	"time"
 
+	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 )
 
@@ -26,6 +27,10 @@ type StatusResponse struct {
 	Status string `json:"status"`
 }
 
+type ReplaceSecretsRequest struct {
+	Password string `json:"password"`
+}
+
 const APIPort = 8090
 
 func (c *Client) Status(sshIP string, privateKey []byte) (string, error) {
@@ -84,8 +89,13 @@ func (c *Client) ReplaceSecrets(sshIP, password string, privateKey []byte) error
 		func(host string) {
 			uri := fmt.Sprintf("%s/replace-secrets", host)
 
-			body := strings.NewReader(fmt.Sprintf(`{"password":"%s"}`, password))
-			req, err := http.NewRequest("PUT", uri, body)
+			body, err := json.Marshal(&ReplaceSecretsRequest{Password: password})
+			if err != nil {
+				errorInTunnel = err
+				return
+			}
+
+			req, err := http.NewRequest("PUT", uri, bytes.NewReader(body))
 			if err != nil {
 				errorInTunnel = err
 				return
@@ -115,6 +125,54 @@ func (c *Client) ReplaceSecrets(sshIP, password string, privateKey []byte) error
 	}
 }
 
+// Credentials returns nil credentials for OVAs without a credentials endpoint, so the caller can fall back to what it recorded about the VM.
+func (c *Client) Credentials(sshIP string, privateKey []byte) (*config.Credentials, error) {
+	var resp *http.Response
+	var errorInTunnel error
+	errorWithTunnel := c.SSHClient.WithSSHTunnel(
+		fmt.Sprintf("127.0.0.1:%d", APIPort),
+		[]ssh.SSHAddress{{IP: sshIP, Port: "22"}},
+		privateKey,
+		time.Minute,
+		func(host string) {
+			var err error
+			resp, err = c.HttpClient.Get(fmt.Sprintf("%s/credentials", host))
+			if err != nil {
+				errorInTunnel = &PCFDevVmUnreachableError{err}
+			}
+		},
+	)
+
+	if errorWithTunnel != nil {
+		return nil, errorWithTunnel
+	}
+
+	if errorInTunnel != nil {
+		return nil, errorInTunnel
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		data, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		credentials := &config.Credentials{}
+		if err := json.Unmarshal(data, credentials); err != nil {
+			return nil, &InvalidJSONError{err}
+		}
+
+		return credentials, nil
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, &CredentialsRetrievalError{fmt.Errorf("PCF Dev API returned: %d", resp.StatusCode)}
+	}
+}
+
 type PCFDevVmUnreachableError struct {
 	Err error
 }
@@ -131,6 +189,14 @@ func (e *StatusRetrievalError) Error() string {
 	return fmt.Sprintf("failed to retrieve status: %+v", e.Err)
 }
 
+type CredentialsRetrievalError struct {
+	Err error
+}
+
+func (e *CredentialsRetrievalError) Error() string {
+	return fmt.Sprintf("failed to retrieve credentials: %+v", e.Err)
+}
+
 type InvalidJSONError struct {
 	Err error
 }
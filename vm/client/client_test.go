@@ -12,6 +12,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	c "github.com/pivotal-cf/pcfdev-cli/vm/client"
 	"github.com/pivotal-cf/pcfdev-cli/vm/client/mocks"
@@ -207,6 +208,32 @@ var _ = Describe("PCF Dev Client", func() {
 			Expect(client.ReplaceSecrets("some-ip", "some-master-password", []byte("some-private-key"))).To(Succeed())
 		})
 
+		Context("when the password contains characters that must be escaped", func() {
+			It("should encode the password as JSON", func() {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+
+					Expect(r.URL.Path).To(Equal("/replace-secrets"))
+					Expect(ioutil.ReadAll(r.Body)).To(MatchJSON(`{"password":"some-\"quoted\"\\password"}`))
+					w.WriteHeader(200)
+				}
+
+				host := httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				mockSSH.EXPECT().WithSSHTunnel(
+					fmt.Sprintf("127.0.0.1:%d", c.APIPort),
+					[]ssh.SSHAddress{{IP: "some-ip", Port: "22"}},
+					[]byte("some-private-key"),
+					time.Minute,
+					gomock.Any(),
+				).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+					block(host)
+				})
+
+				Expect(client.ReplaceSecrets("some-ip", `some-"quoted"\password`, []byte("some-private-key"))).To(Succeed())
+			})
+		})
+
 		Context("when there is a bad response from the api", func() {
 			It("should return an error", func() {
 				host := "http://some-bad-host"
@@ -266,4 +293,81 @@ var _ = Describe("PCF Dev Client", func() {
 			})
 		})
 	})
+
+	Describe("#Credentials", func() {
+		var respondWith func(status int, body string)
+
+		BeforeEach(func() {
+			respondWith = func(status int, body string) {
+				handler := func(w http.ResponseWriter, r *http.Request) {
+					defer GinkgoRecover()
+
+					switch r.URL.Path {
+					case "/credentials":
+						Expect(r.Method).To(Equal("GET"))
+						w.WriteHeader(status)
+						w.Write([]byte(body))
+					default:
+						Fail("unexpected server request")
+					}
+				}
+				host := httptest.NewServer(http.HandlerFunc(handler)).URL
+
+				mockSSH.EXPECT().WithSSHTunnel(
+					fmt.Sprintf("127.0.0.1:%d", c.APIPort),
+					[]ssh.SSHAddress{{IP: "some-ip", Port: "22"}},
+					[]byte("some-private-key"),
+					time.Minute,
+					gomock.Any(),
+				).Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, block func(string)) {
+					block(host)
+				})
+			}
+		})
+
+		It("should return the credentials of the VM", func() {
+			respondWith(200, `{"admin_username":"some-admin","user_username":"some-user","master_password":true}`)
+
+			Expect(client.Credentials("some-ip", []byte("some-private-key"))).To(Equal(&config.Credentials{
+				AdminUsername:  "some-admin",
+				UserUsername:   "some-user",
+				MasterPassword: true,
+			}))
+		})
+
+		Context("when the VM does not report its credentials", func() {
+			It("should return no credentials so that the caller can fall back", func() {
+				respondWith(404, "")
+
+				Expect(client.Credentials("some-ip", []byte("some-private-key"))).To(BeNil())
+			})
+		})
+
+		Context("when there is invalid JSON", func() {
+			It("should return an error", func() {
+				respondWith(200, "some-bad-json")
+
+				_, err := client.Credentials("some-ip", []byte("some-private-key"))
+				Expect(err).To(MatchError(ContainSubstring("failed to parse JSON response:")))
+			})
+		})
+
+		Context("when it fails to retrieve the credentials", func() {
+			It("should return an error", func() {
+				respondWith(500, "")
+
+				_, err := client.Credentials("some-ip", []byte("some-private-key"))
+				Expect(err).To(MatchError("failed to retrieve credentials: PCF Dev API returned: 500"))
+			})
+		})
+
+		Context("when creating the tunnel fails", func() {
+			It("should return an error", func() {
+				mockSSH.EXPECT().WithSSHTunnel(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+
+				_, err := client.Credentials("some-ip", []byte("some-private-key"))
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+	})
 })
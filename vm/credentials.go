@@ -0,0 +1,16 @@
+package vm
+
+import "github.com/pivotal-cf/pcfdev-cli/config"
+
+func fetchCredentials(client Client, vmConfig *config.VMConfig, privateKey []byte) (*config.Credentials, error) {
+	credentials, err := client.Credentials(vmConfig.IP, privateKey)
+	if err != nil || credentials != nil {
+		return credentials, err
+	}
+
+	// The OVA cannot report its credentials, so rely on how the VM was started.
+	if vmConfig.MasterPassword {
+		return config.MasterPasswordCredentials(), nil
+	}
+	return config.DefaultCredentials(), nil
+}
@@ -0,0 +1,33 @@
+package vm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func fetchDebugLogs(logFetcher LogFetcher, ui UI, opts *DebugOpts) error {
+	result, err := logFetcher.FetchLogs(opts.fetchOpts())
+	if err != nil {
+		return &FetchLogsError{err}
+	}
+
+	ui.Say("Debug logs written to %s. While some scrubbing has taken place, please remove any remaining sensitive information from these logs before sharing.", opts.Output)
+	if len(result.Redactions) > 0 {
+		labels := []string{}
+		for label := range result.Redactions {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+
+		counts := []string{}
+		for _, label := range labels {
+			counts = append(counts, fmt.Sprintf("%d %s", result.Redactions[label], label))
+		}
+		ui.Say("Redacted: %s.", strings.Join(counts, ", "))
+	}
+	if result.MappingPath != "" {
+		ui.Say("The original values behind the pseudonyms were written to %s. Keep this file private, it is not part of the tarball.", result.MappingPath)
+	}
+	return nil
+}
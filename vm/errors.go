@@ -1,6 +1,9 @@
 package vm
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type StartVMError struct {
 	Err error
@@ -42,6 +45,15 @@ func (e *ProvisionVMError) Error() string {
 	return fmt.Sprintf("failed to provision VM: %s", e.Err)
 }
 
+type ProvisionTimeoutError struct {
+	Timeout time.Duration
+	LogPath string
+}
+
+func (e *ProvisionTimeoutError) Error() string {
+	return fmt.Sprintf("provisioning did not finish within %s, the full output was saved to %s. Run 'cf dev start -p --provision-timeout DURATION' to try again with a longer timeout", e.Timeout, e.LogPath)
+}
+
 type StopVMError struct {
 	Err error
 }
@@ -66,6 +78,14 @@ func (e *FetchLogsError) Error() string {
 	return fmt.Sprintf("failed to retrieve logs: %s", e.Err)
 }
 
+type LogsError struct {
+	Err error
+}
+
+func (e *LogsError) Error() string {
+	return fmt.Sprintf("failed to stream logs: %s", e.Err)
+}
+
 type TrustError struct {
 	Err error
 }
@@ -81,3 +101,51 @@ type TargetError struct {
 func (e *TargetError) Error() string {
 	return fmt.Sprintf("failed to target PCF Dev: %s", e.Err)
 }
+
+type ResizeDiskError struct {
+	Err error
+}
+
+func (e *ResizeDiskError) Error() string {
+	return fmt.Sprintf("failed to resize disk: %s", e.Err)
+}
+
+type SharedFolderError struct {
+	Err error
+}
+
+func (e *SharedFolderError) Error() string {
+	return fmt.Sprintf("failed to update shared folders: %s", e.Err)
+}
+
+type BackupError struct {
+	Err error
+}
+
+func (e *BackupError) Error() string {
+	return fmt.Sprintf("failed to back up PCF Dev: %s", e.Err)
+}
+
+type RestoreError struct {
+	Err error
+}
+
+func (e *RestoreError) Error() string {
+	return fmt.Sprintf("failed to restore PCF Dev: %s", e.Err)
+}
+
+type SeedError struct {
+	Err error
+}
+
+func (e *SeedError) Error() string {
+	return fmt.Sprintf("failed to seed PCF Dev: %s", e.Err)
+}
+
+type RotatePasswordError struct {
+	Err error
+}
+
+func (e *RotatePasswordError) Error() string {
+	return fmt.Sprintf("failed to rotate master password: %s", e.Err)
+}
@@ -1,6 +1,10 @@
 package vm
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
 
 type Invalid struct {
 	Err error
@@ -34,7 +38,11 @@ func (i *Invalid) Resume() error {
 	return i.err()
 }
 
-func (i *Invalid) GetDebugLogs() error {
+func (i *Invalid) GetDebugLogs(opts *DebugOpts) error {
+	return i.err()
+}
+
+func (i *Invalid) Logs(opts *LogsOpts) error {
 	return i.err()
 }
 
@@ -42,7 +50,7 @@ func (i *Invalid) Trust(startOps *StartOpts) error {
 	return i.err()
 }
 
-func (i *Invalid) Target(autoTarget bool) error {
+func (i *Invalid) Target(opts *TargetOpts) error {
 	return i.err()
 }
 
@@ -50,6 +58,38 @@ func (i *Invalid) SSH() error {
 	return i.err()
 }
 
+func (i *Invalid) ResizeDisk(size uint64) error {
+	return i.err()
+}
+
+func (i *Invalid) AddShare(sharedFolder *config.SharedFolder) error {
+	return i.err()
+}
+
+func (i *Invalid) RemoveShare(guestPath string) error {
+	return i.err()
+}
+
+func (i *Invalid) ListShares() error {
+	return i.err()
+}
+
+func (i *Invalid) Backup(path string) error {
+	return i.err()
+}
+
+func (i *Invalid) Restore(path string) error {
+	return i.err()
+}
+
+func (i *Invalid) Seed(path string) error {
+	return i.err()
+}
+
+func (i *Invalid) RotatePassword(password string) error {
+	return i.err()
+}
+
 func (i *Invalid) message() string {
 	return "PCF Dev is in an invalid state. Please run 'cf dev destroy'"
 }
@@ -3,6 +3,7 @@ package vm_test
 import (
 	"errors"
 
+	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 
 	. "github.com/onsi/ginkgo"
@@ -58,7 +59,13 @@ var _ = Describe("Invalid", func() {
 
 	Describe("GetDebugLogs", func() {
 		It("should say a message", func() {
-			Expect(invalid.GetDebugLogs()).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+			Expect(invalid.GetDebugLogs(&vm.DebugOpts{})).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Logs", func() {
+		It("should return an error", func() {
+			Expect(invalid.Logs(&vm.LogsOpts{})).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
 		})
 	})
 
@@ -70,7 +77,7 @@ var _ = Describe("Invalid", func() {
 
 	Describe("Target", func() {
 		It("should say a message", func() {
-			Expect(invalid.Target(false)).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+			Expect(invalid.Target(&vm.TargetOpts{})).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
 		})
 	})
 
@@ -79,4 +86,50 @@ var _ = Describe("Invalid", func() {
 			Expect(invalid.SSH()).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
 		})
 	})
+	Describe("ResizeDisk", func() {
+		It("should return an error", func() {
+			Expect(invalid.ResizeDisk(uint64(81920))).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+	Describe("AddShare", func() {
+		It("should return an error", func() {
+			Expect(invalid.AddShare(&config.SharedFolder{GuestPath: "/some-guest-path"})).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("RemoveShare", func() {
+		It("should return an error", func() {
+			Expect(invalid.RemoveShare("/some-guest-path")).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("ListShares", func() {
+		It("should return an error", func() {
+			Expect(invalid.ListShares()).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Backup", func() {
+		It("should return an error", func() {
+			Expect(invalid.Backup("some-backup-path")).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Restore", func() {
+		It("should return an error", func() {
+			Expect(invalid.Restore("some-backup-path")).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("Seed", func() {
+		It("should return an error", func() {
+			Expect(invalid.Seed("some-seed-path")).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
+
+	Describe("RotatePassword", func() {
+		It("should return an error", func() {
+			Expect(invalid.RotatePassword("some-password")).To(MatchError("some-error.\nPCF Dev is in an invalid state. Please run 'cf dev destroy'"))
+		})
+	})
 })
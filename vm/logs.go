@@ -0,0 +1,130 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/term"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/debug"
+	"github.com/pivotal-cf/pcfdev-cli/ssh"
+)
+
+const logsTailLines = 100
+
+var defaultLogPaths = []string{"/var/pcfdev/provision.log", "/var/pcfdev/reset.log"}
+
+func streamLogs(fs FS, ui UI, sshClient SSH, conf *config.Config, vmConfig *config.VMConfig, opts *LogsOpts) error {
+	privateKeyBytes, err := fs.Read(conf.PrivateKeyPath)
+	if err != nil {
+		return &LogsError{err}
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: vmConfig.SSHPort},
+		{IP: vmConfig.IP, Port: "22"},
+	}
+
+	paths := defaultLogPaths
+	if len(opts.Components) > 0 {
+		paths = debug.ComponentLogGlobs(opts.Components)
+	}
+
+	command := fmt.Sprintf("sudo sh -c 'ls -1d %s 2>/dev/null | xargs -r tail -v -n %d'", strings.Join(paths, " "), logsTailLines)
+	if opts.Follow {
+		command = fmt.Sprintf("sudo sh -c 'tail -v -n %d -F %s'", logsTailLines, strings.Join(paths, " "))
+	}
+
+	printer := &logPrinter{
+		ui:     ui,
+		prefix: len(paths) > 1 || strings.Contains(paths[0], "*"),
+	}
+	var stdout io.WriteCloser = printer
+	if opts.Scrub {
+		scrubber, err := debug.LoadSensitiveInformationScrubber(fs, conf.ScrubConfigPath, false)
+		if err != nil {
+			return &LogsError{err}
+		}
+		stdout = scrubber.ScrubWriter(printer)
+	}
+	_, _, stderr := term.StdStreams()
+
+	err = sshClient.RunSSHCommandUntil(command, addresses, privateKeyBytes, 30*time.Second, stdout, stderr, opts.Stop)
+	stdout.Close()
+	printer.Close()
+	if err != nil && err != ssh.ErrInterrupted {
+		return &LogsError{err}
+	}
+	return nil
+}
+
+type logPrinter struct {
+	ui     UI
+	prefix bool
+
+	source  string
+	pending []byte
+	blank   bool
+}
+
+func (p *logPrinter) Write(data []byte) (int, error) {
+	p.pending = append(p.pending, data...)
+	for {
+		index := bytes.IndexByte(p.pending, '\n')
+		if index < 0 {
+			return len(data), nil
+		}
+		p.printLine(string(p.pending[:index]))
+		p.pending = p.pending[index+1:]
+	}
+}
+
+func (p *logPrinter) Close() error {
+	if len(p.pending) > 0 {
+		p.printLine(string(p.pending))
+		p.pending = nil
+	}
+	if p.blank {
+		p.blank = false
+		p.say("")
+	}
+	return nil
+}
+
+func (p *logPrinter) printLine(line string) {
+	if strings.HasPrefix(line, "==> ") && strings.HasSuffix(line, " <==") {
+		p.blank = false
+		p.source = logSource(strings.TrimSuffix(strings.TrimPrefix(line, "==> "), " <=="))
+		return
+	}
+
+	if p.blank {
+		p.blank = false
+		p.say("")
+	}
+	if line == "" {
+		p.blank = true
+		return
+	}
+	p.say(line)
+}
+
+func (p *logPrinter) say(line string) {
+	if p.prefix && p.source != "" {
+		p.ui.Say("[%s] %s", p.source, line)
+		return
+	}
+	p.ui.Say("%s", line)
+}
+
+func logSource(logPath string) string {
+	if strings.HasPrefix(logPath, "/var/vcap/sys/log/") {
+		return strings.TrimPrefix(logPath, "/var/vcap/sys/log/")
+	}
+	return path.Base(logPath)
+}
@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	config "github.com/pivotal-cf/pcfdev-cli/config"
 )
 
 // Mock of Client interface
@@ -28,6 +29,17 @@ func (_m *MockClient) EXPECT() *_MockClientRecorder {
 	return _m.recorder
 }
 
+func (_m *MockClient) Credentials(_param0 string, _param1 []byte) (*config.Credentials, error) {
+	ret := _m.ctrl.Call(_m, "Credentials", _param0, _param1)
+	ret0, _ := ret[0].(*config.Credentials)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockClientRecorder) Credentials(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Credentials", arg0, arg1)
+}
+
 func (_m *MockClient) ReplaceSecrets(_param0 string, _param1 string, _param2 []byte) error {
 	ret := _m.ctrl.Call(_m, "ReplaceSecrets", _param0, _param1, _param2)
 	ret0, _ := ret[0].(error)
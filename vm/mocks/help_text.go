@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	config "github.com/pivotal-cf/pcfdev-cli/config"
 )
 
 // Mock of HelpText interface
@@ -28,10 +29,10 @@ func (_m *MockHelpText) EXPECT() *_MockHelpTextRecorder {
 	return _m.recorder
 }
 
-func (_m *MockHelpText) Print(_param0 string, _param1 bool) {
-	_m.ctrl.Call(_m, "Print", _param0, _param1)
+func (_m *MockHelpText) Print(_param0 string, _param1 *config.Credentials, _param2 bool) {
+	_m.ctrl.Call(_m, "Print", _param0, _param1, _param2)
 }
 
-func (_mr *_MockHelpTextRecorder) Print(arg0, arg1 interface{}) *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "Print", arg0, arg1)
+func (_mr *_MockHelpTextRecorder) Print(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Print", arg0, arg1, arg2)
 }
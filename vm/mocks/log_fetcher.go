@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	debug "github.com/pivotal-cf/pcfdev-cli/debug"
 )
 
 // Mock of LogFetcher interface
@@ -28,12 +29,13 @@ func (_m *MockLogFetcher) EXPECT() *_MockLogFetcherRecorder {
 	return _m.recorder
 }
 
-func (_m *MockLogFetcher) FetchLogs() error {
-	ret := _m.ctrl.Call(_m, "FetchLogs")
-	ret0, _ := ret[0].(error)
-	return ret0
+func (_m *MockLogFetcher) FetchLogs(_param0 *debug.FetchOpts) (*debug.Result, error) {
+	ret := _m.ctrl.Call(_m, "FetchLogs", _param0)
+	ret0, _ := ret[0].(*debug.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-func (_mr *_MockLogFetcherRecorder) FetchLogs() *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "FetchLogs")
+func (_mr *_MockLogFetcherRecorder) FetchLogs(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "FetchLogs", arg0)
 }
@@ -64,6 +64,26 @@ func (_mr *_MockSSHRecorder) RunSSHCommand(arg0, arg1, arg2, arg3, arg4, arg5 in
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "RunSSHCommand", arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+func (_m *MockSSH) RunSSHCommandUntil(_param0 string, _param1 []ssh.SSHAddress, _param2 []byte, _param3 time.Duration, _param4 io.Writer, _param5 io.Writer, _param6 <-chan struct{}) error {
+	ret := _m.ctrl.Call(_m, "RunSSHCommandUntil", _param0, _param1, _param2, _param3, _param4, _param5, _param6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockSSHRecorder) RunSSHCommandUntil(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RunSSHCommandUntil", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+func (_m *MockSSH) RunSSHCommandWithStdin(_param0 string, _param1 []ssh.SSHAddress, _param2 []byte, _param3 time.Duration, _param4 io.Reader, _param5 io.Writer, _param6 io.Writer) error {
+	ret := _m.ctrl.Call(_m, "RunSSHCommandWithStdin", _param0, _param1, _param2, _param3, _param4, _param5, _param6)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockSSHRecorder) RunSSHCommandWithStdin(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RunSSHCommandWithStdin", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 func (_m *MockSSH) StartSSHSession(_param0 []ssh.SSHAddress, _param1 []byte, _param2 time.Duration, _param3 io.Reader, _param4 io.Writer, _param5 io.Writer) error {
 	ret := _m.ctrl.Call(_m, "StartSSHSession", _param0, _param1, _param2, _param3, _param4, _param5)
 	ret0, _ := ret[0].(error)
@@ -6,6 +6,7 @@ package mocks
 import (
 	gomock "github.com/golang/mock/gomock"
 	config "github.com/pivotal-cf/pcfdev-cli/config"
+	vboxdriver "github.com/pivotal-cf/pcfdev-cli/vboxdriver"
 )
 
 // Mock of VBox interface
@@ -29,6 +30,27 @@ func (_m *MockVBox) EXPECT() *_MockVBoxRecorder {
 	return _m.recorder
 }
 
+func (_m *MockVBox) AddSharedFolder(_param0 *config.VMConfig, _param1 *config.SharedFolder) error {
+	ret := _m.ctrl.Call(_m, "AddSharedFolder", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVBoxRecorder) AddSharedFolder(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddSharedFolder", arg0, arg1)
+}
+
+func (_m *MockVBox) DiskSize(_param0 *config.VMConfig) (uint64, error) {
+	ret := _m.ctrl.Call(_m, "DiskSize", _param0)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVBoxRecorder) DiskSize(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "DiskSize", arg0)
+}
+
 func (_m *MockVBox) ImportVM(_param0 *config.VMConfig) error {
 	ret := _m.ctrl.Call(_m, "ImportVM", _param0)
 	ret0, _ := ret[0].(error)
@@ -39,6 +61,16 @@ func (_mr *_MockVBoxRecorder) ImportVM(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ImportVM", arg0)
 }
 
+func (_m *MockVBox) MountSharedFolders(_param0 *config.VMConfig) error {
+	ret := _m.ctrl.Call(_m, "MountSharedFolders", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVBoxRecorder) MountSharedFolders(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "MountSharedFolders", arg0)
+}
+
 func (_m *MockVBox) PowerOffVM(_param0 *config.VMConfig) error {
 	ret := _m.ctrl.Call(_m, "PowerOffVM", _param0)
 	ret0, _ := ret[0].(error)
@@ -49,6 +81,36 @@ func (_mr *_MockVBoxRecorder) PowerOffVM(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "PowerOffVM", arg0)
 }
 
+func (_m *MockVBox) RecordMasterPassword(_param0 *config.VMConfig) error {
+	ret := _m.ctrl.Call(_m, "RecordMasterPassword", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVBoxRecorder) RecordMasterPassword(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RecordMasterPassword", arg0)
+}
+
+func (_m *MockVBox) RemoveSharedFolder(_param0 *config.VMConfig, _param1 string) error {
+	ret := _m.ctrl.Call(_m, "RemoveSharedFolder", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVBoxRecorder) RemoveSharedFolder(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveSharedFolder", arg0, arg1)
+}
+
+func (_m *MockVBox) ResizeDisk(_param0 *config.VMConfig, _param1 uint64) error {
+	ret := _m.ctrl.Call(_m, "ResizeDisk", _param0, _param1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVBoxRecorder) ResizeDisk(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResizeDisk", arg0, arg1)
+}
+
 func (_m *MockVBox) ResumePausedVM(_param0 *config.VMConfig) error {
 	ret := _m.ctrl.Call(_m, "ResumePausedVM", _param0)
 	ret0, _ := ret[0].(error)
@@ -69,6 +131,17 @@ func (_mr *_MockVBoxRecorder) ResumeSavedVM(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResumeSavedVM", arg0)
 }
 
+func (_m *MockVBox) SharedFolders(_param0 *config.VMConfig) ([]*config.SharedFolder, error) {
+	ret := _m.ctrl.Call(_m, "SharedFolders", _param0)
+	ret0, _ := ret[0].([]*config.SharedFolder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVBoxRecorder) SharedFolders(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "SharedFolders", arg0)
+}
+
 func (_m *MockVBox) StartVM(_param0 *config.VMConfig) error {
 	ret := _m.ctrl.Call(_m, "StartVM", _param0)
 	ret0, _ := ret[0].(error)
@@ -120,3 +193,14 @@ func (_m *MockVBox) VMStatus(_param0 string) (string, error) {
 func (_mr *_MockVBoxRecorder) VMStatus(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "VMStatus", arg0)
 }
+
+func (_m *MockVBox) Version() (*vboxdriver.VBoxDriverVersion, error) {
+	ret := _m.ctrl.Call(_m, "Version")
+	ret0, _ := ret[0].(*vboxdriver.VBoxDriverVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVBoxRecorder) Version() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Version")
+}
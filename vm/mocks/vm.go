@@ -5,6 +5,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	config "github.com/pivotal-cf/pcfdev-cli/config"
 	vm "github.com/pivotal-cf/pcfdev-cli/vm"
 )
 
@@ -29,14 +30,54 @@ func (_m *MockVM) EXPECT() *_MockVMRecorder {
 	return _m.recorder
 }
 
-func (_m *MockVM) GetDebugLogs() error {
-	ret := _m.ctrl.Call(_m, "GetDebugLogs")
+func (_m *MockVM) AddShare(_param0 *config.SharedFolder) error {
+	ret := _m.ctrl.Call(_m, "AddShare", _param0)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (_mr *_MockVMRecorder) GetDebugLogs() *gomock.Call {
-	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDebugLogs")
+func (_mr *_MockVMRecorder) AddShare(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "AddShare", arg0)
+}
+
+func (_m *MockVM) Backup(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "Backup", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Backup(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Backup", arg0)
+}
+
+func (_m *MockVM) GetDebugLogs(_param0 *vm.DebugOpts) error {
+	ret := _m.ctrl.Call(_m, "GetDebugLogs", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) GetDebugLogs(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetDebugLogs", arg0)
+}
+
+func (_m *MockVM) ListShares() error {
+	ret := _m.ctrl.Call(_m, "ListShares")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) ListShares() *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ListShares")
+}
+
+func (_m *MockVM) Logs(_param0 *vm.LogsOpts) error {
+	ret := _m.ctrl.Call(_m, "Logs", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Logs(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Logs", arg0)
 }
 
 func (_m *MockVM) Provision(_param0 *vm.StartOpts) error {
@@ -49,6 +90,36 @@ func (_mr *_MockVMRecorder) Provision(arg0 interface{}) *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Provision", arg0)
 }
 
+func (_m *MockVM) RemoveShare(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "RemoveShare", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) RemoveShare(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RemoveShare", arg0)
+}
+
+func (_m *MockVM) ResizeDisk(_param0 uint64) error {
+	ret := _m.ctrl.Call(_m, "ResizeDisk", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) ResizeDisk(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ResizeDisk", arg0)
+}
+
+func (_m *MockVM) Restore(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "Restore", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Restore(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Restore", arg0)
+}
+
 func (_m *MockVM) Resume() error {
 	ret := _m.ctrl.Call(_m, "Resume")
 	ret0, _ := ret[0].(error)
@@ -59,6 +130,16 @@ func (_mr *_MockVMRecorder) Resume() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Resume")
 }
 
+func (_m *MockVM) RotatePassword(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "RotatePassword", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) RotatePassword(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "RotatePassword", arg0)
+}
+
 func (_m *MockVM) SSH() error {
 	ret := _m.ctrl.Call(_m, "SSH")
 	ret0, _ := ret[0].(error)
@@ -69,6 +150,16 @@ func (_mr *_MockVMRecorder) SSH() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "SSH")
 }
 
+func (_m *MockVM) Seed(_param0 string) error {
+	ret := _m.ctrl.Call(_m, "Seed", _param0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVMRecorder) Seed(arg0 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Seed", arg0)
+}
+
 func (_m *MockVM) Start(_param0 *vm.StartOpts) error {
 	ret := _m.ctrl.Call(_m, "Start", _param0)
 	ret0, _ := ret[0].(error)
@@ -109,7 +200,7 @@ func (_mr *_MockVMRecorder) Suspend() *gomock.Call {
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "Suspend")
 }
 
-func (_m *MockVM) Target(_param0 bool) error {
+func (_m *MockVM) Target(_param0 *vm.TargetOpts) error {
 	ret := _m.ctrl.Call(_m, "Target", _param0)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -8,16 +8,19 @@ import (
 
 	"github.com/pivotal-cf/pcfdev-cli/address"
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 )
 
 type NotCreated struct {
-	VBox     VBox
-	UI       UI
-	Builder  Builder
-	Config   *config.Config
-	VMConfig *config.VMConfig
-	FS       FS
-	Network  Network
+	VBox         VBox
+	UI           UI
+	Builder      Builder
+	Config       *config.Config
+	VMConfig     *config.VMConfig
+	FS           FS
+	Network      Network
+	AddressTable *address.Table
+	Tracer       *timing.Tracer
 }
 
 func (n *NotCreated) Stop() error {
@@ -67,7 +70,11 @@ func (n *NotCreated) VerifyStartOpts(opts *StartOpts) error {
 		}
 	}
 
-	if opts.IP == "" && opts.Domain != "" && !address.IsDomainAllowed(opts.Domain) {
+	if opts.Network == config.NetworkBridged && opts.IP != "" {
+		return errors.New("the -i flag cannot be used with bridged networking, the IP address is assigned by DHCP")
+	}
+
+	if opts.Network != config.NetworkBridged && opts.IP == "" && opts.Domain != "" && !n.AddressTable.IsDomainAllowed(opts.Domain) {
 		return errors.New(fmt.Sprintf("%s is not one of the allowed PCF Dev domains", opts.Domain))
 	}
 
@@ -153,14 +160,18 @@ func (n *NotCreated) Start(opts *StartOpts) error {
 
 	n.UI.Say(fmt.Sprintf("Allocating %d MB out of %d MB total system memory (%d MB free).", memory, n.Config.TotalMemory, n.Config.FreeMemory))
 	n.UI.Say("Importing VM...")
-	if err := n.VBox.ImportVM(&config.VMConfig{
-		Name:    n.VMConfig.Name,
-		Memory:  memory,
-		CPUs:    cpus,
-		OVAPath: ovaPath,
-		IP:      opts.IP,
-
-		Domain: opts.Domain,
+	if err := n.Tracer.Trace("import", func() error {
+		return n.VBox.ImportVM(&config.VMConfig{
+			Name:    n.VMConfig.Name,
+			Memory:  memory,
+			CPUs:    cpus,
+			OVAPath: ovaPath,
+			IP:      opts.IP,
+
+			Domain:        opts.Domain,
+			Network:       opts.Network,
+			BridgeAdapter: opts.BridgeAdapter,
+		})
 	}); err != nil {
 		return &ImportVMError{err}
 	}
@@ -189,17 +200,22 @@ func (n *NotCreated) Resume() error {
 	return nil
 }
 
-func (n *NotCreated) GetDebugLogs() error {
+func (n *NotCreated) GetDebugLogs(opts *DebugOpts) error {
 	n.UI.Say("No VM created, cannot retrieve debug logs.")
 	return nil
 }
 
+func (n *NotCreated) Logs(opts *LogsOpts) error {
+	n.UI.Say("No VM created, cannot stream logs.")
+	return nil
+}
+
 func (n *NotCreated) Trust(startOps *StartOpts) error {
 	n.UI.Say("No VM created, cannot trust VM certificates.")
 	return nil
 }
 
-func (n *NotCreated) Target(autoTarget bool) error {
+func (n *NotCreated) Target(opts *TargetOpts) error {
 	n.UI.Say("No VM created, cannot target PCF Dev.")
 	return nil
 }
@@ -208,3 +224,43 @@ func (n *NotCreated) SSH() error {
 	n.UI.Say("No VM created, cannot SSH to PCF Dev.")
 	return nil
 }
+
+func (n *NotCreated) ResizeDisk(size uint64) error {
+	n.UI.Say("No VM created, cannot resize disk.")
+	return nil
+}
+
+func (n *NotCreated) AddShare(sharedFolder *config.SharedFolder) error {
+	n.UI.Say("No VM created, cannot add shared folders.")
+	return nil
+}
+
+func (n *NotCreated) RemoveShare(guestPath string) error {
+	n.UI.Say("No VM created, cannot remove shared folders.")
+	return nil
+}
+
+func (n *NotCreated) ListShares() error {
+	n.UI.Say("No VM created, no shared folders.")
+	return nil
+}
+
+func (n *NotCreated) Backup(path string) error {
+	n.UI.Say("No VM created, cannot back up PCF Dev.")
+	return nil
+}
+
+func (n *NotCreated) Restore(path string) error {
+	n.UI.Say("No VM created, cannot restore PCF Dev.")
+	return nil
+}
+
+func (n *NotCreated) Seed(path string) error {
+	n.UI.Say("No VM created, cannot seed PCF Dev.")
+	return nil
+}
+
+func (n *NotCreated) RotatePassword(password string) error {
+	n.UI.Say("No VM created, cannot rotate the master password.")
+	return nil
+}
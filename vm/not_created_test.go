@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 
 	"github.com/golang/mock/gomock"
+	"github.com/pivotal-cf/pcfdev-cli/address"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/user"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
@@ -50,6 +51,14 @@ var _ = Describe("Not Created", func() {
 			FS:      mockFS,
 			Config:  conf,
 			Network: mockNetwork,
+
+			AddressTable: &address.Table{
+				Addresses: []address.Mapping{
+					{IP: "192.168.11.11", Domain: "local.pcfdev.io"},
+					{IP: "10.0.5.20", Domain: "pcfdev.example.com"},
+				},
+				WildcardPattern: "%s.nip.io",
+			},
 		}
 	})
 
@@ -139,6 +148,14 @@ var _ = Describe("Not Created", func() {
 				})
 			})
 
+			Context("when a domain from the address table and no IP is passed", func() {
+				It("should succeed", func() {
+					Expect(notCreatedVM.VerifyStartOpts(&vm.StartOpts{
+						Domain: "pcfdev.example.com",
+					})).To(Succeed())
+				})
+			})
+
 			Context("when non-standard domain and no IP is passed", func() {
 				It("should return an error", func() {
 					Expect(notCreatedVM.VerifyStartOpts(&vm.StartOpts{
@@ -147,6 +164,24 @@ var _ = Describe("Not Created", func() {
 				})
 			})
 
+			Context("when bridged networking and a domain outside the address table are passed", func() {
+				It("should succeed", func() {
+					Expect(notCreatedVM.VerifyStartOpts(&vm.StartOpts{
+						Domain:  "some-bad-domain",
+						Network: "bridged",
+					})).To(Succeed())
+				})
+			})
+
+			Context("when bridged networking and an IP are passed", func() {
+				It("should return an error", func() {
+					Expect(notCreatedVM.VerifyStartOpts(&vm.StartOpts{
+						IP:      "192.168.11.11",
+						Network: "bridged",
+					})).To(MatchError("the -i flag cannot be used with bridged networking, the IP address is assigned by DHCP"))
+				})
+			})
+
 			Context("when non-standard domain and IP is passed", func() {
 				It("should succeed", func() {
 					mockNetwork.EXPECT().HasIPCollision("192.168.11.1").Return(false, nil)
@@ -432,6 +467,36 @@ var _ = Describe("Not Created", func() {
 			})
 		})
 
+		Context("when bridged networking is requested", func() {
+			It("should import the vm with the network mode and host interface", func() {
+				startOpts := &vm.StartOpts{
+					Memory:        uint64(4000),
+					CPUs:          3,
+					OVAPath:       "some-ova-path",
+					Network:       "bridged",
+					BridgeAdapter: "some-adapter",
+				}
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Allocating 4000 MB out of 8000 MB total system memory (5000 MB free)."),
+					mockUI.EXPECT().Say("Importing VM..."),
+					mockVBox.EXPECT().ImportVM(&config.VMConfig{
+						Name:          "some-vm",
+						Memory:        uint64(4000),
+						CPUs:          3,
+						OVAPath:       "some-ova-path",
+						Network:       "bridged",
+						BridgeAdapter: "some-adapter",
+					}),
+					mockBuilder.EXPECT().VM("some-vm").Return(mockStopped, nil),
+					mockStopped.EXPECT().Start(startOpts),
+				)
+				conf.FreeMemory = uint64(5000)
+				conf.TotalMemory = uint64(8000)
+
+				Expect(notCreatedVM.Start(startOpts)).To(Succeed())
+			})
+		})
+
 		Context("when scs is passed as a service", func() {
 			It("should use the spring cloud default memory", func() {
 				startOpts := &vm.StartOpts{
@@ -632,7 +697,14 @@ var _ = Describe("Not Created", func() {
 		It("should say message", func() {
 			mockUI.EXPECT().Say("No VM created, cannot retrieve debug logs.")
 
-			Expect(notCreatedVM.GetDebugLogs()).To(Succeed())
+			Expect(notCreatedVM.GetDebugLogs(&vm.DebugOpts{})).To(Succeed())
+		})
+	})
+
+	Describe("Logs", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot stream logs.")
+			Expect(notCreatedVM.Logs(&vm.LogsOpts{})).To(Succeed())
 		})
 	})
 
@@ -648,7 +720,7 @@ var _ = Describe("Not Created", func() {
 		It("should say message", func() {
 			mockUI.EXPECT().Say("No VM created, cannot target PCF Dev.")
 
-			Expect(notCreatedVM.Target(false)).To(Succeed())
+			Expect(notCreatedVM.Target(&vm.TargetOpts{})).To(Succeed())
 		})
 	})
 
@@ -659,4 +731,62 @@ var _ = Describe("Not Created", func() {
 			Expect(notCreatedVM.SSH()).To(Succeed())
 		})
 	})
+	Describe("ResizeDisk", func() {
+		It("should say message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot resize disk.")
+
+			Expect(notCreatedVM.ResizeDisk(uint64(81920))).To(Succeed())
+		})
+	})
+	Describe("AddShare", func() {
+		It("should say message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot add shared folders.")
+
+			Expect(notCreatedVM.AddShare(&config.SharedFolder{GuestPath: "/some-guest-path"})).To(Succeed())
+		})
+	})
+
+	Describe("RemoveShare", func() {
+		It("should say message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot remove shared folders.")
+
+			Expect(notCreatedVM.RemoveShare("/some-guest-path")).To(Succeed())
+		})
+	})
+
+	Describe("ListShares", func() {
+		It("should say message", func() {
+			mockUI.EXPECT().Say("No VM created, no shared folders.")
+
+			Expect(notCreatedVM.ListShares()).To(Succeed())
+		})
+	})
+
+	Describe("Backup", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot back up PCF Dev.")
+			Expect(notCreatedVM.Backup("some-backup-path")).To(Succeed())
+		})
+	})
+
+	Describe("Restore", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot restore PCF Dev.")
+			Expect(notCreatedVM.Restore("some-backup-path")).To(Succeed())
+		})
+	})
+
+	Describe("Seed", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot seed PCF Dev.")
+			Expect(notCreatedVM.Seed("some-seed-path")).To(Succeed())
+		})
+	})
+
+	Describe("RotatePassword", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("No VM created, cannot rotate the master password.")
+			Expect(notCreatedVM.RotatePassword("some-password")).To(Succeed())
+		})
+	})
 })
@@ -6,6 +6,7 @@ import (
 
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 )
 
 type Paused struct {
@@ -16,6 +17,7 @@ type Paused struct {
 	VBox      VBox
 	SSHClient SSH
 	FS        FS
+	Tracer    *timing.Tracer
 }
 
 func (p *Paused) Stop() error {
@@ -39,6 +41,9 @@ func (p *Paused) VerifyStartOpts(opts *StartOpts) error {
 	if opts.IP != "" {
 		return errors.New("the -i flag cannot be used if the VM has already been created")
 	}
+	if opts.Network != "" {
+		return errors.New("the --network flag cannot be used if the VM has already been created")
+	}
 	return nil
 }
 
@@ -61,7 +66,9 @@ func (p *Paused) Suspend() error {
 
 func (p *Paused) Resume() error {
 	p.UI.Say("Resuming VM...")
-	if err := p.VBox.ResumePausedVM(p.VMConfig); err != nil {
+	if err := p.Tracer.Trace("resume VM", func() error {
+		return p.VBox.ResumePausedVM(p.VMConfig)
+	}); err != nil {
 		return &ResumeVMError{err}
 	}
 
@@ -78,22 +85,33 @@ func (p *Paused) Resume() error {
 		return &ResumeVMError{err}
 	}
 
+	if err := p.Tracer.Trace("mount shared folders", func() error {
+		return p.VBox.MountSharedFolders(p.VMConfig)
+	}); err != nil {
+		return &ResumeVMError{err}
+	}
+
 	p.UI.Say("PCF Dev is now running.")
 
 	return nil
 }
 
-func (p *Paused) GetDebugLogs() error {
+func (p *Paused) GetDebugLogs(opts *DebugOpts) error {
 	p.UI.Say("Your VM is suspended. Resume to retrieve debug logs.")
 	return nil
 }
 
+func (p *Paused) Logs(opts *LogsOpts) error {
+	p.UI.Say("Your VM is suspended. Resume to stream logs.")
+	return nil
+}
+
 func (p *Paused) Trust(startOps *StartOpts) error {
 	p.UI.Say("Your VM is suspended. Resume to trust VM certificates.")
 	return nil
 }
 
-func (p *Paused) Target(autoTarget bool) error {
+func (p *Paused) Target(opts *TargetOpts) error {
 	p.UI.Say("Your VM is suspended. Resume to target PCF Dev.")
 	return nil
 }
@@ -102,3 +120,42 @@ func (p *Paused) SSH() error {
 	p.UI.Say("Your VM is suspended. Resume to SSH to PCF Dev.")
 	return nil
 }
+
+func (p *Paused) ResizeDisk(size uint64) error {
+	p.UI.Say("Your VM is suspended. Stop VM to resize its disk.")
+	return nil
+}
+
+func (p *Paused) AddShare(sharedFolder *config.SharedFolder) error {
+	p.UI.Say("Your VM is suspended. Resume and stop VM to add or remove shared folders.")
+	return nil
+}
+
+func (p *Paused) RemoveShare(guestPath string) error {
+	p.UI.Say("Your VM is suspended. Resume and stop VM to add or remove shared folders.")
+	return nil
+}
+
+func (p *Paused) ListShares() error {
+	return listSharedFolders(p.VBox, p.UI, p.VMConfig)
+}
+
+func (p *Paused) Backup(path string) error {
+	p.UI.Say("Your VM is suspended. Resume to back up PCF Dev.")
+	return nil
+}
+
+func (p *Paused) Restore(path string) error {
+	p.UI.Say("Your VM is suspended. Resume to restore PCF Dev.")
+	return nil
+}
+
+func (p *Paused) Seed(path string) error {
+	p.UI.Say("Your VM is suspended. Resume to seed PCF Dev.")
+	return nil
+}
+
+func (p *Paused) RotatePassword(password string) error {
+	p.UI.Say("Your VM is suspended. Resume to rotate the master password.")
+	return nil
+}
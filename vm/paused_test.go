@@ -78,6 +78,7 @@ var _ = Describe("Paused", func() {
 				mockVBox.EXPECT().ResumePausedVM(pausedVM.VMConfig),
 				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 				mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute),
+				mockVBox.EXPECT().MountSharedFolders(pausedVM.VMConfig),
 				mockUI.EXPECT().Say("PCF Dev is now running."),
 			)
 
@@ -107,6 +108,20 @@ var _ = Describe("Paused", func() {
 			})
 		})
 
+		Context("when mounting shared folders fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Resuming VM..."),
+					mockVBox.EXPECT().ResumePausedVM(pausedVM.VMConfig),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().WaitForSSH(gomock.Any(), []byte("some-private-key"), 5*time.Minute),
+					mockVBox.EXPECT().MountSharedFolders(pausedVM.VMConfig).Return(errors.New("some-error")),
+				)
+
+				Expect(pausedVM.Resume()).To(MatchError("failed to resume VM: some-error"))
+			})
+		})
+
 		Context("when waiting for SSH fails", func() {
 			It("should return an error", func() {
 				addresses := []ssh.SSHAddress{
@@ -164,6 +179,14 @@ var _ = Describe("Paused", func() {
 			})
 		})
 
+		Context("when a network mode is passed", func() {
+			It("should return an error", func() {
+				Expect(pausedVM.VerifyStartOpts(&vm.StartOpts{
+					Network: "bridged",
+				})).To(MatchError("the --network flag cannot be used if the VM has already been created"))
+			})
+		})
+
 		Context("when desired domain is passed", func() {
 			It("should return an error", func() {
 				Expect(pausedVM.VerifyStartOpts(&vm.StartOpts{
@@ -184,6 +207,7 @@ var _ = Describe("Paused", func() {
 				mockVBox.EXPECT().ResumePausedVM(pausedVM.VMConfig),
 				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 				mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute),
+				mockVBox.EXPECT().MountSharedFolders(pausedVM.VMConfig),
 				mockUI.EXPECT().Say("PCF Dev is now running."),
 			)
 
@@ -240,7 +264,14 @@ var _ = Describe("Paused", func() {
 	Describe("GetDebugLogs", func() {
 		It("should say a message", func() {
 			mockUI.EXPECT().Say("Your VM is suspended. Resume to retrieve debug logs.")
-			Expect(pausedVM.GetDebugLogs()).To(Succeed())
+			Expect(pausedVM.GetDebugLogs(&vm.DebugOpts{})).To(Succeed())
+		})
+	})
+
+	Describe("Logs", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to stream logs.")
+			Expect(pausedVM.Logs(&vm.LogsOpts{})).To(Succeed())
 		})
 	})
 
@@ -254,7 +285,7 @@ var _ = Describe("Paused", func() {
 	Describe("Target", func() {
 		It("should say a message", func() {
 			mockUI.EXPECT().Say("Your VM is suspended. Resume to target PCF Dev.")
-			Expect(pausedVM.Target(false)).To(Succeed())
+			Expect(pausedVM.Target(&vm.TargetOpts{})).To(Succeed())
 		})
 	})
 
@@ -264,4 +295,85 @@ var _ = Describe("Paused", func() {
 			Expect(pausedVM.SSH()).To(Succeed())
 		})
 	})
+	Describe("ResizeDisk", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Stop VM to resize its disk.")
+			Expect(pausedVM.ResizeDisk(uint64(81920))).To(Succeed())
+		})
+	})
+	Describe("AddShare", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume and stop VM to add or remove shared folders.")
+			Expect(pausedVM.AddShare(&config.SharedFolder{GuestPath: "/some-guest-path"})).To(Succeed())
+		})
+	})
+
+	Describe("RemoveShare", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume and stop VM to add or remove shared folders.")
+			Expect(pausedVM.RemoveShare("/some-guest-path")).To(Succeed())
+		})
+	})
+
+	Describe("ListShares", func() {
+		It("should list the shared folders", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().SharedFolders(pausedVM.VMConfig).Return([]*config.SharedFolder{
+					{HostPath: "some-host-path", GuestPath: "/some-guest-path"},
+					{HostPath: "some-other-host-path", GuestPath: "/some-other-guest-path", ReadOnly: true},
+				}, nil),
+				mockUI.EXPECT().Say("some-host-path -> /some-guest-path"),
+				mockUI.EXPECT().Say("some-other-host-path -> /some-other-guest-path (read-only)"),
+			)
+
+			Expect(pausedVM.ListShares()).To(Succeed())
+		})
+
+		Context("when there are no shared folders", func() {
+			It("should say a message", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().SharedFolders(pausedVM.VMConfig).Return([]*config.SharedFolder{}, nil),
+					mockUI.EXPECT().Say("No shared folders."),
+				)
+
+				Expect(pausedVM.ListShares()).To(Succeed())
+			})
+		})
+
+		Context("when retrieving the shared folders fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().SharedFolders(pausedVM.VMConfig).Return(nil, errors.New("some-error"))
+
+				Expect(pausedVM.ListShares()).To(MatchError("failed to update shared folders: some-error"))
+			})
+		})
+	})
+
+	Describe("Backup", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to back up PCF Dev.")
+			Expect(pausedVM.Backup("some-backup-path")).To(Succeed())
+		})
+	})
+
+	Describe("Restore", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to restore PCF Dev.")
+			Expect(pausedVM.Restore("some-backup-path")).To(Succeed())
+		})
+	})
+
+	Describe("Seed", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to seed PCF Dev.")
+			Expect(pausedVM.Seed("some-seed-path")).To(Succeed())
+		})
+	})
+
+	Describe("RotatePassword", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to rotate the master password.")
+			Expect(pausedVM.RotatePassword("some-password")).To(Succeed())
+		})
+	})
 })
@@ -0,0 +1,82 @@
+package vm
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const DefaultProvisionTimeout = 15 * time.Minute
+
+type provisionPhase struct {
+	marker  *regexp.Regexp
+	message string
+}
+
+var (
+	provisionPhases = []provisionPhase{
+		{marker: regexp.MustCompile(`^Waiting for services to start`), message: "Waiting for services to start..."},
+		{marker: regexp.MustCompile(`^Services started`), message: "Services started"},
+	}
+	provisionServicesRunning = regexp.MustCompile(`^(\d+) out of (\d+) running`)
+)
+
+type provisionProgress struct {
+	ui  UI
+	log io.Writer
+
+	pending []byte
+	running string
+}
+
+func (p *provisionProgress) Write(data []byte) (int, error) {
+	p.log.Write(data)
+
+	p.pending = append(p.pending, data...)
+	for {
+		index := bytes.IndexByte(p.pending, '\n')
+		if index < 0 {
+			return len(data), nil
+		}
+		p.parseLine(string(p.pending[:index]))
+		p.pending = p.pending[index+1:]
+	}
+}
+
+func (p *provisionProgress) Close() error {
+	if len(p.pending) > 0 {
+		p.parseLine(string(p.pending))
+		p.pending = nil
+	}
+	return nil
+}
+
+func (p *provisionProgress) parseLine(line string) {
+	line = strings.TrimSpace(line)
+
+	for _, phase := range provisionPhases {
+		if phase.marker.MatchString(line) {
+			p.ui.Say(phase.message)
+			return
+		}
+	}
+
+	if match := provisionServicesRunning.FindStringSubmatch(line); match != nil {
+		running := match[1] + " of " + match[2]
+		if running != p.running {
+			p.running = running
+			p.ui.Say("%s services running", running)
+		}
+	}
+}
+
+type provisionLog struct {
+	writer io.Writer
+}
+
+func (l *provisionLog) Write(data []byte) (int, error) {
+	l.writer.Write(data)
+	return len(data), nil
+}
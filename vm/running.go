@@ -3,12 +3,16 @@ package vm
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/pkg/term"
 
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 )
 
 type Running struct {
@@ -24,11 +28,17 @@ type Running struct {
 	CertStore  CertStore
 	CmdRunner  CmdRunner
 	HelpText   HelpText
+	Seeder     Seeder
+	Client     Client
+	System     System
+	Tracer     *timing.Tracer
 }
 
 func (r *Running) Stop() error {
 	r.UI.Say("Stopping VM...")
-	err := r.VBox.StopVM(r.VMConfig)
+	err := r.Tracer.Trace("stop VM", func() error {
+		return r.VBox.StopVM(r.VMConfig)
+	})
 	if err != nil {
 		return &StopVMError{err}
 	}
@@ -79,6 +89,9 @@ func (r *Running) VerifyStartOpts(opts *StartOpts) error {
 	if opts.IP != "" {
 		return errors.New("the -i flag cannot be used if the VM has already been created")
 	}
+	if opts.Network != "" {
+		return errors.New("the --network flag cannot be used if the VM has already been created")
+	}
 	return nil
 }
 
@@ -88,7 +101,13 @@ func (r *Running) Start(opts *StartOpts) error {
 }
 
 func (r *Running) Status() string {
-	return fmt.Sprintf("Running\nCLI Login: cf login -a https://api.%s --skip-ssl-validation\nApps Manager URL: https://%s\nAdmin user => Email: admin / Password: admin\nRegular user => Email: user / Password: pass", r.VMConfig.Domain, r.VMConfig.Domain)
+	status := fmt.Sprintf("Running\nCLI Login: cf login -a https://api.%s --skip-ssl-validation\nApps Manager URL: https://%s", r.VMConfig.Domain, r.VMConfig.Domain)
+
+	credentials, err := r.credentials()
+	if err != nil {
+		return fmt.Sprintf("%s\nUnable to retrieve credentials: %s", status, err)
+	}
+	return fmt.Sprintf("%s\n%s", status, credentials)
 }
 
 func (r *Running) Suspend() error {
@@ -143,36 +162,164 @@ func (r *Running) Trust(startOpts *StartOpts) error {
 	return nil
 }
 
-func (r *Running) Target(autoTarget bool) error {
-	if _, err := r.CmdRunner.Run(
-		"cf",
-		"login",
-		"-a", fmt.Sprintf("api.%s", r.VMConfig.Domain),
-		"--skip-ssl-validation",
-		"-u", "user",
-		"-p", "pass",
-		"-o", "pcfdev-org",
-		"-s", "pcfdev-space",
-	); err != nil {
+func (r *Running) Target(opts *TargetOpts) error {
+	credentials, err := r.credentials()
+	if err != nil {
 		return &TargetError{err}
 	}
 
-	if !autoTarget {
-		r.UI.Say(fmt.Sprintf("Successfully logged in to api.%s as user.", r.VMConfig.Domain))
+	user := opts.User
+	if user == "" {
+		user = credentials.UserUsername
+	}
+
+	org := opts.Org
+	if org == "" {
+		org = "pcfdev-org"
+	}
+
+	space := opts.Space
+	if space == "" && opts.Org == "" {
+		space = "pcfdev-space"
+	}
+
+	if err := r.login(user, r.password(user, credentials), org, space); err != nil {
+		return &TargetError{err}
+	}
+
+	if !opts.AutoTarget {
+		r.UI.Say(fmt.Sprintf("Successfully logged in to api.%s as %s.", r.VMConfig.Domain, user))
+	}
+
+	return nil
+}
+
+func (r *Running) Seed(path string) error {
+	manifest, err := r.FS.Read(path)
+	if err != nil {
+		return &SeedError{err}
+	}
+
+	credentials, err := r.credentials()
+	if err != nil {
+		return &SeedError{err}
+	}
+
+	r.UI.Say(fmt.Sprintf("Seeding PCF Dev from %s...", path))
+	adminPassword := r.password(credentials.AdminUsername, credentials)
+	if err := r.login(credentials.AdminUsername, adminPassword, "system", ""); err != nil {
+		return &SeedError{err}
+	}
+
+	if err := r.Seeder.Seed(manifest); err != nil {
+		return &SeedError{err}
+	}
+
+	userPassword := credentials.Password(credentials.UserUsername)
+	if credentials.MasterPassword {
+		userPassword = adminPassword
+	}
+	if err := r.login(credentials.UserUsername, userPassword, "pcfdev-org", "pcfdev-space"); err != nil {
+		return &SeedError{fmt.Errorf("PCF Dev was seeded, but logging in as %s again failed: %s", credentials.UserUsername, err)}
+	}
+
+	r.UI.Say("PCF Dev seeded.")
+	return nil
+}
+
+const restartComponentsCommand = `sudo /var/vcap/bosh/bin/monit restart all && ` + waitForComponentsCommand
+
+const waitForComponentsCommand = `for i in $(seq 1 180); do ` +
+	`if sudo /var/vcap/bosh/bin/monit summary | tail -n +3 | grep -v "running$" > /dev/null; then sleep 5; else exit 0; fi; ` +
+	`done; exit 1`
+
+func (r *Running) RotatePassword(password string) error {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return &RotatePasswordError{err}
+	}
+
+	r.UI.Say("Rotating master password...")
+	if err := r.Client.ReplaceSecrets(r.VMConfig.IP, password, privateKeyBytes); err != nil {
+		return &RotatePasswordError{err}
+	}
+
+	if err := r.VBox.RecordMasterPassword(r.VMConfig); err != nil {
+		return &RotatePasswordError{err}
+	}
+
+	addresses := []ssh.SSHAddress{
+		{IP: "127.0.0.1", Port: r.VMConfig.SSHPort},
+		{IP: r.VMConfig.IP, Port: "22"},
+	}
+
+	r.UI.Say("Restarting PCF Dev components...")
+	if err := r.SSHClient.RunSSHCommand(restartComponentsCommand, addresses, privateKeyBytes, 15*time.Minute, ioutil.Discard, ioutil.Discard); err != nil {
+		return &RotatePasswordError{err}
+	}
+
+	credentials, err := fetchCredentials(r.Client, r.VMConfig, privateKeyBytes)
+	if err != nil {
+		return &RotatePasswordError{err}
 	}
 
+	r.UI.Say("Verifying new credentials...")
+	if err := r.login(credentials.UserUsername, password, "pcfdev-org", "pcfdev-space"); err != nil {
+		return &RotatePasswordError{fmt.Errorf("unable to log in with the new master password: %s", err)}
+	}
+
+	r.UI.Say(fmt.Sprintf("Master password rotated. Logged in to api.%s as %s with the new master password.", r.VMConfig.Domain, credentials.UserUsername))
 	return nil
 }
 
-func (r *Running) GetDebugLogs() error {
-	if err := r.LogFetcher.FetchLogs(); err != nil {
-		return &FetchLogsError{err}
+func (r *Running) credentials() (*config.Credentials, error) {
+	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
+	if err != nil {
+		return nil, err
 	}
 
-	r.UI.Say("Debug logs written to pcfdev-debug.tgz. While some scrubbing has taken place, please remove any remaining sensitive information from these logs before sharing.")
+	return fetchCredentials(r.Client, r.VMConfig, privateKeyBytes)
+}
+
+func (r *Running) password(user string, credentials *config.Credentials) string {
+	if password := credentials.Password(user); password != "" {
+		return password
+	}
+
+	if password := os.Getenv("PCFDEV_PASSWORD"); password != "" {
+		return password
+	}
+
+	return r.UI.AskForPassword(fmt.Sprintf("Password for %s", user))
+}
+
+func (r *Running) login(user string, password string, org string, space string) error {
+	args := []string{
+		"login",
+		"-a", fmt.Sprintf("api.%s", r.VMConfig.Domain),
+		"--skip-ssl-validation",
+		"-u", user,
+		"-p", password,
+		"-o", org,
+	}
+	if space != "" {
+		args = append(args, "-s", space)
+	}
+
+	if _, err := r.CmdRunner.Run("cf", args...); err != nil {
+		return errors.New(strings.Replace(err.Error(), fmt.Sprintf(" -p %s ", password), " -p <redacted> ", -1))
+	}
 	return nil
 }
 
+func (r *Running) GetDebugLogs(opts *DebugOpts) error {
+	return fetchDebugLogs(r.LogFetcher, r.UI, opts)
+}
+
+func (r *Running) Logs(opts *LogsOpts) error {
+	return streamLogs(r.FS, r.UI, r.SSHClient, r.Config, r.VMConfig, opts)
+}
+
 func (r *Running) SSH() error {
 	privateKeyBytes, err := r.FS.Read(r.Config.PrivateKeyPath)
 	if err != nil {
@@ -187,3 +334,22 @@ func (r *Running) SSH() error {
 	stdin, stdout, stderr := term.StdStreams()
 	return r.SSHClient.StartSSHSession(addresses, privateKeyBytes, 5*time.Minute, stdin, stdout, stderr)
 }
+
+func (r *Running) ResizeDisk(size uint64) error {
+	r.UI.Say("Your VM is currently running. Stop VM to resize its disk.")
+	return nil
+}
+
+func (r *Running) AddShare(sharedFolder *config.SharedFolder) error {
+	r.UI.Say("Your VM is currently running. Stop VM to add or remove shared folders.")
+	return nil
+}
+
+func (r *Running) RemoveShare(guestPath string) error {
+	r.UI.Say("Your VM is currently running. Stop VM to add or remove shared folders.")
+	return nil
+}
+
+func (r *Running) ListShares() error {
+	return listSharedFolders(r.VBox, r.UI, r.VMConfig)
+}
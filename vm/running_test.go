@@ -2,10 +2,17 @@ package vm_test
 
 import (
 	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	conf "github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/debug"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 	"github.com/pivotal-cf/pcfdev-cli/vm/mocks"
@@ -27,6 +34,9 @@ var _ = Describe("Running", func() {
 		mockLogFetcher *mocks.MockLogFetcher
 		mockCertStore  *mocks.MockCertStore
 		mockCmdRunner  *mocks.MockCmdRunner
+		mockSeeder     *mocks.MockSeeder
+		mockClient     *mocks.MockClient
+		mockSystem     *mocks.MockSystem
 
 		runningVM vm.Running
 		config    *conf.VMConfig
@@ -43,6 +53,9 @@ var _ = Describe("Running", func() {
 		mockLogFetcher = mocks.NewMockLogFetcher(mockCtrl)
 		mockCertStore = mocks.NewMockCertStore(mockCtrl)
 		mockCmdRunner = mocks.NewMockCmdRunner(mockCtrl)
+		mockSeeder = mocks.NewMockSeeder(mockCtrl)
+		mockClient = mocks.NewMockClient(mockCtrl)
+		mockSystem = mocks.NewMockSystem(mockCtrl)
 		config = &conf.VMConfig{}
 
 		runningVM = vm.Running{
@@ -53,7 +66,8 @@ var _ = Describe("Running", func() {
 				SSHPort: "some-port",
 			},
 			Config: &conf.Config{
-				PrivateKeyPath: "some-private-key-path",
+				PrivateKeyPath:  "some-private-key-path",
+				ScrubConfigPath: "some-scrub-config-path",
 			},
 
 			VBox:       mockVBox,
@@ -64,6 +78,9 @@ var _ = Describe("Running", func() {
 			LogFetcher: mockLogFetcher,
 			CertStore:  mockCertStore,
 			CmdRunner:  mockCmdRunner,
+			Seeder:     mockSeeder,
+			Client:     mockClient,
+			System:     mockSystem,
 		}
 	})
 
@@ -133,6 +150,14 @@ var _ = Describe("Running", func() {
 			})
 		})
 
+		Context("when a network mode is passed", func() {
+			It("should return an error", func() {
+				Expect(runningVM.VerifyStartOpts(&vm.StartOpts{
+					Network: "bridged",
+				})).To(MatchError("the --network flag cannot be used if the VM has already been created"))
+			})
+		})
+
 		Context("when desired domain is passed", func() {
 			It("should return an error", func() {
 				Expect(runningVM.VerifyStartOpts(&vm.StartOpts{
@@ -226,8 +251,39 @@ var _ = Describe("Running", func() {
 
 	Describe("Status", func() {
 		It("should return 'Running' with login instructions", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+			)
+
 			Expect(runningVM.Status()).To(Equal("Running\nCLI Login: cf login -a https://api.some-domain --skip-ssl-validation\nApps Manager URL: https://some-domain\nAdmin user => Email: admin / Password: admin\nRegular user => Email: user / Password: pass"))
 		})
+
+		Context("when a master password is used", func() {
+			It("should not print the passwords", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(&conf.Credentials{
+						AdminUsername:  "admin",
+						UserUsername:   "user",
+						MasterPassword: true,
+					}, nil),
+				)
+
+				Expect(runningVM.Status()).To(Equal("Running\nCLI Login: cf login -a https://api.some-domain --skip-ssl-validation\nApps Manager URL: https://some-domain\nAdmin user => Email: admin / Password: <master password>\nRegular user => Email: user / Password: <master password>"))
+			})
+		})
+
+		Context("when the credentials cannot be retrieved", func() {
+			It("should say so", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(nil, errors.New("some-error")),
+				)
+
+				Expect(runningVM.Status()).To(Equal("Running\nCLI Login: cf login -a https://api.some-domain --skip-ssl-validation\nApps Manager URL: https://some-domain\nUnable to retrieve credentials: some-error"))
+			})
+		})
 	})
 
 	Describe("Suspend", func() {
@@ -260,18 +316,34 @@ var _ = Describe("Running", func() {
 	Describe("GetDebugLogs", func() {
 		It("should succeed", func() {
 			gomock.InOrder(
-				mockLogFetcher.EXPECT().FetchLogs(),
-				mockUI.EXPECT().Say("Debug logs written to pcfdev-debug.tgz. While some scrubbing has taken place, please remove any remaining sensitive information from these logs before sharing."),
+				mockLogFetcher.EXPECT().FetchLogs(&debug.FetchOpts{Output: "some-output.tgz", Timeout: 5 * time.Second, Components: []string{"some-component"}, Since: time.Hour, MaxSize: 1024}).Return(&debug.Result{}, nil),
+				mockUI.EXPECT().Say("Debug logs written to %s. While some scrubbing has taken place, please remove any remaining sensitive information from these logs before sharing.", "some-output.tgz"),
 			)
 
-			Expect(runningVM.GetDebugLogs()).To(Succeed())
+			Expect(runningVM.GetDebugLogs(&vm.DebugOpts{Output: "some-output.tgz", Timeout: 5 * time.Second, Components: []string{"some-component"}, Since: time.Hour, MaxSize: 1024})).To(Succeed())
+		})
+
+		Context("when values were redacted and pseudonymized", func() {
+			It("should summarize the redactions and say where the mapping is", func() {
+				gomock.InOrder(
+					mockLogFetcher.EXPECT().FetchLogs(&debug.FetchOpts{Output: "some-output.tgz", Pseudonymize: true}).Return(&debug.Result{
+						Redactions:  map[string]int{"uri": 1, "email": 3},
+						MappingPath: "some-output-pseudonyms.json",
+					}, nil),
+					mockUI.EXPECT().Say("Debug logs written to %s. While some scrubbing has taken place, please remove any remaining sensitive information from these logs before sharing.", "some-output.tgz"),
+					mockUI.EXPECT().Say("Redacted: %s.", "3 email, 1 uri"),
+					mockUI.EXPECT().Say("The original values behind the pseudonyms were written to %s. Keep this file private, it is not part of the tarball.", "some-output-pseudonyms.json"),
+				)
+
+				Expect(runningVM.GetDebugLogs(&vm.DebugOpts{Output: "some-output.tgz", Pseudonymize: true})).To(Succeed())
+			})
 		})
 
 		Context("when fetching logs fails", func() {
 			It("should return the error", func() {
-				mockLogFetcher.EXPECT().FetchLogs().Return(errors.New("some-error"))
+				mockLogFetcher.EXPECT().FetchLogs(&debug.FetchOpts{Output: "some-output.tgz", Timeout: 5 * time.Second, Components: []string{"some-component"}, Since: time.Hour, MaxSize: 1024}).Return(nil, errors.New("some-error"))
 
-				Expect(runningVM.GetDebugLogs()).To(MatchError("failed to retrieve logs: some-error"))
+				Expect(runningVM.GetDebugLogs(&vm.DebugOpts{Output: "some-output.tgz", Timeout: 5 * time.Second, Components: []string{"some-component"}, Since: time.Hour, MaxSize: 1024})).To(MatchError("failed to retrieve logs: some-error"))
 			})
 		})
 	})
@@ -348,6 +420,127 @@ var _ = Describe("Running", func() {
 		})
 	})
 
+	Describe("Logs", func() {
+		var (
+			addresses []ssh.SSHAddress
+			stderr    io.Writer
+			stop      chan struct{}
+		)
+
+		BeforeEach(func() {
+			addresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+			_, _, stderr = term.StdStreams()
+			stop = make(chan struct{})
+		})
+
+		writeOutput := func(output string) func(string, []ssh.SSHAddress, []byte, time.Duration, io.Writer, io.Writer, <-chan struct{}) {
+			return func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, stdout io.Writer, _ io.Writer, _ <-chan struct{}) {
+				io.WriteString(stdout, output)
+			}
+		}
+
+		It("should print the recent provision and reset logs prefixed with their source", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().RunSSHCommandUntil("sudo sh -c 'ls -1d /var/pcfdev/provision.log /var/pcfdev/reset.log 2>/dev/null | xargs -r tail -v -n 100'", addresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), stderr, gomock.Any()).
+					Do(writeOutput("==> /var/pcfdev/provision.log <==\nsome-line\n\nsome-other-line\n\n==> /var/pcfdev/reset.log <==\nsome-100% line")),
+				mockUI.EXPECT().Say("[%s] %s", "provision.log", "some-line"),
+				mockUI.EXPECT().Say("[%s] %s", "provision.log", ""),
+				mockUI.EXPECT().Say("[%s] %s", "provision.log", "some-other-line"),
+				mockUI.EXPECT().Say("[%s] %s", "reset.log", "some-100% line"),
+			)
+
+			Expect(runningVM.Logs(&vm.LogsOpts{Stop: stop})).To(Succeed())
+		})
+
+		Context("when following the logs", func() {
+			It("should tail the logs until it is stopped", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommandUntil("sudo sh -c 'tail -v -n 100 -F /var/pcfdev/provision.log /var/pcfdev/reset.log'", addresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), stderr, (<-chan struct{})(stop)).
+						Do(writeOutput("==> /var/pcfdev/reset.log <==\nsome-line\n")).Return(ssh.ErrInterrupted),
+					mockUI.EXPECT().Say("[%s] %s", "reset.log", "some-line"),
+				)
+
+				Expect(runningVM.Logs(&vm.LogsOpts{Follow: true, Stop: stop})).To(Succeed())
+			})
+		})
+
+		Context("when components are given", func() {
+			It("should print the component logs prefixed with their job and file", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommandUntil("sudo sh -c 'tail -v -n 100 -F /var/vcap/sys/log/gorouter/*.log'", addresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), stderr, gomock.Any()).
+						Do(writeOutput("==> /var/vcap/sys/log/gorouter/gorouter.stdout.log <==\nsome-line\n")),
+					mockUI.EXPECT().Say("[%s] %s", "gorouter/gorouter.stdout.log", "some-line"),
+				)
+
+				Expect(runningVM.Logs(&vm.LogsOpts{Follow: true, Components: []string{"router"}, Stop: stop})).To(Succeed())
+			})
+		})
+
+		Context("when scrubbing is requested", func() {
+			It("should scrub the output before printing it", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockFS.EXPECT().Exists("some-scrub-config-path").Return(false, nil),
+					mockSSH.EXPECT().RunSSHCommandUntil(gomock.Any(), addresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), stderr, gomock.Any()).
+						Do(writeOutput("==> /var/pcfdev/provision.log <==\nconnecting to 192.168.11.11\n")),
+					mockUI.EXPECT().Say("[%s] %s", "provision.log", "connecting to <redacted ip-address>"),
+				)
+
+				Expect(runningVM.Logs(&vm.LogsOpts{Scrub: true, Stop: stop})).To(Succeed())
+			})
+
+			It("should apply the extra patterns and allowlist of the scrub config", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockFS.EXPECT().Exists("some-scrub-config-path").Return(true, nil),
+					mockFS.EXPECT().Read("some-scrub-config-path").Return([]byte(`{"patterns": [{"label": "hostname", "regex": "[a-z]+\\.corp\\.example\\.com"}], "allowlist": ["192\\.168\\.11\\.11"]}`), nil),
+					mockSSH.EXPECT().RunSSHCommandUntil(gomock.Any(), addresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), stderr, gomock.Any()).
+						Do(writeOutput("==> /var/pcfdev/provision.log <==\nconnecting build.corp.example.com to 192.168.11.11\n")),
+					mockUI.EXPECT().Say("[%s] %s", "provision.log", "connecting <redacted hostname> to 192.168.11.11"),
+				)
+
+				Expect(runningVM.Logs(&vm.LogsOpts{Scrub: true, Stop: stop})).To(Succeed())
+			})
+
+			Context("when the scrub config cannot be parsed", func() {
+				It("should return an error", func() {
+					gomock.InOrder(
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockFS.EXPECT().Exists("some-scrub-config-path").Return(true, nil),
+						mockFS.EXPECT().Read("some-scrub-config-path").Return([]byte("some-bad-json"), nil),
+					)
+
+					Expect(runningVM.Logs(&vm.LogsOpts{Scrub: true, Stop: stop})).To(MatchError(HavePrefix("failed to stream logs: failed to parse some-scrub-config-path:")))
+				})
+			})
+		})
+
+		Context("when streaming the logs fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommandUntil(gomock.Any(), addresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), stderr, gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.Logs(&vm.LogsOpts{Stop: stop})).To(MatchError("failed to stream logs: some-error"))
+			})
+		})
+
+		Context("when reading the private key fails", func() {
+			It("should return an error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.Logs(&vm.LogsOpts{Stop: stop})).To(MatchError("failed to stream logs: some-error"))
+			})
+		})
+	})
+
 	Describe("SSH", func() {
 		It("should execute ssh on the client", func() {
 			addresses := []ssh.SSHAddress{
@@ -391,8 +584,569 @@ var _ = Describe("Running", func() {
 	})
 
 	Describe("Target", func() {
+		var masterCredentials *conf.Credentials
+
+		BeforeEach(func() {
+			masterCredentials = &conf.Credentials{
+				AdminUsername:  "admin",
+				UserUsername:   "user",
+				MasterPassword: true,
+			}
+			mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil).AnyTimes()
+		})
+
 		Context("when autoTarget is set", func() {
 			It("target PCF Dev", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockCmdRunner.EXPECT().Run(
+						"cf",
+						"login",
+						"-a", "api.some-domain",
+						"--skip-ssl-validation",
+						"-u", "user",
+						"-p", "pass",
+						"-o", "pcfdev-org",
+						"-s", "pcfdev-space",
+					),
+				)
+				Expect(runningVM.Target(&vm.TargetOpts{AutoTarget: true})).To(Succeed())
+			})
+		})
+
+		Context("when autoTarget is NOT set", func() {
+			It("target PCF Dev and prints an output message to the user", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockCmdRunner.EXPECT().Run(
+						"cf",
+						"login",
+						"-a", "api.some-domain",
+						"--skip-ssl-validation",
+						"-u", "user",
+						"-p", "pass",
+						"-o", "pcfdev-org",
+						"-s", "pcfdev-space",
+					),
+					mockUI.EXPECT().Say("Successfully logged in to api.some-domain as user."),
+				)
+
+				Expect(runningVM.Target(&vm.TargetOpts{})).To(Succeed())
+			})
+		})
+
+		Context("when a user, org and space are given", func() {
+			It("should prompt for the password and target them", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockUI.EXPECT().AskForPassword("Password for some-user").Return("some-password"),
+					mockCmdRunner.EXPECT().Run(
+						"cf",
+						"login",
+						"-a", "api.some-domain",
+						"--skip-ssl-validation",
+						"-u", "some-user",
+						"-p", "some-password",
+						"-o", "some-org",
+						"-s", "some-space",
+					),
+					mockUI.EXPECT().Say("Successfully logged in to api.some-domain as some-user."),
+				)
+
+				Expect(runningVM.Target(&vm.TargetOpts{User: "some-user", Org: "some-org", Space: "some-space"})).To(Succeed())
+			})
+		})
+
+		Context("when only an org is given", func() {
+			It("should not target a space", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockCmdRunner.EXPECT().Run(
+						"cf",
+						"login",
+						"-a", "api.some-domain",
+						"--skip-ssl-validation",
+						"-u", "admin",
+						"-p", "admin",
+						"-o", "system",
+					),
+					mockUI.EXPECT().Say("Successfully logged in to api.some-domain as admin."),
+				)
+
+				Expect(runningVM.Target(&vm.TargetOpts{User: "admin", Org: "system"})).To(Succeed())
+			})
+		})
+
+		Context("when a master password is used", func() {
+			var password string
+
+			BeforeEach(func() {
+				password = os.Getenv("PCFDEV_PASSWORD")
+			})
+
+			AfterEach(func() {
+				os.Setenv("PCFDEV_PASSWORD", password)
+			})
+
+			It("should read the password from PCFDEV_PASSWORD", func() {
+				os.Setenv("PCFDEV_PASSWORD", "some-master-password")
+
+				gomock.InOrder(
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(masterCredentials, nil),
+					mockCmdRunner.EXPECT().Run(
+						"cf",
+						"login",
+						"-a", "api.some-domain",
+						"--skip-ssl-validation",
+						"-u", "user",
+						"-p", "some-master-password",
+						"-o", "pcfdev-org",
+						"-s", "pcfdev-space",
+					),
+				)
+
+				Expect(runningVM.Target(&vm.TargetOpts{AutoTarget: true})).To(Succeed())
+			})
+
+			It("should prompt for the password when PCFDEV_PASSWORD is not set", func() {
+				os.Unsetenv("PCFDEV_PASSWORD")
+
+				gomock.InOrder(
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(masterCredentials, nil),
+					mockUI.EXPECT().AskForPassword("Password for user").Return("some-master-password"),
+					mockCmdRunner.EXPECT().Run(
+						"cf",
+						"login",
+						"-a", "api.some-domain",
+						"--skip-ssl-validation",
+						"-u", "user",
+						"-p", "some-master-password",
+						"-o", "pcfdev-org",
+						"-s", "pcfdev-space",
+					),
+				)
+
+				Expect(runningVM.Target(&vm.TargetOpts{AutoTarget: true})).To(Succeed())
+			})
+		})
+
+		Context("when the credentials cannot be retrieved", func() {
+			It("should return the error", func() {
+				mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.Target(&vm.TargetOpts{})).To(MatchError("failed to target PCF Dev: some-error"))
+			})
+		})
+
+		Context("when logging in fails", func() {
+			It("should return the error without the password", func() {
+				gomock.InOrder(
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockCmdRunner.EXPECT().Run(
+						"cf",
+						"login",
+						"-a", "api.some-domain",
+						"--skip-ssl-validation",
+						"-u", "user",
+						"-p", "pass",
+						"-o", "pcfdev-org",
+						"-s", "pcfdev-space",
+					).Return(nil, errors.New("failed to execute 'cf login -a api.some-domain --skip-ssl-validation -u user -p pass -o pcfdev-org -s pcfdev-space': some-error")),
+				)
+
+				Expect(runningVM.Target(&vm.TargetOpts{})).To(MatchError("failed to target PCF Dev: failed to execute 'cf login -a api.some-domain --skip-ssl-validation -u user -p <redacted> -o pcfdev-org -s pcfdev-space': some-error"))
+			})
+		})
+	})
+
+	Describe("ResizeDisk", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently running. Stop VM to resize its disk.")
+			Expect(runningVM.ResizeDisk(uint64(81920))).To(Succeed())
+		})
+	})
+	Describe("AddShare", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently running. Stop VM to add or remove shared folders.")
+			Expect(runningVM.AddShare(&conf.SharedFolder{GuestPath: "/some-guest-path"})).To(Succeed())
+		})
+	})
+
+	Describe("RemoveShare", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently running. Stop VM to add or remove shared folders.")
+			Expect(runningVM.RemoveShare("/some-guest-path")).To(Succeed())
+		})
+	})
+
+	Describe("ListShares", func() {
+		It("should list the shared folders", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().SharedFolders(runningVM.VMConfig).Return([]*conf.SharedFolder{
+					{HostPath: "some-host-path", GuestPath: "/some-guest-path"},
+					{HostPath: "some-other-host-path", GuestPath: "/some-other-guest-path", ReadOnly: true},
+				}, nil),
+				mockUI.EXPECT().Say("some-host-path -> /some-guest-path"),
+				mockUI.EXPECT().Say("some-other-host-path -> /some-other-guest-path (read-only)"),
+			)
+
+			Expect(runningVM.ListShares()).To(Succeed())
+		})
+
+		Context("when there are no shared folders", func() {
+			It("should say a message", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().SharedFolders(runningVM.VMConfig).Return([]*conf.SharedFolder{}, nil),
+					mockUI.EXPECT().Say("No shared folders."),
+				)
+
+				Expect(runningVM.ListShares()).To(Succeed())
+			})
+		})
+
+		Context("when retrieving the shared folders fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().SharedFolders(runningVM.VMConfig).Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.ListShares()).To(MatchError("failed to update shared folders: some-error"))
+			})
+		})
+	})
+
+	Describe("Backup", func() {
+		var written map[string]string
+
+		backupSizeCommand := `sudo du -sb /var/vcap/store/blobstore | cut -f1 && sudo /var/vcap/packages/mariadb/bin/mysql --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf -N -B -e "SELECT COALESCE(SUM(data_length + index_length), 0) FROM information_schema.tables WHERE table_schema IN ('ccdb', 'uaadb')"`
+
+		BeforeEach(func() {
+			runningVM.Config.Version = &conf.Version{OVABuildVersion: "some-newer-ova-version"}
+			runningVM.VMConfig.OVAVersion = "some-ova-version"
+			written = map[string]string{}
+		})
+
+		recordWrite := func(path string, contents io.Reader, append bool) {
+			data, err := ioutil.ReadAll(contents)
+			Expect(err).NotTo(HaveOccurred())
+			written[path] = string(data)
+		}
+
+		It("should export the databases and blobstore into a tarball", func() {
+			mockUI.EXPECT().Say("Backing up PCF Dev...")
+			mockFS.EXPECT().TempDir().Return("some-temp-dir", nil)
+			mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil)
+			addresses := []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+			mockSSH.EXPECT().GetSSHOutput(backupSizeCommand, addresses, []byte("some-private-key"), 5*time.Minute).Return("1000\n234\n", nil)
+			mockSystem.EXPECT().CheckDiskSpace("some-temp-dir", uint64(1234))
+			mockSystem.EXPECT().CheckDiskSpace("some-dir", uint64(1234))
+			for _, artifact := range []struct{ command, contents string }{
+				{"sudo /var/vcap/packages/mariadb/bin/mysqldump --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf --single-transaction --add-drop-database --databases ccdb", "some-ccdb-dump"},
+				{"sudo /var/vcap/packages/mariadb/bin/mysqldump --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf --single-transaction --add-drop-database --databases uaadb", "some-uaadb-dump"},
+				{"sudo tar -C /var/vcap/store/blobstore -czf - .", "some-blobstore"},
+			} {
+				contents := artifact.contents
+				mockSSH.EXPECT().RunSSHCommand(artifact.command, addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any()).Do(
+					func(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, stdout io.Writer, stderr io.Writer) {
+						stdout.Write([]byte(contents))
+					},
+				)
+			}
+			mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "ccdb.sql"), gomock.Any(), false).Do(recordWrite)
+			mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "uaadb.sql"), gomock.Any(), false).Do(recordWrite)
+			mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "blobstore.tgz"), gomock.Any(), false).Do(recordWrite)
+			mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "manifest.json"), gomock.Any(), false).Do(recordWrite)
+			mockFS.EXPECT().Compress("some-backup", filepath.Join("some-dir"), []string{
+				filepath.Join("some-temp-dir", "manifest.json"),
+				filepath.Join("some-temp-dir", "ccdb.sql"),
+				filepath.Join("some-temp-dir", "uaadb.sql"),
+				filepath.Join("some-temp-dir", "blobstore.tgz"),
+			})
+			mockUI.EXPECT().Say("PCF Dev backed up to " + filepath.Join("some-dir", "some-backup.tgz") + ".")
+			mockFS.EXPECT().Remove("some-temp-dir")
+
+			Expect(runningVM.Backup(filepath.Join("some-dir", "some-backup.tgz"))).To(Succeed())
+			Expect(written[filepath.Join("some-temp-dir", "ccdb.sql")]).To(Equal("some-ccdb-dump"))
+			Expect(written[filepath.Join("some-temp-dir", "uaadb.sql")]).To(Equal("some-uaadb-dump"))
+			Expect(written[filepath.Join("some-temp-dir", "blobstore.tgz")]).To(Equal("some-blobstore"))
+			Expect(written[filepath.Join("some-temp-dir", "manifest.json")]).To(MatchJSON(`{"ova_version":"some-ova-version","domain":"some-domain"}`))
+		})
+
+		Context("when exporting an artifact fails", func() {
+			It("should return an error", func() {
+				mockUI.EXPECT().Say("Backing up PCF Dev...")
+				mockFS.EXPECT().TempDir().Return("some-temp-dir", nil)
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil)
+				mockSSH.EXPECT().GetSSHOutput(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("1000\n234\n", nil)
+				mockSystem.EXPECT().CheckDiskSpace(gomock.Any(), gomock.Any()).Times(2)
+				mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+				mockFS.EXPECT().Write(filepath.Join("some-temp-dir", "ccdb.sql"), gomock.Any(), false).Do(
+					func(path string, contents io.Reader, append bool) {
+						_, err := ioutil.ReadAll(contents)
+						Expect(err).To(MatchError("some-error"))
+					},
+				).Return(errors.New("some-error"))
+				mockFS.EXPECT().Remove("some-temp-dir")
+
+				Expect(runningVM.Backup("some-backup.tgz")).To(MatchError("failed to back up PCF Dev: some-error"))
+			})
+		})
+
+		Context("when there is not enough free disk space for the backup", func() {
+			It("should return an error before exporting anything", func() {
+				mockUI.EXPECT().Say("Backing up PCF Dev...")
+				mockFS.EXPECT().TempDir().Return("some-temp-dir", nil)
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil)
+				mockSSH.EXPECT().GetSSHOutput(backupSizeCommand, gomock.Any(), []byte("some-private-key"), 5*time.Minute).Return("1000\n234\n", nil)
+				mockSystem.EXPECT().CheckDiskSpace("some-temp-dir", uint64(1234))
+				mockSystem.EXPECT().CheckDiskSpace("some-dir", uint64(1234)).Return(errors.New("some-error"))
+				mockFS.EXPECT().Remove("some-temp-dir")
+
+				Expect(runningVM.Backup(filepath.Join("some-dir", "some-backup.tgz"))).To(MatchError("failed to back up PCF Dev: some-error"))
+			})
+		})
+
+		Context("when the size of the backup cannot be estimated", func() {
+			It("should return an error", func() {
+				mockUI.EXPECT().Say("Backing up PCF Dev...")
+				mockFS.EXPECT().TempDir().Return("some-temp-dir", nil)
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil)
+				mockSSH.EXPECT().GetSSHOutput(backupSizeCommand, gomock.Any(), []byte("some-private-key"), 5*time.Minute).Return("some-output\n", nil)
+				mockFS.EXPECT().Remove("some-temp-dir")
+
+				Expect(runningVM.Backup("some-backup.tgz")).To(MatchError("failed to back up PCF Dev: failed to estimate the size of the backup: some-output"))
+			})
+		})
+
+		Context("when creating the temp dir fails", func() {
+			It("should return an error", func() {
+				mockUI.EXPECT().Say("Backing up PCF Dev...")
+				mockFS.EXPECT().TempDir().Return("", errors.New("some-error"))
+
+				Expect(runningVM.Backup("some-backup.tgz")).To(MatchError("failed to back up PCF Dev: some-error"))
+			})
+		})
+	})
+
+	Describe("Restore", func() {
+		var addresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			runningVM.Config.Version = &conf.Version{OVABuildVersion: "some-newer-ova-version"}
+			runningVM.VMConfig.OVAVersion = "some-ova-version"
+			addresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		stopCommand := `for i in $(seq 1 60); do processes=$(sudo /var/vcap/bosh/bin/monit summary | grep "^Process" | grep -v -e mysql -e mariadb | grep -v "not monitored$"); if [ -z "$processes" ]; then exit 0; fi; echo "$processes" | cut -d "'" -f2 | xargs -n 1 sudo /var/vcap/bosh/bin/monit stop; sleep 5; done; exit 1`
+		startCommand := `sudo /var/vcap/bosh/bin/monit start all && for i in $(seq 1 180); do if sudo /var/vcap/bosh/bin/monit summary | tail -n +3 | grep -v "running$" > /dev/null; then sleep 5; else exit 0; fi; done; exit 1`
+
+		expectStop := func() {
+			gomock.InOrder(
+				mockUI.EXPECT().Say("Stopping PCF Dev components..."),
+				mockSSH.EXPECT().RunSSHCommand(stopCommand, addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any()),
+			)
+		}
+
+		expectStart := func() {
+			gomock.InOrder(
+				mockUI.EXPECT().Say("Starting PCF Dev components..."),
+				mockSSH.EXPECT().RunSSHCommand(startCommand, addresses, []byte("some-private-key"), 15*time.Minute, gomock.Any(), gomock.Any()),
+			)
+		}
+
+		expectImport := func(filename string, pattern string, command string) {
+			path := filepath.Join("some-temp-dir", filename)
+			file := ioutil.NopCloser(strings.NewReader("some-" + filename))
+			gomock.InOrder(
+				mockFS.EXPECT().Extract("some-backup.tgz", path, pattern),
+				mockFS.EXPECT().Open(path).Return(file, nil),
+				mockSSH.EXPECT().RunSSHCommandWithStdin(command, addresses, []byte("some-private-key"), 5*time.Minute, file, gomock.Any(), gomock.Any()),
+			)
+		}
+
+		It("should import the databases and blobstore from the tarball", func() {
+			gomock.InOrder(
+				mockUI.EXPECT().Say("Restoring PCF Dev from some-backup.tgz..."),
+				mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+				mockFS.EXPECT().Extract("some-backup.tgz", filepath.Join("some-temp-dir", "manifest.json"), `manifest\.json$`),
+				mockFS.EXPECT().Read(filepath.Join("some-temp-dir", "manifest.json")).Return([]byte(`{"ova_version":"some-ova-version","domain":"some-domain"}`), nil),
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+			)
+			expectStop()
+			expectImport("ccdb.sql", `ccdb\.sql$`, "sudo /var/vcap/packages/mariadb/bin/mysql --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf")
+			expectImport("uaadb.sql", `uaadb\.sql$`, "sudo /var/vcap/packages/mariadb/bin/mysql --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf")
+			expectImport("blobstore.tgz", `blobstore\.tgz$`, "sudo rm -rf /var/vcap/store/blobstore && sudo mkdir -p /var/vcap/store/blobstore && sudo tar -C /var/vcap/store/blobstore -xzf -")
+			expectStart()
+			mockUI.EXPECT().Say("PCF Dev restored.")
+			mockFS.EXPECT().Remove("some-temp-dir")
+
+			Expect(runningVM.Restore("some-backup.tgz")).To(Succeed())
+		})
+
+		Context("when the backup was created for a different domain", func() {
+			It("should replace the domain in the databases", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Restoring PCF Dev from some-backup.tgz..."),
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Extract("some-backup.tgz", filepath.Join("some-temp-dir", "manifest.json"), `manifest\.json$`),
+					mockFS.EXPECT().Read(filepath.Join("some-temp-dir", "manifest.json")).Return([]byte(`{"ova_version":"some-ova-version","domain":"some.old.domain"}`), nil),
+					mockUI.EXPECT().Say("Backup was created for domain some.old.domain. Replacing it with some-domain..."),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				)
+				expectStop()
+				expectImport("ccdb.sql", `ccdb\.sql$`, `sed -e 's/some\.old\.domain/some-domain/g' | sudo /var/vcap/packages/mariadb/bin/mysql --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf`)
+				expectImport("uaadb.sql", `uaadb\.sql$`, `sed -e 's/some\.old\.domain/some-domain/g' | sudo /var/vcap/packages/mariadb/bin/mysql --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf`)
+				expectImport("blobstore.tgz", `blobstore\.tgz$`, "sudo rm -rf /var/vcap/store/blobstore && sudo mkdir -p /var/vcap/store/blobstore && sudo tar -C /var/vcap/store/blobstore -xzf -")
+				expectStart()
+				mockUI.EXPECT().Say("PCF Dev restored.")
+				mockFS.EXPECT().Remove("some-temp-dir")
+
+				Expect(runningVM.Restore("some-backup.tgz")).To(Succeed())
+			})
+		})
+
+		Context("when the domains contain characters that are special to sed or the shell", func() {
+			It("should replace the domain literally", func() {
+				runningVM.VMConfig.Domain = "new&d/om"
+				var command string
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Restoring PCF Dev from some-backup.tgz..."),
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Extract("some-backup.tgz", filepath.Join("some-temp-dir", "manifest.json"), `manifest\.json$`),
+					mockFS.EXPECT().Read(filepath.Join("some-temp-dir", "manifest.json")).Return([]byte(`{"ova_version":"some-ova-version","domain":"some.old$d/o'm&ain"}`), nil),
+					mockUI.EXPECT().Say(`Backup was created for domain some.old$d/o'm&ain. Replacing it with new&d/om...`),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				)
+				expectStop()
+				mockFS.EXPECT().Extract("some-backup.tgz", gomock.Any(), gomock.Any()).AnyTimes()
+				mockFS.EXPECT().Open(gomock.Any()).Return(ioutil.NopCloser(strings.NewReader("")), nil).AnyTimes()
+				mockSSH.EXPECT().RunSSHCommandWithStdin(gomock.Any(), addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(c string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, _ io.Reader, _ io.Writer, _ io.Writer) {
+						if strings.HasPrefix(c, "sed ") {
+							command = strings.Split(c, " | ")[0]
+						}
+					}).AnyTimes()
+				expectStart()
+				mockUI.EXPECT().Say("PCF Dev restored.")
+				mockFS.EXPECT().Remove("some-temp-dir")
+
+				Expect(runningVM.Restore("some-backup.tgz")).To(Succeed())
+
+				sed := exec.Command("sh", "-c", command)
+				sed.Stdin = strings.NewReader("http://api.some.old$d/o'm&ain some.oldXd/o'm&ain")
+				output, err := sed.Output()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(output)).To(Equal("http://api.new&d/om some.oldXd/o'm&ain"))
+			})
+		})
+
+		Context("when the backup was created with a different OVA version", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Restoring PCF Dev from some-backup.tgz..."),
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Extract("some-backup.tgz", filepath.Join("some-temp-dir", "manifest.json"), `manifest\.json$`),
+					mockFS.EXPECT().Read(filepath.Join("some-temp-dir", "manifest.json")).Return([]byte(`{"ova_version":"some-other-ova-version","domain":"some-domain"}`), nil),
+					mockFS.EXPECT().Remove("some-temp-dir"),
+				)
+
+				Expect(runningVM.Restore("some-backup.tgz")).To(MatchError("failed to restore PCF Dev: backup was created with OVA version some-other-ova-version, but this VM is running OVA version some-ova-version"))
+			})
+		})
+
+		Context("when the OVA version of the VM or the backup is unknown", func() {
+			It("should warn and restore anyway", func() {
+				runningVM.VMConfig.OVAVersion = ""
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Restoring PCF Dev from some-backup.tgz..."),
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Extract("some-backup.tgz", filepath.Join("some-temp-dir", "manifest.json"), `manifest\.json$`),
+					mockFS.EXPECT().Read(filepath.Join("some-temp-dir", "manifest.json")).Return([]byte(`{"ova_version":"","domain":"some-domain"}`), nil),
+					mockUI.EXPECT().Say("Unable to verify that the backup was created with the OVA version of this VM. Restoring anyway..."),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				)
+				expectStop()
+				expectImport("ccdb.sql", `ccdb\.sql$`, "sudo /var/vcap/packages/mariadb/bin/mysql --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf")
+				expectImport("uaadb.sql", `uaadb\.sql$`, "sudo /var/vcap/packages/mariadb/bin/mysql --defaults-file=/var/vcap/jobs/mysql/config/mylogin.cnf")
+				expectImport("blobstore.tgz", `blobstore\.tgz$`, "sudo rm -rf /var/vcap/store/blobstore && sudo mkdir -p /var/vcap/store/blobstore && sudo tar -C /var/vcap/store/blobstore -xzf -")
+				expectStart()
+				mockUI.EXPECT().Say("PCF Dev restored.")
+				mockFS.EXPECT().Remove("some-temp-dir")
+
+				Expect(runningVM.Restore("some-backup.tgz")).To(Succeed())
+			})
+		})
+
+		Context("when importing an artifact fails", func() {
+			It("should start the components again and return an error", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Restoring PCF Dev from some-backup.tgz..."),
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Extract("some-backup.tgz", filepath.Join("some-temp-dir", "manifest.json"), `manifest\.json$`),
+					mockFS.EXPECT().Read(filepath.Join("some-temp-dir", "manifest.json")).Return([]byte(`{"ova_version":"some-ova-version","domain":"some-domain"}`), nil),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				)
+				expectStop()
+				mockFS.EXPECT().Extract("some-backup.tgz", filepath.Join("some-temp-dir", "ccdb.sql"), `ccdb\.sql$`).Return(errors.New("some-error"))
+				expectStart()
+				mockFS.EXPECT().Remove("some-temp-dir")
+
+				Expect(runningVM.Restore("some-backup.tgz")).To(MatchError("failed to restore PCF Dev: some-error"))
+			})
+		})
+
+		Context("when the components cannot be stopped", func() {
+			It("should not import anything and return an error", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Restoring PCF Dev from some-backup.tgz..."),
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Extract("some-backup.tgz", filepath.Join("some-temp-dir", "manifest.json"), `manifest\.json$`),
+					mockFS.EXPECT().Read(filepath.Join("some-temp-dir", "manifest.json")).Return([]byte(`{"ova_version":"some-ova-version","domain":"some-domain"}`), nil),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Stopping PCF Dev components..."),
+					mockSSH.EXPECT().RunSSHCommand(stopCommand, addresses, []byte("some-private-key"), 5*time.Minute, gomock.Any(), gomock.Any()).Return(errors.New("some-error")),
+					mockFS.EXPECT().Remove("some-temp-dir"),
+				)
+
+				Expect(runningVM.Restore("some-backup.tgz")).To(MatchError("failed to restore PCF Dev: some-error"))
+			})
+		})
+
+		Context("when the manifest cannot be extracted", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Restoring PCF Dev from some-backup.tgz..."),
+					mockFS.EXPECT().TempDir().Return("some-temp-dir", nil),
+					mockFS.EXPECT().Extract("some-backup.tgz", filepath.Join("some-temp-dir", "manifest.json"), `manifest\.json$`).Return(errors.New("some-error")),
+					mockFS.EXPECT().Remove("some-temp-dir"),
+				)
+
+				Expect(runningVM.Restore("some-backup.tgz")).To(MatchError("failed to restore PCF Dev: some-error"))
+			})
+		})
+	})
+
+	Describe("Seed", func() {
+		It("should log in as admin, seed PCF Dev and target PCF Dev again", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-seed-path").Return([]byte("some-manifest"), nil),
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+				mockUI.EXPECT().Say("Seeding PCF Dev from some-seed-path..."),
+				mockCmdRunner.EXPECT().Run(
+					"cf",
+					"login",
+					"-a", "api.some-domain",
+					"--skip-ssl-validation",
+					"-u", "admin",
+					"-p", "admin",
+					"-o", "system",
+				),
+				mockSeeder.EXPECT().Seed([]byte("some-manifest")),
 				mockCmdRunner.EXPECT().Run(
 					"cf",
 					"login",
@@ -402,44 +1156,179 @@ var _ = Describe("Running", func() {
 					"-p", "pass",
 					"-o", "pcfdev-org",
 					"-s", "pcfdev-space",
+				),
+				mockUI.EXPECT().Say("PCF Dev seeded."),
+			)
+
+			Expect(runningVM.Seed("some-seed-path")).To(Succeed())
+		})
+
+		Context("when a master password is used", func() {
+			It("should prompt for the master password once", func() {
+				password := os.Getenv("PCFDEV_PASSWORD")
+				defer os.Setenv("PCFDEV_PASSWORD", password)
+				os.Unsetenv("PCFDEV_PASSWORD")
+
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-seed-path").Return([]byte("some-manifest"), nil),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(&conf.Credentials{
+						AdminUsername:  "admin",
+						UserUsername:   "user",
+						MasterPassword: true,
+					}, nil),
+					mockUI.EXPECT().Say("Seeding PCF Dev from some-seed-path..."),
+					mockUI.EXPECT().AskForPassword("Password for admin").Return("some-master-password"),
+					mockCmdRunner.EXPECT().Run("cf", "login", "-a", "api.some-domain", "--skip-ssl-validation", "-u", "admin", "-p", "some-master-password", "-o", "system"),
+					mockSeeder.EXPECT().Seed([]byte("some-manifest")),
+					mockCmdRunner.EXPECT().Run("cf", "login", "-a", "api.some-domain", "--skip-ssl-validation", "-u", "user", "-p", "some-master-password", "-o", "pcfdev-org", "-s", "pcfdev-space"),
+					mockUI.EXPECT().Say("PCF Dev seeded."),
+				)
+
+				Expect(runningVM.Seed("some-seed-path")).To(Succeed())
+			})
+		})
+
+		Context("when reading the manifest fails", func() {
+			It("should return an error", func() {
+				mockFS.EXPECT().Read("some-seed-path").Return(nil, errors.New("some-error"))
+
+				Expect(runningVM.Seed("some-seed-path")).To(MatchError("failed to seed PCF Dev: some-error"))
+			})
+		})
+
+		Context("when logging in as admin fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-seed-path").Return([]byte("some-manifest"), nil),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockUI.EXPECT().Say("Seeding PCF Dev from some-seed-path..."),
+					mockCmdRunner.EXPECT().Run("cf", "login", "-a", "api.some-domain", "--skip-ssl-validation", "-u", "admin", "-p", "admin", "-o", "system").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(runningVM.Seed("some-seed-path")).To(MatchError("failed to seed PCF Dev: some-error"))
+			})
+		})
+
+		Context("when seeding fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-seed-path").Return([]byte("some-manifest"), nil),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockUI.EXPECT().Say("Seeding PCF Dev from some-seed-path..."),
+					mockCmdRunner.EXPECT().Run("cf", "login", "-a", "api.some-domain", "--skip-ssl-validation", "-u", "admin", "-p", "admin", "-o", "system"),
+					mockSeeder.EXPECT().Seed([]byte("some-manifest")).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.Seed("some-seed-path")).To(MatchError("failed to seed PCF Dev: some-error"))
+			})
+		})
+		Context("when logging in as the user again fails", func() {
+			It("should return a seed error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-seed-path").Return([]byte("some-manifest"), nil),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockUI.EXPECT().Say("Seeding PCF Dev from some-seed-path..."),
+					mockCmdRunner.EXPECT().Run("cf", "login", "-a", "api.some-domain", "--skip-ssl-validation", "-u", "admin", "-p", "admin", "-o", "system"),
+					mockSeeder.EXPECT().Seed([]byte("some-manifest")),
+					mockCmdRunner.EXPECT().Run("cf", "login", "-a", "api.some-domain", "--skip-ssl-validation", "-u", "user", "-p", "pass", "-o", "pcfdev-org", "-s", "pcfdev-space").Return(nil, errors.New("some-error")),
 				)
-				Expect(runningVM.Target(true)).To(Succeed())
+
+				Expect(runningVM.Seed("some-seed-path")).To(MatchError("failed to seed PCF Dev: PCF Dev was seeded, but logging in as user again failed: some-error"))
 			})
 		})
 	})
 
-	Context("when autoTarget is NOT set", func() {
-		It("target PCF Dev and prints an output message to the user", func() {
-			mockCmdRunner.EXPECT().Run(
-				"cf",
-				"login",
-				"-a", "api.some-domain",
-				"--skip-ssl-validation",
-				"-u", "user",
-				"-p", "pass",
-				"-o", "pcfdev-org",
-				"-s", "pcfdev-space",
+	Describe("RotatePassword", func() {
+		var addresses []ssh.SSHAddress
+
+		BeforeEach(func() {
+			addresses = []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+		})
+
+		It("should replace the secrets, restart the components and verify the new password", func() {
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockUI.EXPECT().Say("Rotating master password..."),
+				mockClient.EXPECT().ReplaceSecrets("some-ip", "some-password", []byte("some-private-key")),
+				mockVBox.EXPECT().RecordMasterPassword(runningVM.VMConfig),
+				mockUI.EXPECT().Say("Restarting PCF Dev components..."),
+				mockSSH.EXPECT().RunSSHCommand(`sudo /var/vcap/bosh/bin/monit restart all && for i in $(seq 1 180); do if sudo /var/vcap/bosh/bin/monit summary | tail -n +3 | grep -v "running$" > /dev/null; then sleep 5; else exit 0; fi; done; exit 1`, addresses, []byte("some-private-key"), 15*time.Minute, gomock.Any(), gomock.Any()),
+				mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(&conf.Credentials{
+					AdminUsername:  "admin",
+					UserUsername:   "user",
+					MasterPassword: true,
+				}, nil),
+				mockUI.EXPECT().Say("Verifying new credentials..."),
+				mockCmdRunner.EXPECT().Run("cf", "login", "-a", "api.some-domain", "--skip-ssl-validation", "-u", "user", "-p", "some-password", "-o", "pcfdev-org", "-s", "pcfdev-space"),
+				mockUI.EXPECT().Say("Master password rotated. Logged in to api.some-domain as user with the new master password."),
 			)
-			mockUI.EXPECT().Say("Successfully logged in to api.some-domain as user.")
 
-			Expect(runningVM.Target(false)).To(Succeed())
+			Expect(runningVM.RotatePassword("some-password")).To(Succeed())
+		})
+
+		Context("when replacing the secrets fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Rotating master password..."),
+					mockClient.EXPECT().ReplaceSecrets("some-ip", "some-password", []byte("some-private-key")).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.RotatePassword("some-password")).To(MatchError("failed to rotate master password: some-error"))
+			})
 		})
-	})
 
-	Context("when there is an error", func() {
-		It("should return the error", func() {
-			mockCmdRunner.EXPECT().Run(
-				"cf",
-				"login",
-				"-a", "api.some-domain",
-				"--skip-ssl-validation",
-				"-u", "user",
-				"-p", "pass",
-				"-o", "pcfdev-org",
-				"-s", "pcfdev-space",
-			).Return(nil, errors.New("some-error"))
-
-			Expect(runningVM.Target(false)).To(MatchError("failed to target PCF Dev: some-error"))
+		Context("when recording the master password fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Rotating master password..."),
+					mockClient.EXPECT().ReplaceSecrets("some-ip", "some-password", []byte("some-private-key")),
+					mockVBox.EXPECT().RecordMasterPassword(runningVM.VMConfig).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.RotatePassword("some-password")).To(MatchError("failed to rotate master password: some-error"))
+			})
+		})
+
+		Context("when restarting the components fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Rotating master password..."),
+					mockClient.EXPECT().ReplaceSecrets("some-ip", "some-password", []byte("some-private-key")),
+					mockVBox.EXPECT().RecordMasterPassword(runningVM.VMConfig),
+					mockUI.EXPECT().Say("Restarting PCF Dev components..."),
+					mockSSH.EXPECT().RunSSHCommand(gomock.Any(), addresses, []byte("some-private-key"), 15*time.Minute, gomock.Any(), gomock.Any()).Return(errors.New("some-error")),
+				)
+
+				Expect(runningVM.RotatePassword("some-password")).To(MatchError("failed to rotate master password: some-error"))
+			})
+		})
+
+		Context("when logging in with the new password fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockUI.EXPECT().Say("Rotating master password..."),
+					mockClient.EXPECT().ReplaceSecrets("some-ip", "some-password", []byte("some-private-key")),
+					mockVBox.EXPECT().RecordMasterPassword(runningVM.VMConfig),
+					mockUI.EXPECT().Say("Restarting PCF Dev components..."),
+					mockSSH.EXPECT().RunSSHCommand(gomock.Any(), addresses, []byte("some-private-key"), 15*time.Minute, gomock.Any(), gomock.Any()),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockUI.EXPECT().Say("Verifying new credentials..."),
+					mockCmdRunner.EXPECT().Run("cf", "login", "-a", "api.some-domain", "--skip-ssl-validation", "-u", "user", "-p", "some-password", "-o", "pcfdev-org", "-s", "pcfdev-space").Return(nil, errors.New("some-error")),
+				)
+
+				Expect(runningVM.RotatePassword("some-password")).To(MatchError("failed to rotate master password: unable to log in with the new master password: some-error"))
+			})
 		})
 	})
 })
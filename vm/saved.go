@@ -7,6 +7,7 @@ import (
 
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 )
 
 type Saved struct {
@@ -17,6 +18,7 @@ type Saved struct {
 	UI        UI
 	VBox      VBox
 	SSHClient SSH
+	Tracer    *timing.Tracer
 }
 
 func (s *Saved) VerifyStartOpts(opts *StartOpts) error {
@@ -35,6 +37,9 @@ func (s *Saved) VerifyStartOpts(opts *StartOpts) error {
 	if opts.IP != "" {
 		return errors.New("the -i flag cannot be used if the VM has already been created")
 	}
+	if opts.Network != "" {
+		return errors.New("the --network flag cannot be used if the VM has already been created")
+	}
 	if err := s.checkMemory(); err != nil {
 		return err
 	}
@@ -68,7 +73,9 @@ func (s *Saved) Resume() error {
 		return err
 	}
 	s.UI.Say("Resuming VM...")
-	if err := s.VBox.ResumeSavedVM(s.VMConfig); err != nil {
+	if err := s.Tracer.Trace("resume VM", func() error {
+		return s.VBox.ResumeSavedVM(s.VMConfig)
+	}); err != nil {
 		return &ResumeVMError{err}
 	}
 
@@ -85,6 +92,12 @@ func (s *Saved) Resume() error {
 		return &ResumeVMError{err}
 	}
 
+	if err := s.Tracer.Trace("mount shared folders", func() error {
+		return s.VBox.MountSharedFolders(s.VMConfig)
+	}); err != nil {
+		return &ResumeVMError{err}
+	}
+
 	s.UI.Say("PCF Dev is now running.")
 
 	return nil
@@ -99,17 +112,22 @@ func (s *Saved) checkMemory() error {
 	return nil
 }
 
-func (s *Saved) GetDebugLogs() error {
+func (s *Saved) GetDebugLogs(opts *DebugOpts) error {
 	s.UI.Say("Your VM is suspended. Resume to retrieve debug logs.")
 	return nil
 }
 
+func (s *Saved) Logs(opts *LogsOpts) error {
+	s.UI.Say("Your VM is suspended. Resume to stream logs.")
+	return nil
+}
+
 func (s *Saved) Trust(startOps *StartOpts) error {
 	s.UI.Say("Your VM is suspended. Resume to trust VM certificates.")
 	return nil
 }
 
-func (s *Saved) Target(autoTarget bool) error {
+func (s *Saved) Target(opts *TargetOpts) error {
 	s.UI.Say("Your VM is suspended. Resume to target PCF Dev.")
 	return nil
 }
@@ -118,3 +136,42 @@ func (s *Saved) SSH() error {
 	s.UI.Say("Your VM is suspended. Resume to SSH to PCF Dev.")
 	return nil
 }
+
+func (s *Saved) ResizeDisk(size uint64) error {
+	s.UI.Say("Your VM is suspended. Stop VM to resize its disk.")
+	return nil
+}
+
+func (s *Saved) AddShare(sharedFolder *config.SharedFolder) error {
+	s.UI.Say("Your VM is suspended. Resume and stop VM to add or remove shared folders.")
+	return nil
+}
+
+func (s *Saved) RemoveShare(guestPath string) error {
+	s.UI.Say("Your VM is suspended. Resume and stop VM to add or remove shared folders.")
+	return nil
+}
+
+func (s *Saved) ListShares() error {
+	return listSharedFolders(s.VBox, s.UI, s.VMConfig)
+}
+
+func (s *Saved) Backup(path string) error {
+	s.UI.Say("Your VM is suspended. Resume to back up PCF Dev.")
+	return nil
+}
+
+func (s *Saved) Restore(path string) error {
+	s.UI.Say("Your VM is suspended. Resume to restore PCF Dev.")
+	return nil
+}
+
+func (s *Saved) Seed(path string) error {
+	s.UI.Say("Your VM is suspended. Resume to seed PCF Dev.")
+	return nil
+}
+
+func (s *Saved) RotatePassword(password string) error {
+	s.UI.Say("Your VM is suspended. Resume to rotate the master password.")
+	return nil
+}
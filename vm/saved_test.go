@@ -78,6 +78,7 @@ var _ = Describe("Saved", func() {
 				mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 				mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute),
+				mockVBox.EXPECT().MountSharedFolders(savedVM.VMConfig),
 				mockUI.EXPECT().Say("PCF Dev is now running."),
 			)
 
@@ -106,6 +107,20 @@ var _ = Describe("Saved", func() {
 				Expect(savedVM.Start(&vm.StartOpts{})).To(MatchError("failed to resume VM: some-error"))
 			})
 		})
+		Context("when mounting shared folders fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockUI.EXPECT().Say("Resuming VM..."),
+					mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().WaitForSSH(gomock.Any(), []byte("some-private-key"), 5*time.Minute),
+					mockVBox.EXPECT().MountSharedFolders(savedVM.VMConfig).Return(errors.New("some-error")),
+				)
+
+				Expect(savedVM.Start(&vm.StartOpts{})).To(MatchError("failed to resume VM: some-error"))
+			})
+		})
+
 		Context("when waiting for SSH fails", func() {
 			It("should return an error", func() {
 				addresses := []ssh.SSHAddress{
@@ -157,6 +172,14 @@ var _ = Describe("Saved", func() {
 			})
 		})
 
+		Context("when a network mode is passed", func() {
+			It("should return an error", func() {
+				Expect(savedVM.VerifyStartOpts(&vm.StartOpts{
+					Network: "bridged",
+				})).To(MatchError("the --network flag cannot be used if the VM has already been created"))
+			})
+		})
+
 		Context("when desired domain is passed", func() {
 			It("should return an error", func() {
 				Expect(savedVM.VerifyStartOpts(&vm.StartOpts{
@@ -213,6 +236,7 @@ var _ = Describe("Saved", func() {
 				mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 				mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute),
+				mockVBox.EXPECT().MountSharedFolders(savedVM.VMConfig),
 				mockUI.EXPECT().Say("PCF Dev is now running."),
 			)
 
@@ -281,6 +305,7 @@ var _ = Describe("Saved", func() {
 						mockVBox.EXPECT().ResumeSavedVM(savedVM.VMConfig),
 						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 						mockSSH.EXPECT().WaitForSSH(addresses, []byte("some-private-key"), 5*time.Minute),
+						mockVBox.EXPECT().MountSharedFolders(savedVM.VMConfig),
 						mockUI.EXPECT().Say("PCF Dev is now running."),
 					)
 
@@ -310,7 +335,14 @@ var _ = Describe("Saved", func() {
 	Describe("GetDebugLogs", func() {
 		It("should say a message", func() {
 			mockUI.EXPECT().Say("Your VM is suspended. Resume to retrieve debug logs.")
-			Expect(savedVM.GetDebugLogs()).To(Succeed())
+			Expect(savedVM.GetDebugLogs(&vm.DebugOpts{})).To(Succeed())
+		})
+	})
+
+	Describe("Logs", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to stream logs.")
+			Expect(savedVM.Logs(&vm.LogsOpts{})).To(Succeed())
 		})
 	})
 
@@ -324,7 +356,7 @@ var _ = Describe("Saved", func() {
 	Describe("Target", func() {
 		It("should say a message", func() {
 			mockUI.EXPECT().Say("Your VM is suspended. Resume to target PCF Dev.")
-			Expect(savedVM.Target(false)).To(Succeed())
+			Expect(savedVM.Target(&vm.TargetOpts{})).To(Succeed())
 		})
 	})
 
@@ -334,4 +366,85 @@ var _ = Describe("Saved", func() {
 			Expect(savedVM.SSH()).To(Succeed())
 		})
 	})
+	Describe("ResizeDisk", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Stop VM to resize its disk.")
+			Expect(savedVM.ResizeDisk(uint64(81920))).To(Succeed())
+		})
+	})
+	Describe("AddShare", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume and stop VM to add or remove shared folders.")
+			Expect(savedVM.AddShare(&config.SharedFolder{GuestPath: "/some-guest-path"})).To(Succeed())
+		})
+	})
+
+	Describe("RemoveShare", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume and stop VM to add or remove shared folders.")
+			Expect(savedVM.RemoveShare("/some-guest-path")).To(Succeed())
+		})
+	})
+
+	Describe("ListShares", func() {
+		It("should list the shared folders", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().SharedFolders(savedVM.VMConfig).Return([]*config.SharedFolder{
+					{HostPath: "some-host-path", GuestPath: "/some-guest-path"},
+					{HostPath: "some-other-host-path", GuestPath: "/some-other-guest-path", ReadOnly: true},
+				}, nil),
+				mockUI.EXPECT().Say("some-host-path -> /some-guest-path"),
+				mockUI.EXPECT().Say("some-other-host-path -> /some-other-guest-path (read-only)"),
+			)
+
+			Expect(savedVM.ListShares()).To(Succeed())
+		})
+
+		Context("when there are no shared folders", func() {
+			It("should say a message", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().SharedFolders(savedVM.VMConfig).Return([]*config.SharedFolder{}, nil),
+					mockUI.EXPECT().Say("No shared folders."),
+				)
+
+				Expect(savedVM.ListShares()).To(Succeed())
+			})
+		})
+
+		Context("when retrieving the shared folders fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().SharedFolders(savedVM.VMConfig).Return(nil, errors.New("some-error"))
+
+				Expect(savedVM.ListShares()).To(MatchError("failed to update shared folders: some-error"))
+			})
+		})
+	})
+
+	Describe("Backup", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to back up PCF Dev.")
+			Expect(savedVM.Backup("some-backup-path")).To(Succeed())
+		})
+	})
+
+	Describe("Restore", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to restore PCF Dev.")
+			Expect(savedVM.Restore("some-backup-path")).To(Succeed())
+		})
+	})
+
+	Describe("Seed", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to seed PCF Dev.")
+			Expect(savedVM.Seed("some-seed-path")).To(Succeed())
+		})
+	})
+
+	Describe("RotatePassword", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is suspended. Resume to rotate the master password.")
+			Expect(savedVM.RotatePassword("some-password")).To(Succeed())
+		})
+	})
 })
@@ -0,0 +1,28 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/pivotal-cf/pcfdev-cli/config"
+)
+
+func listSharedFolders(vbox VBox, ui UI, vmConfig *config.VMConfig) error {
+	sharedFolders, err := vbox.SharedFolders(vmConfig)
+	if err != nil {
+		return &SharedFolderError{err}
+	}
+
+	if len(sharedFolders) == 0 {
+		ui.Say("No shared folders.")
+		return nil
+	}
+
+	for _, sharedFolder := range sharedFolders {
+		if sharedFolder.ReadOnly {
+			ui.Say(fmt.Sprintf("%s -> %s (read-only)", sharedFolder.HostPath, sharedFolder.GuestPath))
+		} else {
+			ui.Say(fmt.Sprintf("%s -> %s", sharedFolder.HostPath, sharedFolder.GuestPath))
+		}
+	}
+	return nil
+}
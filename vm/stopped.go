@@ -12,6 +12,7 @@ import (
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/helpers"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 )
 
 type Stopped struct {
@@ -23,6 +24,7 @@ type Stopped struct {
 	SSHClient SSH
 	UI        UI
 	Builder   Builder
+	Tracer    *timing.Tracer
 }
 
 func (s *Stopped) Stop() error {
@@ -49,6 +51,9 @@ func (s *Stopped) VerifyStartOpts(opts *StartOpts) error {
 	if opts.IP != "" {
 		return errors.New("the -i flag cannot be used if the VM has already been created")
 	}
+	if opts.Network != "" {
+		return errors.New("the --network flag cannot be used if the VM has already been created")
+	}
 	if s.VMConfig.Memory > s.Config.FreeMemory {
 		if !s.UI.Confirm(fmt.Sprintf("Less than %d MB of free memory detected, continue (y/N): ", s.VMConfig.Memory)) {
 			return errors.New("user declined to continue, exiting")
@@ -59,7 +64,15 @@ func (s *Stopped) VerifyStartOpts(opts *StartOpts) error {
 
 func (s *Stopped) Start(opts *StartOpts) error {
 	s.UI.Say("Starting VM...")
-	if err := s.VBox.StartVM(s.VMConfig); err != nil {
+	if err := s.Tracer.Trace("start VM", func() error {
+		return s.VBox.StartVM(s.VMConfig)
+	}); err != nil {
+		return &StartVMError{err}
+	}
+
+	if err := s.Tracer.Trace("mount shared folders", func() error {
+		return s.VBox.MountSharedFolders(s.VMConfig)
+	}); err != nil {
 		return &StartVMError{err}
 	}
 
@@ -178,17 +191,22 @@ func (s *Stopped) Resume() error {
 	return nil
 }
 
-func (s *Stopped) GetDebugLogs() error {
+func (s *Stopped) GetDebugLogs(opts *DebugOpts) error {
 	s.UI.Say("Your VM is currently stopped. Start VM to retrieve debug logs.")
 	return nil
 }
 
+func (s *Stopped) Logs(opts *LogsOpts) error {
+	s.UI.Say("Your VM is currently stopped. Start VM to stream logs.")
+	return nil
+}
+
 func (s *Stopped) Trust(startOps *StartOpts) error {
 	s.UI.Say("Your VM is currently stopped. Start VM to trust VM certificates.")
 	return nil
 }
 
-func (s *Stopped) Target(autoTarget bool) error {
+func (s *Stopped) Target(opts *TargetOpts) error {
 	s.UI.Say("Your VM is currently stopped. Start VM to target PCF Dev.")
 	return nil
 }
@@ -197,3 +215,69 @@ func (s *Stopped) SSH() error {
 	s.UI.Say("Your VM is currently stopped. Start VM to SSH to PCF Dev.")
 	return nil
 }
+
+func (s *Stopped) ResizeDisk(size uint64) error {
+	currentSize, err := s.VBox.DiskSize(s.VMConfig)
+	if err != nil {
+		return &ResizeDiskError{err}
+	}
+
+	if size < currentSize {
+		return &ResizeDiskError{fmt.Errorf("disk cannot be shrunk from %d MB to %d MB", currentSize, size)}
+	}
+
+	if size == currentSize {
+		s.UI.Say(fmt.Sprintf("Disk is already %d MB.", currentSize))
+		return nil
+	}
+
+	s.UI.Say("Resizing disk...")
+	if err := s.VBox.ResizeDisk(s.VMConfig, size); err != nil {
+		return &ResizeDiskError{err}
+	}
+
+	s.UI.Say(fmt.Sprintf("Disk resized from %d MB to %d MB. The guest filesystem will be grown the next time PCF Dev starts.", currentSize, size))
+	return nil
+}
+
+func (s *Stopped) AddShare(sharedFolder *config.SharedFolder) error {
+	if err := s.VBox.AddSharedFolder(s.VMConfig, sharedFolder); err != nil {
+		return &SharedFolderError{err}
+	}
+
+	s.UI.Say(fmt.Sprintf("%s will be mounted at %s the next time PCF Dev starts.", sharedFolder.HostPath, sharedFolder.GuestPath))
+	return nil
+}
+
+func (s *Stopped) RemoveShare(guestPath string) error {
+	if err := s.VBox.RemoveSharedFolder(s.VMConfig, guestPath); err != nil {
+		return &SharedFolderError{err}
+	}
+
+	s.UI.Say(fmt.Sprintf("Shared folder at %s removed.", guestPath))
+	return nil
+}
+
+func (s *Stopped) ListShares() error {
+	return listSharedFolders(s.VBox, s.UI, s.VMConfig)
+}
+
+func (s *Stopped) Backup(path string) error {
+	s.UI.Say("Your VM is currently stopped. Start VM to back up PCF Dev.")
+	return nil
+}
+
+func (s *Stopped) Restore(path string) error {
+	s.UI.Say("Your VM is currently stopped. Start VM to restore PCF Dev.")
+	return nil
+}
+
+func (s *Stopped) Seed(path string) error {
+	s.UI.Say("Your VM is currently stopped. Start VM to seed PCF Dev.")
+	return nil
+}
+
+func (s *Stopped) RotatePassword(password string) error {
+	s.UI.Say("Your VM is currently stopped. Start VM to rotate the master password.")
+	return nil
+}
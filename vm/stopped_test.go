@@ -76,6 +76,14 @@ var _ = Describe("Stopped", func() {
 			})
 		})
 
+		Context("when a network mode is passed", func() {
+			It("should return an error", func() {
+				Expect(stoppedVM.VerifyStartOpts(&vm.StartOpts{
+					Network: "bridged",
+				})).To(MatchError("the --network flag cannot be used if the VM has already been created"))
+			})
+		})
+
 		Context("when desired domain is passed", func() {
 			It("should return an error", func() {
 				Expect(stoppedVM.VerifyStartOpts(&vm.StartOpts{
@@ -172,11 +180,22 @@ var _ = Describe("Stopped", func() {
 			mockSSH.EXPECT().GetSSHOutput(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).AnyTimes()
 			mockUI.EXPECT().Say(gomock.Any()).AnyTimes()
 			mockVBox.EXPECT().StartVM(gomock.Any()).AnyTimes()
+			mockVBox.EXPECT().MountSharedFolders(gomock.Any()).AnyTimes()
 			mockBuilder.EXPECT().VM(gomock.Any()).AnyTimes().Return(mockUnprovisioned, nil)
 			mockFS.EXPECT().Read(gomock.Any()).AnyTimes().Return([]byte("some-private-key"), nil)
 			mockUnprovisioned.EXPECT().Provision(gomock.Any()).AnyTimes()
 		}
 
+		It("should start the VM before provisioning it, so that the provisioned components see the guest environment", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+				mockUnprovisioned.EXPECT().Provision(&vm.StartOpts{}),
+			)
+			allowHappyPathInteractions()
+
+			Expect(stoppedVM.Start(&vm.StartOpts{})).To(Succeed())
+		})
+
 		Context("when 'none' services are specified", func() {
 			It("should start vm with no extra services", func() {
 				mockSSH.EXPECT().RunSSHCommand("echo "+
@@ -380,6 +399,18 @@ var _ = Describe("Stopped", func() {
 			})
 		})
 
+		Context("when mounting shared folders fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().StartVM(stoppedVM.VMConfig),
+					mockVBox.EXPECT().MountSharedFolders(stoppedVM.VMConfig).Return(errors.New("some-error")),
+				)
+				allowHappyPathInteractions()
+
+				Expect(stoppedVM.Start(&vm.StartOpts{})).To(MatchError("failed to start VM: some-error"))
+			})
+		})
+
 		Context("when reading the private key fails", func() {
 			It("should return an error", func() {
 				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
@@ -462,7 +493,14 @@ var _ = Describe("Stopped", func() {
 	Describe("GetDebugLogs", func() {
 		It("should say a message", func() {
 			mockUI.EXPECT().Say("Your VM is currently stopped. Start VM to retrieve debug logs.")
-			Expect(stoppedVM.GetDebugLogs()).To(Succeed())
+			Expect(stoppedVM.GetDebugLogs(&vm.DebugOpts{})).To(Succeed())
+		})
+	})
+
+	Describe("Logs", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently stopped. Start VM to stream logs.")
+			Expect(stoppedVM.Logs(&vm.LogsOpts{})).To(Succeed())
 		})
 	})
 
@@ -476,7 +514,7 @@ var _ = Describe("Stopped", func() {
 	Describe("Target", func() {
 		It("should say a message", func() {
 			mockUI.EXPECT().Say("Your VM is currently stopped. Start VM to target PCF Dev.")
-			Expect(stoppedVM.Target(false)).To(Succeed())
+			Expect(stoppedVM.Target(&vm.TargetOpts{})).To(Succeed())
 		})
 	})
 
@@ -486,4 +524,156 @@ var _ = Describe("Stopped", func() {
 			Expect(stoppedVM.SSH()).To(Succeed())
 		})
 	})
+	Describe("ResizeDisk", func() {
+		It("should resize the disk and report the size before and after", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().DiskSize(stoppedVM.VMConfig).Return(uint64(40960), nil),
+				mockUI.EXPECT().Say("Resizing disk..."),
+				mockVBox.EXPECT().ResizeDisk(stoppedVM.VMConfig, uint64(81920)),
+				mockUI.EXPECT().Say("Disk resized from 40960 MB to 81920 MB. The guest filesystem will be grown the next time PCF Dev starts."),
+			)
+
+			Expect(stoppedVM.ResizeDisk(uint64(81920))).To(Succeed())
+		})
+
+		Context("when the disk is already the requested size", func() {
+			It("should say a message", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().DiskSize(stoppedVM.VMConfig).Return(uint64(81920), nil),
+					mockUI.EXPECT().Say("Disk is already 81920 MB."),
+				)
+
+				Expect(stoppedVM.ResizeDisk(uint64(81920))).To(Succeed())
+			})
+		})
+
+		Context("when the requested size is smaller than the disk", func() {
+			It("should refuse to shrink the disk", func() {
+				mockVBox.EXPECT().DiskSize(stoppedVM.VMConfig).Return(uint64(81920), nil)
+
+				Expect(stoppedVM.ResizeDisk(uint64(40960))).To(MatchError("failed to resize disk: disk cannot be shrunk from 81920 MB to 40960 MB"))
+			})
+		})
+
+		Context("when getting the disk size fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().DiskSize(stoppedVM.VMConfig).Return(uint64(0), errors.New("some-error"))
+
+				Expect(stoppedVM.ResizeDisk(uint64(81920))).To(MatchError("failed to resize disk: some-error"))
+			})
+		})
+
+		Context("when resizing the disk fails", func() {
+			It("should return an error", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().DiskSize(stoppedVM.VMConfig).Return(uint64(40960), nil),
+					mockUI.EXPECT().Say("Resizing disk..."),
+					mockVBox.EXPECT().ResizeDisk(stoppedVM.VMConfig, uint64(81920)).Return(errors.New("some-error")),
+				)
+
+				Expect(stoppedVM.ResizeDisk(uint64(81920))).To(MatchError("failed to resize disk: some-error"))
+			})
+		})
+	})
+	Describe("AddShare", func() {
+		It("should add the shared folder", func() {
+			sharedFolder := &config.SharedFolder{HostPath: "some-host-path", GuestPath: "/some-guest-path"}
+			gomock.InOrder(
+				mockVBox.EXPECT().AddSharedFolder(stoppedVM.VMConfig, sharedFolder),
+				mockUI.EXPECT().Say("some-host-path will be mounted at /some-guest-path the next time PCF Dev starts."),
+			)
+
+			Expect(stoppedVM.AddShare(sharedFolder)).To(Succeed())
+		})
+
+		Context("when adding the shared folder fails", func() {
+			It("should return an error", func() {
+				sharedFolder := &config.SharedFolder{HostPath: "some-host-path", GuestPath: "/some-guest-path"}
+				mockVBox.EXPECT().AddSharedFolder(stoppedVM.VMConfig, sharedFolder).Return(errors.New("some-error"))
+
+				Expect(stoppedVM.AddShare(sharedFolder)).To(MatchError("failed to update shared folders: some-error"))
+			})
+		})
+	})
+
+	Describe("RemoveShare", func() {
+		It("should remove the shared folder", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().RemoveSharedFolder(stoppedVM.VMConfig, "/some-guest-path"),
+				mockUI.EXPECT().Say("Shared folder at /some-guest-path removed."),
+			)
+
+			Expect(stoppedVM.RemoveShare("/some-guest-path")).To(Succeed())
+		})
+
+		Context("when removing the shared folder fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().RemoveSharedFolder(stoppedVM.VMConfig, "/some-guest-path").Return(errors.New("some-error"))
+
+				Expect(stoppedVM.RemoveShare("/some-guest-path")).To(MatchError("failed to update shared folders: some-error"))
+			})
+		})
+	})
+
+	Describe("ListShares", func() {
+		It("should list the shared folders", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().SharedFolders(stoppedVM.VMConfig).Return([]*config.SharedFolder{
+					{HostPath: "some-host-path", GuestPath: "/some-guest-path"},
+					{HostPath: "some-other-host-path", GuestPath: "/some-other-guest-path", ReadOnly: true},
+				}, nil),
+				mockUI.EXPECT().Say("some-host-path -> /some-guest-path"),
+				mockUI.EXPECT().Say("some-other-host-path -> /some-other-guest-path (read-only)"),
+			)
+
+			Expect(stoppedVM.ListShares()).To(Succeed())
+		})
+
+		Context("when there are no shared folders", func() {
+			It("should say a message", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().SharedFolders(stoppedVM.VMConfig).Return([]*config.SharedFolder{}, nil),
+					mockUI.EXPECT().Say("No shared folders."),
+				)
+
+				Expect(stoppedVM.ListShares()).To(Succeed())
+			})
+		})
+
+		Context("when retrieving the shared folders fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().SharedFolders(stoppedVM.VMConfig).Return(nil, errors.New("some-error"))
+
+				Expect(stoppedVM.ListShares()).To(MatchError("failed to update shared folders: some-error"))
+			})
+		})
+	})
+
+	Describe("Backup", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently stopped. Start VM to back up PCF Dev.")
+			Expect(stoppedVM.Backup("some-backup-path")).To(Succeed())
+		})
+	})
+
+	Describe("Restore", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently stopped. Start VM to restore PCF Dev.")
+			Expect(stoppedVM.Restore("some-backup-path")).To(Succeed())
+		})
+	})
+
+	Describe("Seed", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently stopped. Start VM to seed PCF Dev.")
+			Expect(stoppedVM.Seed("some-seed-path")).To(Succeed())
+		})
+	})
+
+	Describe("RotatePassword", func() {
+		It("should say a message", func() {
+			mockUI.EXPECT().Say("Your VM is currently stopped. Start VM to rotate the master password.")
+			Expect(stoppedVM.RotatePassword("some-password")).To(Succeed())
+		})
+	})
 })
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/docker/docker/pkg/term"
 	"github.com/pivotal-cf/pcfdev-cli/config"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	"github.com/pivotal-cf/pcfdev-cli/timing"
 )
 
 type Unprovisioned struct {
@@ -23,11 +25,14 @@ type Unprovisioned struct {
 	VMConfig   *config.VMConfig
 	HelpText   HelpText
 	Client     Client
+	Tracer     *timing.Tracer
 }
 
 func (u *Unprovisioned) Stop() error {
 	u.UI.Say("Stopping VM...")
-	if err := u.VBox.StopVM(u.VMConfig); err != nil {
+	if err := u.Tracer.Trace("stop VM", func() error {
+		return u.VBox.StopVM(u.VMConfig)
+	}); err != nil {
 		return err
 	}
 	u.UI.Say("PCF Dev is now stopped.")
@@ -56,6 +61,10 @@ func (u *Unprovisioned) Provision(opts *StartOpts) error {
 		if err := u.Client.ReplaceSecrets(u.VMConfig.IP, opts.MasterPassword, privateKey); err != nil {
 			return err
 		}
+
+		if err := u.VBox.RecordMasterPassword(u.VMConfig); err != nil {
+			return err
+		}
 	}
 
 	privateKeyBytes, err := u.FS.Read(u.Config.PrivateKeyPath)
@@ -90,15 +99,61 @@ func (u *Unprovisioned) Provision(opts *StartOpts) error {
 
 	u.UI.Say("Provisioning VM...")
 	provisionCommand := fmt.Sprintf(`sudo -H /var/pcfdev/provision "%s" "%s" "%s" "%s" "%s"`, provisionConfig.Domain, provisionConfig.IP, provisionConfig.Services, strings.Join(provisionConfig.Registries, ","), provisionConfig.Provider)
-	if err := u.SSHClient.RunSSHCommand(provisionCommand, addresses, privateKeyBytes, 5*time.Minute, os.Stdout, os.Stderr); err != nil {
+	if err := u.Tracer.Trace("provision", func() error {
+		return u.runProvision(provisionCommand, addresses, privateKeyBytes, opts.ProvisionTimeout)
+	}); err != nil {
 		return &ProvisionVMError{err}
 	}
 
-	u.HelpText.Print(u.VMConfig.Domain, opts.Target)
+	credentials, err := fetchCredentials(u.Client, u.VMConfig, privateKeyBytes)
+	if err != nil {
+		return &ProvisionVMError{err}
+	}
+
+	u.HelpText.Print(u.VMConfig.Domain, credentials, opts.Target)
 
 	return nil
 }
 
+func (u *Unprovisioned) runProvision(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = DefaultProvisionTimeout
+	}
+	logPath := u.Config.ProvisionLogPath(u.VMConfig.Name)
+
+	reader, writer := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		err := u.FS.Write(logPath, reader, false)
+		reader.CloseWithError(err)
+		written <- err
+	}()
+
+	log := &provisionLog{writer}
+	progress := &provisionProgress{ui: u.UI, log: log}
+
+	stop := make(chan struct{})
+	timer := time.AfterFunc(timeout, func() { close(stop) })
+	err := u.SSHClient.RunSSHCommandUntil(command, addresses, privateKey, 30*time.Second, progress, log, stop)
+	timer.Stop()
+
+	progress.Close()
+	writer.Close()
+	if writeErr := <-written; writeErr != nil {
+		u.UI.Say("Could not save the provision output to %s: %s", logPath, writeErr)
+	}
+
+	// Only the timer stops the command, so a command that was interrupted timed out, while one that
+	// finished just as the timer fired keeps its own result.
+	if err == ssh.ErrInterrupted {
+		return &ProvisionTimeoutError{timeout, logPath}
+	}
+	if err != nil {
+		u.UI.Say("The full provision output was saved to %s.", logPath)
+	}
+	return err
+}
+
 func (u *Unprovisioned) Suspend() error {
 	return u.err()
 }
@@ -111,10 +166,14 @@ func (u *Unprovisioned) Trust(startOps *StartOpts) error {
 	return u.err()
 }
 
-func (u *Unprovisioned) Target(autoTarget bool) error {
+func (u *Unprovisioned) Target(opts *TargetOpts) error {
 	return u.err()
 }
 
+func (u *Unprovisioned) Logs(opts *LogsOpts) error {
+	return streamLogs(u.FS, u.UI, u.SSHClient, u.Config, u.VMConfig, opts)
+}
+
 func (u *Unprovisioned) SSH() error {
 	privateKeyBytes, err := u.FS.Read(u.Config.PrivateKeyPath)
 	if err != nil {
@@ -134,10 +193,38 @@ func (u *Unprovisioned) err() error {
 	return errors.New("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'")
 }
 
-func (u *Unprovisioned) GetDebugLogs() error {
-	if err := u.LogFetcher.FetchLogs(); err != nil {
-		return &FetchLogsError{err}
-	}
+func (u *Unprovisioned) GetDebugLogs(opts *DebugOpts) error {
+	return fetchDebugLogs(u.LogFetcher, u.UI, opts)
+}
 
-	return nil
+func (u *Unprovisioned) ResizeDisk(size uint64) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) AddShare(sharedFolder *config.SharedFolder) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) RemoveShare(guestPath string) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) ListShares() error {
+	return listSharedFolders(u.VBox, u.UI, u.VMConfig)
+}
+
+func (u *Unprovisioned) Backup(path string) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) Restore(path string) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) Seed(path string) error {
+	return u.err()
+}
+
+func (u *Unprovisioned) RotatePassword(password string) error {
+	return u.err()
 }
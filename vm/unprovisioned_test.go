@@ -1,12 +1,16 @@
 package vm_test
 
 import (
+	"bytes"
 	"errors"
+	"io"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	conf "github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/debug"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
 	"github.com/pivotal-cf/pcfdev-cli/vm"
 	"github.com/pivotal-cf/pcfdev-cli/vm/mocks"
@@ -49,6 +53,7 @@ var _ = Describe("Unprovisioned", func() {
 			Client:     mockClient,
 			Config: &conf.Config{
 				PrivateKeyPath: "some-private-key-path",
+				VMDir:          "some-vm-dir",
 			},
 			VMConfig: &conf.VMConfig{
 				Name:    "some-vm",
@@ -96,11 +101,35 @@ var _ = Describe("Unprovisioned", func() {
 	})
 
 	Describe("Provision", func() {
+		var (
+			provisionLogPath string
+			provisionLog     *bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			provisionLogPath = filepath.Join("some-vm-dir", "some-vm", "provision.log")
+			provisionLog = &bytes.Buffer{}
+		})
+
+		expectProvisionLog := func() {
+			mockFS.EXPECT().Write(provisionLogPath, gomock.Any(), false).Do(func(_ string, contents io.Reader, _ bool) {
+				io.Copy(provisionLog, contents)
+			})
+		}
+
+		writeProvisionOutput := func(stdout string, stderr string) func(string, []ssh.SSHAddress, []byte, time.Duration, io.Writer, io.Writer, <-chan struct{}) {
+			return func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, stdoutWriter io.Writer, stderrWriter io.Writer, _ <-chan struct{}) {
+				io.WriteString(stdoutWriter, stdout)
+				io.WriteString(stderrWriter, stderr)
+			}
+		}
+
 		It("should provision the VM", func() {
 			sshAddresses := []ssh.SSHAddress{
 				{IP: "127.0.0.1", Port: "some-port"},
 				{IP: "some-ip", Port: "22"},
 			}
+			expectProvisionLog()
 			gomock.InOrder(
 				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 				mockSSH.EXPECT().RunSSHCommand(
@@ -118,29 +147,35 @@ var _ = Describe("Unprovisioned", func() {
 					30*time.Second,
 				).Return(`{"domain":"some-domain","ip":"some-ip","services":"some-service,some-other-service","registries":["some-registry","some-other-registry"],"provider":"some-provider"}`, nil),
 				mockUI.EXPECT().Say("Provisioning VM..."),
-				mockSSH.EXPECT().RunSSHCommand(
+				mockSSH.EXPECT().RunSSHCommandUntil(
 					`sudo -H /var/pcfdev/provision "some-domain" "some-ip" "some-service,some-other-service" "some-registry,some-other-registry" "some-provider"`,
 					sshAddresses,
 					[]byte("some-private-key"),
-					5*time.Minute,
-					os.Stdout,
-					os.Stderr,
+					30*time.Second,
+					gomock.Any(),
+					gomock.Any(),
+					gomock.Any(),
 				),
-				mockHelpText.EXPECT().Print("some-domain", false),
+				mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(nil, nil),
+				mockHelpText.EXPECT().Print("some-domain", conf.DefaultCredentials(), false),
 			)
 
 			Expect(unprovisioned.Provision(&vm.StartOpts{})).To(Succeed())
 		})
 
 		Context("when the user passes in a master password", func() {
-			It("should provision the VM after replacing the secrets", func() {
+			It("should provision the VM after replacing the secrets and recording the master password", func() {
 				sshAddresses := []ssh.SSHAddress{
 					{IP: "127.0.0.1", Port: "some-port"},
 					{IP: "some-ip", Port: "22"},
 				}
+				expectProvisionLog()
 				gomock.InOrder(
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockClient.EXPECT().ReplaceSecrets("some-ip", "some-master-password", []byte("some-private-key")),
+					mockVBox.EXPECT().RecordMasterPassword(unprovisioned.VMConfig).Do(func(vmConfig *conf.VMConfig) {
+						vmConfig.MasterPassword = true
+					}),
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand(
 						"if [ -e /var/pcfdev/provision-options.json ]; then exit 0; else exit 1; fi",
@@ -157,21 +192,35 @@ var _ = Describe("Unprovisioned", func() {
 						30*time.Second,
 					).Return(`{"domain":"some-domain","ip":"some-ip","services":"some-service,some-other-service","registries":["some-registry","some-other-registry"],"provider":"some-provider"}`, nil),
 					mockUI.EXPECT().Say("Provisioning VM..."),
-					mockSSH.EXPECT().RunSSHCommand(
+					mockSSH.EXPECT().RunSSHCommandUntil(
 						`sudo -H /var/pcfdev/provision "some-domain" "some-ip" "some-service,some-other-service" "some-registry,some-other-registry" "some-provider"`,
 						sshAddresses,
 						[]byte("some-private-key"),
-						5*time.Minute,
-						os.Stdout,
-						os.Stderr,
+						30*time.Second,
+						gomock.Any(),
+						gomock.Any(),
+						gomock.Any(),
 					),
-					mockHelpText.EXPECT().Print("some-domain", false),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(nil, nil),
+					mockHelpText.EXPECT().Print("some-domain", conf.MasterPasswordCredentials(), false),
 				)
 
 				Expect(unprovisioned.Provision(&vm.StartOpts{MasterPassword: "some-master-password"})).To(Succeed())
 			})
 		})
 
+		Context("when the user passes in a master password and recording it fails", func() {
+			It("should return the error", func() {
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockClient.EXPECT().ReplaceSecrets("some-ip", "some-master-password", []byte("some-private-key")),
+					mockVBox.EXPECT().RecordMasterPassword(unprovisioned.VMConfig).Return(errors.New("some-error")),
+				)
+
+				Expect(unprovisioned.Provision(&vm.StartOpts{MasterPassword: "some-master-password"})).To(MatchError("some-error"))
+			})
+		})
+
 		Context("when the user passes in a master password and there is an error", func() {
 			It("should return the error", func() {
 				gomock.InOrder(
@@ -197,8 +246,8 @@ var _ = Describe("Unprovisioned", func() {
 					{IP: "127.0.0.1", Port: "some-port"},
 					{IP: "some-ip", Port: "22"},
 				}
+				expectProvisionLog()
 				gomock.InOrder(
-
 					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
 					mockSSH.EXPECT().RunSSHCommand("if [ -e /var/pcfdev/provision-options.json ]; then exit 0; else exit 1; fi",
 						sshAddresses,
@@ -214,21 +263,123 @@ var _ = Describe("Unprovisioned", func() {
 						30*time.Second,
 					).Return(`{"domain":"some-domain","ip":"some-ip","services":"some-service,some-other-service","registries":["some-registry","some-other-registry"],"provider":"some-provider"}`, nil),
 					mockUI.EXPECT().Say("Provisioning VM..."),
-					mockSSH.EXPECT().RunSSHCommand(
+					mockSSH.EXPECT().RunSSHCommandUntil(
 						`sudo -H /var/pcfdev/provision "some-domain" "some-ip" "some-service,some-other-service" "some-registry,some-other-registry" "some-provider"`,
 						sshAddresses,
 						[]byte("some-private-key"),
-						5*time.Minute,
-						os.Stdout,
-						os.Stderr,
+						30*time.Second,
+						gomock.Any(),
+						gomock.Any(),
+						gomock.Any(),
 					),
-					mockHelpText.EXPECT().Print("some-domain", true),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockHelpText.EXPECT().Print("some-domain", conf.DefaultCredentials(), true),
 				)
 
 				Expect(unprovisioned.Provision(&vm.StartOpts{Target: true})).To(Succeed())
 			})
 		})
 
+		Context("when the provision script reports its progress", func() {
+			It("should print the phases and service counts and save the full output", func() {
+				expectProvisionLog()
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+					mockSSH.EXPECT().GetSSHOutput(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(`{"domain":"some-domain","ip":"some-ip"}`, nil),
+					mockUI.EXPECT().Say("Provisioning VM..."),
+					mockSSH.EXPECT().RunSSHCommandUntil(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Do(writeProvisionOutput("some-setup-output\nWaiting for services to start...\n7 out of 58 running\n7 out of 58 running\n58 out of 58 running\nServices started\n", "some-warning\n")),
+					mockUI.EXPECT().Say("Waiting for services to start..."),
+					mockUI.EXPECT().Say("%s services running", "7 of 58"),
+					mockUI.EXPECT().Say("%s services running", "58 of 58"),
+					mockUI.EXPECT().Say("Services started"),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockHelpText.EXPECT().Print("some-domain", conf.DefaultCredentials(), false),
+				)
+
+				Expect(unprovisioned.Provision(&vm.StartOpts{})).To(Succeed())
+				Expect(provisionLog.String()).To(ContainSubstring("some-setup-output\nWaiting for services to start...\n7 out of 58 running\n"))
+				Expect(provisionLog.String()).To(ContainSubstring("some-warning\n"))
+			})
+		})
+
+		Context("when provisioning takes longer than the provision timeout", func() {
+			It("should stop provisioning and return a timeout error", func() {
+				expectProvisionLog()
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+					mockSSH.EXPECT().GetSSHOutput(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(`{"domain":"some-domain","ip":"some-ip"}`, nil),
+					mockUI.EXPECT().Say("Provisioning VM..."),
+					mockSSH.EXPECT().RunSSHCommandUntil(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, _ io.Writer, _ io.Writer, stop <-chan struct{}) {
+							<-stop
+						}).Return(ssh.ErrInterrupted),
+				)
+
+				Expect(unprovisioned.Provision(&vm.StartOpts{ProvisionTimeout: 10 * time.Millisecond})).To(MatchError(
+					"failed to provision VM: provisioning did not finish within 10ms, the full output was saved to " + provisionLogPath + ". Run 'cf dev start -p --provision-timeout DURATION' to try again with a longer timeout",
+				))
+			})
+
+			Context("when provisioning finishes as the timeout passes", func() {
+				It("should succeed", func() {
+					expectProvisionLog()
+					gomock.InOrder(
+						mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+						mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+						mockSSH.EXPECT().GetSSHOutput(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(`{"domain":"some-domain","ip":"some-ip"}`, nil),
+						mockUI.EXPECT().Say("Provisioning VM..."),
+						mockSSH.EXPECT().RunSSHCommandUntil(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+							Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, _ io.Writer, _ io.Writer, stop <-chan struct{}) {
+								<-stop
+							}),
+						mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+						mockHelpText.EXPECT().Print("some-domain", conf.DefaultCredentials(), false),
+					)
+
+					Expect(unprovisioned.Provision(&vm.StartOpts{ProvisionTimeout: 10 * time.Millisecond})).To(Succeed())
+				})
+			})
+		})
+
+		Context("when the provision script fails", func() {
+			It("should return an error and point to the saved output", func() {
+				expectProvisionLog()
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+					mockSSH.EXPECT().GetSSHOutput(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(`{"domain":"some-domain","ip":"some-ip"}`, nil),
+					mockUI.EXPECT().Say("Provisioning VM..."),
+					mockSSH.EXPECT().RunSSHCommandUntil(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some-error")),
+					mockUI.EXPECT().Say("The full provision output was saved to %s.", provisionLogPath),
+				)
+
+				Expect(unprovisioned.Provision(&vm.StartOpts{})).To(MatchError("failed to provision VM: some-error"))
+			})
+		})
+
+		Context("when the provision output cannot be saved", func() {
+			It("should warn and still provision the VM", func() {
+				mockFS.EXPECT().Write(provisionLogPath, gomock.Any(), false).Return(errors.New("some-error"))
+				gomock.InOrder(
+					mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+					mockSSH.EXPECT().RunSSHCommand(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()),
+					mockSSH.EXPECT().GetSSHOutput(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(`{"domain":"some-domain","ip":"some-ip"}`, nil),
+					mockUI.EXPECT().Say("Provisioning VM..."),
+					mockSSH.EXPECT().RunSSHCommandUntil(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Do(writeProvisionOutput("Services started\n", "")),
+					mockUI.EXPECT().Say("Services started"),
+					mockUI.EXPECT().Say("Could not save the provision output to %s: %s", provisionLogPath, errors.New("some-error")),
+					mockClient.EXPECT().Credentials("some-ip", []byte("some-private-key")).Return(conf.DefaultCredentials(), nil),
+					mockHelpText.EXPECT().Print("some-domain", conf.DefaultCredentials(), false),
+				)
+
+				Expect(unprovisioned.Provision(&vm.StartOpts{})).To(Succeed())
+			})
+		})
+
 		Context("when reading the private key fails", func() {
 			It("should return an error", func() {
 				mockFS.EXPECT().Read("some-private-key-path").Return(nil, errors.New("some-error"))
@@ -326,7 +477,7 @@ var _ = Describe("Unprovisioned", func() {
 
 	Describe("Target", func() {
 		It("should return an error", func() {
-			Expect(unprovisioned.Target(false)).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+			Expect(unprovisioned.Target(&vm.TargetOpts{})).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
 		})
 	})
 
@@ -372,20 +523,132 @@ var _ = Describe("Unprovisioned", func() {
 		})
 	})
 
+	Describe("Logs", func() {
+		It("should print the logs", func() {
+			addresses := []ssh.SSHAddress{
+				{IP: "127.0.0.1", Port: "some-port"},
+				{IP: "some-ip", Port: "22"},
+			}
+			_, _, stderr := term.StdStreams()
+
+			gomock.InOrder(
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil),
+				mockSSH.EXPECT().RunSSHCommandUntil("sudo sh -c 'tail -v -n 100 -F /var/pcfdev/provision.log /var/pcfdev/reset.log'", addresses, []byte("some-private-key"), 30*time.Second, gomock.Any(), stderr, gomock.Any()).
+					Do(func(_ string, _ []ssh.SSHAddress, _ []byte, _ time.Duration, stdout io.Writer, _ io.Writer, _ <-chan struct{}) {
+						io.WriteString(stdout, "==> /var/pcfdev/provision.log <==\nsome-line\n")
+					}),
+				mockUI.EXPECT().Say("[%s] %s", "provision.log", "some-line"),
+			)
+
+			Expect(unprovisioned.Logs(&vm.LogsOpts{Follow: true})).To(Succeed())
+		})
+
+		Context("when streaming the logs fails", func() {
+			It("should return an error", func() {
+				mockFS.EXPECT().Read("some-private-key-path").Return([]byte("some-private-key"), nil)
+				mockSSH.EXPECT().RunSSHCommandUntil(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("some-error"))
+
+				Expect(unprovisioned.Logs(&vm.LogsOpts{})).To(MatchError("failed to stream logs: some-error"))
+			})
+		})
+	})
+
 	Describe("GetDebugLogs", func() {
-		It("should succeed", func() {
-			mockLogFetcher.EXPECT().FetchLogs()
+		It("should fetch the logs and report where they were written and what was redacted", func() {
+			gomock.InOrder(
+				mockLogFetcher.EXPECT().FetchLogs(&debug.FetchOpts{Output: "some-output.tgz", Timeout: 5 * time.Second, Components: []string{"some-component"}, Since: time.Hour, MaxSize: 1024, Pseudonymize: true}).Return(&debug.Result{
+					Redactions:  map[string]int{"uri": 2, "email": 1},
+					MappingPath: "some-output-pseudonyms.json",
+				}, nil),
+				mockUI.EXPECT().Say("Debug logs written to %s. While some scrubbing has taken place, please remove any remaining sensitive information from these logs before sharing.", "some-output.tgz"),
+				mockUI.EXPECT().Say("Redacted: %s.", "1 email, 2 uri"),
+				mockUI.EXPECT().Say("The original values behind the pseudonyms were written to %s. Keep this file private, it is not part of the tarball.", "some-output-pseudonyms.json"),
+			)
 
-			Expect(unprovisioned.GetDebugLogs()).To(Succeed())
+			Expect(unprovisioned.GetDebugLogs(&vm.DebugOpts{Output: "some-output.tgz", Timeout: 5 * time.Second, Components: []string{"some-component"}, Since: time.Hour, MaxSize: 1024, Pseudonymize: true})).To(Succeed())
 		})
 
 		Context("when fetching logs fails", func() {
 			It("should return the error", func() {
-				mockLogFetcher.EXPECT().FetchLogs().Return(errors.New("some-error"))
+				mockLogFetcher.EXPECT().FetchLogs(&debug.FetchOpts{Output: "some-output.tgz", Timeout: 5 * time.Second, Components: []string{"some-component"}, Since: time.Hour, MaxSize: 1024}).Return(nil, errors.New("some-error"))
+
+				Expect(unprovisioned.GetDebugLogs(&vm.DebugOpts{Output: "some-output.tgz", Timeout: 5 * time.Second, Components: []string{"some-component"}, Since: time.Hour, MaxSize: 1024})).To(MatchError("failed to retrieve logs: some-error"))
+			})
+		})
+	})
+
+	Describe("ResizeDisk", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.ResizeDisk(uint64(81920))).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+	Describe("AddShare", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.AddShare(&conf.SharedFolder{GuestPath: "/some-guest-path"})).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
 
-				Expect(unprovisioned.GetDebugLogs()).To(MatchError("failed to retrieve logs: some-error"))
+	Describe("RemoveShare", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.RemoveShare("/some-guest-path")).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("ListShares", func() {
+		It("should list the shared folders", func() {
+			gomock.InOrder(
+				mockVBox.EXPECT().SharedFolders(unprovisioned.VMConfig).Return([]*conf.SharedFolder{
+					{HostPath: "some-host-path", GuestPath: "/some-guest-path"},
+					{HostPath: "some-other-host-path", GuestPath: "/some-other-guest-path", ReadOnly: true},
+				}, nil),
+				mockUI.EXPECT().Say("some-host-path -> /some-guest-path"),
+				mockUI.EXPECT().Say("some-other-host-path -> /some-other-guest-path (read-only)"),
+			)
+
+			Expect(unprovisioned.ListShares()).To(Succeed())
+		})
+
+		Context("when there are no shared folders", func() {
+			It("should say a message", func() {
+				gomock.InOrder(
+					mockVBox.EXPECT().SharedFolders(unprovisioned.VMConfig).Return([]*conf.SharedFolder{}, nil),
+					mockUI.EXPECT().Say("No shared folders."),
+				)
+
+				Expect(unprovisioned.ListShares()).To(Succeed())
+			})
+		})
+
+		Context("when retrieving the shared folders fails", func() {
+			It("should return an error", func() {
+				mockVBox.EXPECT().SharedFolders(unprovisioned.VMConfig).Return(nil, errors.New("some-error"))
+
+				Expect(unprovisioned.ListShares()).To(MatchError("failed to update shared folders: some-error"))
 			})
 		})
 	})
 
+	Describe("Backup", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.Backup("some-backup-path")).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("Restore", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.Restore("some-backup-path")).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("Seed", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.Seed("some-seed-path")).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
+
+	Describe("RotatePassword", func() {
+		It("should return an error", func() {
+			Expect(unprovisioned.RotatePassword("some-password")).To(MatchError("PCF Dev is in an invalid state. Please run 'cf dev destroy' or 'cf dev stop'"))
+		})
+	})
 })
@@ -2,10 +2,14 @@ package vm
 
 import (
 	"io"
+	"os"
 	"time"
 
 	"github.com/pivotal-cf/pcfdev-cli/config"
+	"github.com/pivotal-cf/pcfdev-cli/debug"
 	"github.com/pivotal-cf/pcfdev-cli/ssh"
+	"github.com/pivotal-cf/pcfdev-cli/system"
+	"github.com/pivotal-cf/pcfdev-cli/vboxdriver"
 )
 
 //go:generate mockgen -package mocks -destination mocks/vbox.go github.com/pivotal-cf/pcfdev-cli/vm VBox
@@ -19,6 +23,14 @@ type VBox interface {
 	ImportVM(vmConfig *config.VMConfig) error
 	VMStatus(vmName string) (state string, err error)
 	VMConfig(vmName string) (vmConfig *config.VMConfig, err error)
+	DiskSize(vmConfig *config.VMConfig) (size uint64, err error)
+	ResizeDisk(vmConfig *config.VMConfig, size uint64) error
+	SharedFolders(vmConfig *config.VMConfig) (sharedFolders []*config.SharedFolder, err error)
+	AddSharedFolder(vmConfig *config.VMConfig, sharedFolder *config.SharedFolder) error
+	RemoveSharedFolder(vmConfig *config.VMConfig, guestPath string) error
+	MountSharedFolders(vmConfig *config.VMConfig) error
+	RecordMasterPassword(vmConfig *config.VMConfig) error
+	Version() (version *vboxdriver.VBoxDriverVersion, err error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/ui.go github.com/pivotal-cf/pcfdev-cli/vm UI
@@ -27,6 +39,7 @@ type UI interface {
 	Say(message string, args ...interface{})
 	Confirm(message string) bool
 	Ask(prompt string) (answer string)
+	AskForPassword(prompt string) (answer string)
 }
 
 //go:generate mockgen -package mocks -destination mocks/ssh.go github.com/pivotal-cf/pcfdev-cli/vm SSH
@@ -35,7 +48,9 @@ type SSH interface {
 	StartSSHSession(addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
 	WaitForSSH(addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration) error
 	RunSSHCommand(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, stdout io.Writer, stderr io.Writer) error
+	RunSSHCommandWithStdin(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
 	GetSSHOutput(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration) (combinedOutput string, err error)
+	RunSSHCommandUntil(command string, addresses []ssh.SSHAddress, privateKey []byte, timeout time.Duration, stdout io.Writer, stderr io.Writer, stop <-chan struct{}) error
 }
 
 //go:generate mockgen -package mocks -destination mocks/vm.go github.com/pivotal-cf/pcfdev-cli/vm VM
@@ -46,10 +61,19 @@ type VM interface {
 	Status() string
 	Suspend() error
 	Resume() error
-	GetDebugLogs() error
+	GetDebugLogs(opts *DebugOpts) error
+	Logs(opts *LogsOpts) error
 	Trust(*StartOpts) error
-	Target(autoTarget bool) error
+	Target(*TargetOpts) error
 	SSH() error
+	ResizeDisk(size uint64) error
+	AddShare(sharedFolder *config.SharedFolder) error
+	RemoveShare(guestPath string) error
+	ListShares() error
+	Backup(path string) error
+	Restore(path string) error
+	Seed(path string) error
+	RotatePassword(password string) error
 
 	VerifyStartOpts(*StartOpts) error
 }
@@ -68,6 +92,7 @@ type CertStore interface {
 type Client interface {
 	Status(host string, privateKey []byte) (string, error)
 	ReplaceSecrets(host, password string, privateKey []byte) error
+	Credentials(host string, privateKey []byte) (*config.Credentials, error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/fs.go github.com/pivotal-cf/pcfdev-cli/vm FS
@@ -75,14 +100,17 @@ type FS interface {
 	Remove(path string) error
 	Exists(path string) (exists bool, err error)
 	Write(path string, contents io.Reader, append bool) error
+	Chmod(path string, mode os.FileMode) error
 	Read(path string) (contents []byte, err error)
 	Compress(name string, path string, contentPaths []string) error
+	Extract(archivePath string, destinationPath string, pattern string) error
+	Open(path string) (file io.ReadCloser, err error)
 	TempDir() (tempDir string, err error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/log_fetcher.go github.com/pivotal-cf/pcfdev-cli/vm LogFetcher
 type LogFetcher interface {
-	FetchLogs() error
+	FetchLogs(opts *debug.FetchOpts) (*debug.Result, error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/driver.go github.com/pivotal-cf/pcfdev-cli/vm Driver
@@ -95,9 +123,20 @@ type CmdRunner interface {
 	Run(command string, args ...string) (output []byte, err error)
 }
 
+//go:generate mockgen -package mocks -destination mocks/seeder.go github.com/pivotal-cf/pcfdev-cli/vm Seeder
+type Seeder interface {
+	Seed(manifest []byte) error
+}
+
 //go:generate mockgen -package mocks -destination mocks/help_text.go github.com/pivotal-cf/pcfdev-cli/vm HelpText
 type HelpText interface {
-	Print(domain string, autoTarget bool)
+	Print(domain string, credentials *config.Credentials, autoTarget bool)
+}
+
+//go:generate mockgen -package mocks -destination mocks/system.go github.com/pivotal-cf/pcfdev-cli/vm System
+type System interface {
+	CheckDiskSpace(path string, required uint64) error
+	Resources() (*system.Resources, error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/network.go github.com/pivotal-cf/pcfdev-cli/vm Network
@@ -106,16 +145,53 @@ type Network interface {
 }
 
 type StartOpts struct {
-	CPUs           int
-	Memory         uint64
-	NoProvision    bool
-	OVAPath        string
-	Registries     string
-	Services       string
-	Trust          bool
-	PrintCA        bool
-	Target         bool
-	IP             string
-	Domain         string
-	MasterPassword string
+	CPUs             int
+	Memory           uint64
+	NoProvision      bool
+	OVAPath          string
+	Registries       string
+	Services         string
+	Trust            bool
+	PrintCA          bool
+	Target           bool
+	IP               string
+	Domain           string
+	MasterPassword   string
+	Network          string
+	BridgeAdapter    string
+	ProvisionTimeout time.Duration
+}
+
+type DebugOpts struct {
+	Output       string
+	Timeout      time.Duration
+	Components   []string
+	Since        time.Duration
+	MaxSize      uint64
+	Pseudonymize bool
+}
+
+func (o *DebugOpts) fetchOpts() *debug.FetchOpts {
+	return &debug.FetchOpts{
+		Output:       o.Output,
+		Timeout:      o.Timeout,
+		Components:   o.Components,
+		Since:        o.Since,
+		MaxSize:      o.MaxSize,
+		Pseudonymize: o.Pseudonymize,
+	}
+}
+
+type LogsOpts struct {
+	Follow     bool
+	Components []string
+	Scrub      bool
+	Stop       <-chan struct{}
+}
+
+type TargetOpts struct {
+	AutoTarget bool
+	User       string
+	Org        string
+	Space      string
 }